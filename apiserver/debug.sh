@@ -62,7 +62,7 @@ echo ""
 echo "===== Environment Variables ====="
 echo "Run the following commands to enable debug mode:"
 echo "export DASHBOARD_BYPASS_AUTH=true"
-echo "export DASHBOARD_DEBUG=true"
+echo "export DASHBOARD_LOG_LEVEL=debug"
 echo ""
 echo "Run the following command to start the application:"
 echo "cd .. && make dev-backend"
\ No newline at end of file
@@ -2,23 +2,91 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/config"
+	"open-cluster-management-io/lab/apiserver/pkg/logging"
 	"open-cluster-management-io/lab/apiserver/pkg/server"
+	"open-cluster-management-io/lab/apiserver/pkg/tracing"
 )
 
 func main() {
-	// Check if debug mode is enabled
-	debugMode := os.Getenv("DASHBOARD_DEBUG") == "true"
+	// Serve until the process is asked to stop
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(os.Args[1:], ctx)
+	stop()
+	os.Exit(code)
+}
+
+// run serves the dashboard API configured by the command-line arguments args
+// until ctx is done, and returns the exit code of the process
+func run(args []string, ctx context.Context) int {
+	// Load the configuration from the file, the environment and the flags
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading configuration:", err)
+		return 2
+	}
+	configs := config.NewManager(cfg, args)
+
+	// Configure structured logging, debug level also enables gin debug mode
+	if err := logging.Configure(cfg.Log.Level, cfg.Log.Format); err != nil {
+		fmt.Fprintln(os.Stderr, "Error configuring logging:", err)
+		return 2
+	}
+	for _, warning := range cfg.Warnings() {
+		slog.Warn(warning)
+	}
+	slog.Info("Configuration loaded", "file", cfg.File())
+
+	// Configure tracing before any client is created
+	shutdownTracing, err := tracing.Setup(ctx)
+	if err != nil {
+		slog.Error("Error setting up tracing", "error", err)
+		return 1
+	}
+	defer shutdownTracing(context.Background())
 
-	// Create a context
-	ctx := context.Background()
+	// Apply the reloadable settings whenever the configuration file changes
+	client.SetCapabilitiesRetryInterval(cfg.Cache.CapabilitiesRetryInterval.Duration)
+	configs.OnReload(func(cfg *config.Config) {
+		if level, err := logging.ParseLevel(cfg.Log.Level); err == nil {
+			logging.SetLevel(level)
+		}
+		client.SetCapabilitiesRetryInterval(cfg.Cache.CapabilitiesRetryInterval.Duration)
+	})
+	go configs.Watch(ctx)
 
-	// Initialize Kubernetes client
-	ocmClient := client.CreateKubernetesClient()
+	// Initialize the clients of every configured hub, or the sample data hub
+	client.SetInformerResyncPeriod(cfg.Cache.InformerResyncPeriod.Duration)
+	var hubs []client.Hub
+	if cfg.Features.Mock {
+		slog.Warn("Serving sample data, no hub is connected")
+		hubs = []client.Hub{{Name: client.DefaultHubName, Source: client.HubSourceMock, Client: client.CreateMockClient()}}
+	} else {
+		var err error
+		hubs, err = client.CreateHubs(cfg.HubSpecs(), cfg.Kubeconfig)
+		if err != nil {
+			slog.Error("Error creating hub clients", "error", err)
+			return 1
+		}
+	}
 
 	// Set up and run the server
-	r := server.SetupServer(ocmClient, ctx, debugMode)
-	server.RunServer(r)
+	r := server.SetupConfiguredServer(hubs, ctx, configs)
+	if err := server.RunConfiguredServer(r, cfg, ctx); err != nil {
+		slog.Error("Server stopped", "error", err)
+		return 1
+	}
+	return 0
 }
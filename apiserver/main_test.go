@@ -1,64 +1,68 @@
 package main
 
 import (
-	"os"
+	"context"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
-func TestMain(t *testing.T) {
+func TestRun(t *testing.T) {
 	tests := []struct {
-		name        string
-		debugMode   string
-		useMock     string
-		expectPanic bool
+		name         string
+		debugMode    string
+		useMock      string
+		args         []string
+		expectedCode int
 	}{
 		{
-			name:        "debug mode enabled",
-			debugMode:   "true",
-			useMock:     "true",
-			expectPanic: false,
+			name:         "debug mode enabled",
+			debugMode:    "true",
+			useMock:      "true",
+			expectedCode: 0,
 		},
 		{
-			name:        "debug mode disabled",
-			debugMode:   "false",
-			useMock:     "true",
-			expectPanic: false,
+			name:         "debug mode disabled",
+			debugMode:    "false",
+			useMock:      "true",
+			expectedCode: 0,
 		},
 		{
-			name:        "no environment variables",
-			debugMode:   "",
-			useMock:     "true",
-			expectPanic: false,
+			name:         "no environment variables",
+			debugMode:    "",
+			useMock:      "true",
+			expectedCode: 0,
+		},
+		{
+			name:         "unknown flag",
+			useMock:      "true",
+			args:         []string{"--unknown"},
+			expectedCode: 2,
+		},
+		{
+			name:         "help",
+			useMock:      "true",
+			args:         []string{"--help"},
+			expectedCode: 0,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			os.Setenv("DASHBOARD_DEBUG", tt.debugMode)
-			os.Setenv("DASHBOARD_USE_MOCK", tt.useMock)
-			defer func() {
-				os.Unsetenv("DASHBOARD_DEBUG")
-				os.Unsetenv("DASHBOARD_USE_MOCK")
-			}()
+			t.Setenv("DASHBOARD_DEBUG", tt.debugMode)
+			t.Setenv("DASHBOARD_USE_MOCK", tt.useMock)
+
+			args := append([]string{
+				"--listen-address", "127.0.0.1:0",
+				"--history-db", filepath.Join(t.TempDir(), "history.db"),
+			}, tt.args...)
 
-			if tt.expectPanic {
-				assert.Panics(t, func() {
-					main()
-				})
-			} else {
-				assert.NotPanics(t, func() {
-					defer func() {
-						if r := recover(); r != nil {
-							if r != "test exit" {
-								panic(r)
-							}
-						}
-					}()
-					main()
-				})
-			}
+			// The server runs until the context is done
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			assert.Equal(t, tt.expectedCode, run(args, ctx))
 		})
 	}
 }
@@ -0,0 +1,73 @@
+package apirequest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DiscoveryResource labels requests that do not target a resource, such as
+// API discovery and /version
+const DiscoveryResource = "discovery"
+
+// ResourceAndVerb derives the resource (resource[/subresource][.group])
+// and the Kubernetes verb from a request path such as
+// /apis/cluster.open-cluster-management.io/v1beta1/namespaces/ns/placements/name
+func ResourceAndVerb(req *http.Request) (string, string) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	var group string
+	var rest []string
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		rest = segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		group = segments[1]
+		rest = segments[3:]
+	}
+	if len(rest) == 0 {
+		return DiscoveryResource, strings.ToLower(req.Method)
+	}
+
+	// Strip the namespace scope, a namespace itself is addressed as namespaces/name
+	if rest[0] == "namespaces" && len(rest) >= 3 {
+		rest = rest[2:]
+	}
+
+	resource := rest[0]
+	if len(rest) >= 3 {
+		resource += "/" + rest[2]
+	}
+	if group != "" {
+		resource += "." + group
+	}
+
+	return resource, requestVerb(req, len(rest) >= 2)
+}
+
+// requestVerb maps the HTTP method to the Kubernetes verb
+func requestVerb(req *http.Request, named bool) string {
+	switch req.Method {
+	case http.MethodGet:
+		switch {
+		case req.URL.Query().Get("watch") == "true":
+			return "watch"
+		case named:
+			return "get"
+		default:
+			return "list"
+		}
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		if !named {
+			return "deletecollection"
+		}
+		return "delete"
+	default:
+		return strings.ToLower(req.Method)
+	}
+}
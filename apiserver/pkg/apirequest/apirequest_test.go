@@ -0,0 +1,105 @@
+package apirequest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceAndVerb(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		url              string
+		expectedResource string
+		expectedVerb     string
+	}{
+		{
+			name:             "list cluster scoped",
+			method:           http.MethodGet,
+			url:              "/apis/cluster.open-cluster-management.io/v1/managedclusters",
+			expectedResource: "managedclusters.cluster.open-cluster-management.io",
+			expectedVerb:     "list",
+		},
+		{
+			name:             "get namespaced",
+			method:           http.MethodGet,
+			url:              "/apis/cluster.open-cluster-management.io/v1beta1/namespaces/default/placements/p1",
+			expectedResource: "placements.cluster.open-cluster-management.io",
+			expectedVerb:     "get",
+		},
+		{
+			name:             "list across namespaces",
+			method:           http.MethodGet,
+			url:              "/apis/work.open-cluster-management.io/v1/manifestworks",
+			expectedResource: "manifestworks.work.open-cluster-management.io",
+			expectedVerb:     "list",
+		},
+		{
+			name:             "watch",
+			method:           http.MethodGet,
+			url:              "/apis/cluster.open-cluster-management.io/v1/managedclusters?watch=true",
+			expectedResource: "managedclusters.cluster.open-cluster-management.io",
+			expectedVerb:     "watch",
+		},
+		{
+			name:             "update subresource",
+			method:           http.MethodPut,
+			url:              "/apis/work.open-cluster-management.io/v1/namespaces/cluster1/manifestworks/w1/status",
+			expectedResource: "manifestworks/status.work.open-cluster-management.io",
+			expectedVerb:     "update",
+		},
+		{
+			name:             "create core resource",
+			method:           http.MethodPost,
+			url:              "/apis/authentication.k8s.io/v1/tokenreviews",
+			expectedResource: "tokenreviews.authentication.k8s.io",
+			expectedVerb:     "create",
+		},
+		{
+			name:             "get namespace",
+			method:           http.MethodGet,
+			url:              "/api/v1/namespaces/cluster1",
+			expectedResource: "namespaces",
+			expectedVerb:     "get",
+		},
+		{
+			name:             "list core namespaced",
+			method:           http.MethodGet,
+			url:              "/api/v1/namespaces/cluster1/secrets",
+			expectedResource: "secrets",
+			expectedVerb:     "list",
+		},
+		{
+			name:             "delete",
+			method:           http.MethodDelete,
+			url:              "/apis/addon.open-cluster-management.io/v1alpha1/namespaces/cluster1/managedclusteraddons/a1",
+			expectedResource: "managedclusteraddons.addon.open-cluster-management.io",
+			expectedVerb:     "delete",
+		},
+		{
+			name:             "discovery",
+			method:           http.MethodGet,
+			url:              "/apis/cluster.open-cluster-management.io/v1",
+			expectedResource: DiscoveryResource,
+			expectedVerb:     "get",
+		},
+		{
+			name:             "version",
+			method:           http.MethodGet,
+			url:              "/version",
+			expectedResource: DiscoveryResource,
+			expectedVerb:     "get",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, verb := ResourceAndVerb(httptest.NewRequest(tt.method, tt.url, nil))
+			assert.Equal(t, tt.expectedResource, resource)
+			assert.Equal(t, tt.expectedVerb, verb)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package client
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// Features of the dashboard, each backed by OCM resources the hub may or may not serve
+const (
+	FeatureClusters                = "clusters"
+	FeatureClusterSets             = "clusterSets"
+	FeaturePlacements              = "placements"
+	FeatureManifestWorks           = "manifestWorks"
+	FeatureManifestWorkReplicaSets = "manifestWorkReplicaSets"
+	FeatureAddons                  = "addons"
+	FeatureAddonConfigs            = "addonConfigs"
+	FeatureAddonTemplates          = "addonTemplates"
+)
+
+// Features lists every feature in the order it is reported
+var Features = []string{
+	FeatureClusters,
+	FeatureClusterSets,
+	FeaturePlacements,
+	FeatureManifestWorks,
+	FeatureManifestWorkReplicaSets,
+	FeatureAddons,
+	FeatureAddonConfigs,
+	FeatureAddonTemplates,
+}
+
+// FeatureResources maps every feature to the resources it needs
+var FeatureResources = map[string][]schema.GroupVersionResource{
+	FeatureClusters:                {ManagedClusterResource},
+	FeatureClusterSets:             {ManagedClusterSetResource, ManagedClusterSetBindingResource},
+	FeaturePlacements:              {PlacementResource, PlacementDecisionResource},
+	FeatureManifestWorks:           {ManifestWorkResource},
+	FeatureManifestWorkReplicaSets: {ManifestWorkReplicaSetResource},
+	FeatureAddons:                  {ManagedClusterAddonResource, ClusterManagementAddonResource},
+	FeatureAddonConfigs:            {AddOnDeploymentConfigResource},
+	FeatureAddonTemplates:          {AddOnTemplateResource},
+}
+
+// SupportedVersions lists the versions of every resource the dashboard can
+// read, in order of preference. The most preferred version the hub serves is
+// selected, and the clients of the dashboard are routed to it through
+// OCMClient.SelectedResource. ManagedClusterSets and their bindings served only
+// in v1beta1 are converted to v1beta2.
+var SupportedVersions = map[schema.GroupResource][]string{
+	ManagedClusterResource.GroupResource():           {"v1"},
+	ManagedClusterSetResource.GroupResource():        {"v1beta2", "v1beta1"},
+	ManagedClusterSetBindingResource.GroupResource(): {"v1beta2", "v1beta1"},
+	PlacementResource.GroupResource():                {"v1beta1"},
+	PlacementDecisionResource.GroupResource():        {"v1beta1"},
+	ManifestWorkResource.GroupResource():             {"v1"},
+	ManifestWorkReplicaSetResource.GroupResource():   {"v1alpha1"},
+	ManagedClusterAddonResource.GroupResource():      {"v1alpha1"},
+	ClusterManagementAddonResource.GroupResource():   {"v1alpha1"},
+	AddOnDeploymentConfigResource.GroupResource():    {"v1alpha1"},
+	AddOnTemplateResource.GroupResource():            {"v1alpha1"},
+}
+
+// DefaultCapabilitiesRetryInterval limits how often discovery is repeated while
+// the hub is unreachable or a feature is unavailable
+const DefaultCapabilitiesRetryInterval = 30 * time.Second
+
+// capabilitiesRetryInterval holds the retry interval in use, it can be changed
+// while the server runs
+var capabilitiesRetryInterval atomic.Int64
+
+func init() {
+	capabilitiesRetryInterval.Store(int64(DefaultCapabilitiesRetryInterval))
+}
+
+// SetCapabilitiesRetryInterval changes how often discovery is repeated while the
+// hub is unreachable or a feature is unavailable
+func SetCapabilitiesRetryInterval(interval time.Duration) {
+	capabilitiesRetryInterval.Store(int64(interval))
+}
+
+// ResourceVersions holds the discovered versions of a resource
+type ResourceVersions struct {
+	Resource schema.GroupResource
+	// Served lists the versions served by the hub
+	Served []string
+	// Selected is the most preferred supported version the hub serves, empty
+	// when it serves none
+	Selected string
+}
+
+// Capabilities records which OCM resources the hub serves, and the supported
+// version selected for each. They are detected at startup and detected again,
+// at most every retry interval, while the hub is unreachable or a feature is
+// unavailable, so that CRDs installed later are picked up.
+type Capabilities struct {
+	discovery discovery.DiscoveryInterface
+
+	mu          sync.RWMutex
+	detected    bool
+	err         error
+	lastAttempt time.Time
+	resources   map[schema.GroupResource]ResourceVersions
+}
+
+// NewCapabilities creates capabilities detected through the given discovery client
+func NewCapabilities(discoveryClient discovery.DiscoveryInterface) *Capabilities {
+	return &Capabilities{discovery: discoveryClient}
+}
+
+// Detect queries discovery for the served OCM group versions and selects the
+// most preferred supported version of every resource
+func (c *Capabilities) Detect() error {
+	resources, err := discoverResources(c.discovery)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastAttempt = time.Now()
+	c.err = err
+	if err != nil {
+		return err
+	}
+	c.detected = true
+	c.resources = resources
+	return nil
+}
+
+// discoverResources lists the served versions of every resource in Resources
+func discoverResources(discoveryClient discovery.DiscoveryInterface) (map[schema.GroupResource]ResourceVersions, error) {
+	groups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API groups: %w", err)
+	}
+
+	groupVersions := make(map[string][]string)
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			groupVersions[group.Name] = append(groupVersions[group.Name], version.Version)
+		}
+	}
+
+	// Resources served by each group version, listed once per group version
+	served := make(map[schema.GroupVersion]map[string]bool)
+	resources := make(map[schema.GroupResource]ResourceVersions, len(Resources))
+	for _, gvr := range Resources {
+		groupResource := gvr.GroupResource()
+		versions := ResourceVersions{Resource: groupResource}
+
+		for _, version := range groupVersions[gvr.Group] {
+			groupVersion := schema.GroupVersion{Group: gvr.Group, Version: version}
+			names, ok := served[groupVersion]
+			if !ok {
+				// A group version that cannot be listed is treated as not served,
+				// its resources are detected again later
+				list, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion.String())
+				if err != nil {
+					slog.Warn("Failed to list served resources", "groupVersion", groupVersion.String(), "error", err)
+				} else {
+					names = make(map[string]bool, len(list.APIResources))
+					for _, resource := range list.APIResources {
+						names[resource.Name] = true
+					}
+				}
+				served[groupVersion] = names
+			}
+			if names[gvr.Resource] {
+				versions.Served = append(versions.Served, version)
+			}
+		}
+
+		for _, version := range SupportedVersions[groupResource] {
+			if contains(versions.Served, version) {
+				versions.Selected = version
+				break
+			}
+		}
+		resources[groupResource] = versions
+	}
+	return resources, nil
+}
+
+// refresh detects the capabilities again if the last detection failed or left
+// a feature unavailable, and the retry interval has passed
+func (c *Capabilities) refresh() {
+	// The attempt is recorded up front so that concurrent requests detect once
+	c.mu.Lock()
+	stale := time.Since(c.lastAttempt) >= time.Duration(capabilitiesRetryInterval.Load()) &&
+		(!c.detected || c.missingResourcesLocked())
+	if stale {
+		c.lastAttempt = time.Now()
+	}
+	c.mu.Unlock()
+
+	if stale {
+		if err := c.Detect(); err != nil {
+			slog.Warn("Failed to detect OCM API capabilities", "error", err)
+		}
+	}
+}
+
+func (c *Capabilities) missingResourcesLocked() bool {
+	for _, versions := range c.resources {
+		if versions.Selected == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Detected reports whether detection has succeeded, and the last detection error
+func (c *Capabilities) Detected() (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	c.refresh()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.detected, c.err
+}
+
+// ResourceVersions returns the discovered versions of a resource
+func (c *Capabilities) ResourceVersions(resource schema.GroupResource) (ResourceVersions, bool) {
+	if c == nil {
+		return ResourceVersions{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	versions, ok := c.resources[resource]
+	return versions, ok
+}
+
+// OptionalFeature reports whether the dashboard can run without the feature.
+// Every feature except clusters is optional.
+func OptionalFeature(feature string) bool {
+	return feature != FeatureClusters
+}
+
+// FeatureOf returns the feature a resource belongs to
+func FeatureOf(resource schema.GroupResource) string {
+	for feature, resources := range FeatureResources {
+		for _, gvr := range resources {
+			if gvr.GroupResource() == resource {
+				return feature
+			}
+		}
+	}
+	return ""
+}
+
+// FeatureAvailable reports whether every resource of the feature is served in a
+// supported version, with a message explaining why not. Features are assumed
+// available while the capabilities are unknown, so that a hub unreachable at
+// startup does not disable the dashboard.
+func (c *Capabilities) FeatureAvailable(feature string) (bool, string) {
+	if c == nil {
+		return true, ""
+	}
+	c.refresh()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.detected {
+		return true, ""
+	}
+
+	var reasons []string
+	for _, gvr := range FeatureResources[feature] {
+		versions := c.resources[gvr.GroupResource()]
+		switch {
+		case versions.Selected != "":
+			continue
+		case len(versions.Served) == 0:
+			reasons = append(reasons, fmt.Sprintf("%s is not installed on the hub", gvr.GroupResource()))
+		default:
+			reasons = append(reasons, fmt.Sprintf("%s is served in %s, the dashboard supports %s",
+				gvr.GroupResource(), strings.Join(versions.Served, ", "),
+				strings.Join(SupportedVersions[gvr.GroupResource()], ", ")))
+		}
+	}
+	if len(reasons) > 0 {
+		return false, strings.Join(reasons, "; ")
+	}
+	return true, ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
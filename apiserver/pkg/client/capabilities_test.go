@@ -0,0 +1,178 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// servedResources lists every resource in Resources for fake discovery, skipping
+// the given group versions
+func servedResources(skip ...string) []*metav1.APIResourceList {
+	lists := make(map[string]*metav1.APIResourceList)
+	var result []*metav1.APIResourceList
+	for _, gvr := range Resources {
+		groupVersion := gvr.GroupVersion().String()
+		if contains(skip, groupVersion) {
+			continue
+		}
+		list, ok := lists[groupVersion]
+		if !ok {
+			list = &metav1.APIResourceList{GroupVersion: groupVersion}
+			lists[groupVersion] = list
+			result = append(result, list)
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: gvr.Resource})
+	}
+	return result
+}
+
+func TestCapabilitiesDetect(t *testing.T) {
+	tests := []struct {
+		name                string
+		served              []*metav1.APIResourceList
+		expectedUnavailable map[string]string
+		expectedVersions    map[string]string
+	}{
+		{
+			name:   "all resources served",
+			served: servedResources(),
+			expectedVersions: map[string]string{
+				"managedclustersets": "v1beta2",
+				"placements":         "v1beta1",
+			},
+		},
+		{
+			name:   "addon framework not installed",
+			served: servedResources("addon.open-cluster-management.io/v1alpha1"),
+			expectedUnavailable: map[string]string{
+				FeatureAddons:         "managedclusteraddons.addon.open-cluster-management.io is not installed on the hub; clustermanagementaddons.addon.open-cluster-management.io is not installed on the hub",
+				FeatureAddonConfigs:   "addondeploymentconfigs.addon.open-cluster-management.io is not installed on the hub",
+				FeatureAddonTemplates: "addontemplates.addon.open-cluster-management.io is not installed on the hub",
+			},
+			expectedVersions: map[string]string{"managedclusteraddons": ""},
+		},
+		{
+			name: "older clusterset version",
+			served: func() []*metav1.APIResourceList {
+				lists := servedResources("cluster.open-cluster-management.io/v1beta2")
+				for _, list := range lists {
+					if list.GroupVersion == "cluster.open-cluster-management.io/v1beta1" {
+						list.APIResources = append(list.APIResources,
+							metav1.APIResource{Name: "managedclustersets"}, metav1.APIResource{Name: "managedclustersetbindings"})
+					}
+				}
+				return lists
+			}(),
+			expectedVersions: map[string]string{"managedclustersets": "v1beta1", "placements": "v1beta1"},
+		},
+		{
+			name: "unsupported addon version",
+			served: func() []*metav1.APIResourceList {
+				lists := servedResources()
+				lists = append(lists, &metav1.APIResourceList{
+					GroupVersion: "addon.open-cluster-management.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "managedclusteraddons"}},
+				})
+				for _, list := range lists {
+					if list.GroupVersion == "addon.open-cluster-management.io/v1alpha1" {
+						var resources []metav1.APIResource
+						for _, resource := range list.APIResources {
+							if resource.Name != "managedclusteraddons" {
+								resources = append(resources, resource)
+							}
+						}
+						list.APIResources = resources
+					}
+				}
+				return lists
+			}(),
+			expectedUnavailable: map[string]string{
+				FeatureAddons: "managedclusteraddons.addon.open-cluster-management.io is served in v1beta1, the dashboard supports v1alpha1",
+			},
+			expectedVersions: map[string]string{"managedclusteraddons": "", "managedclustersets": "v1beta2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+			discovery.Resources = tt.served
+
+			capabilities := NewCapabilities(discovery)
+			require.NoError(t, capabilities.Detect())
+
+			detected, err := capabilities.Detected()
+			assert.True(t, detected)
+			assert.NoError(t, err)
+
+			for _, feature := range Features {
+				available, message := capabilities.FeatureAvailable(feature)
+				expected, unavailable := tt.expectedUnavailable[feature]
+				assert.Equal(t, !unavailable, available, feature)
+				assert.Equal(t, expected, message, feature)
+			}
+
+			for _, gvr := range Resources {
+				expected, ok := tt.expectedVersions[gvr.Resource]
+				if !ok {
+					continue
+				}
+				versions, found := capabilities.ResourceVersions(gvr.GroupResource())
+				require.True(t, found)
+				assert.Equal(t, expected, versions.Selected, gvr.Resource)
+			}
+		})
+	}
+}
+
+// unreachableDiscovery fails to list the API groups of the hub
+type unreachableDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (d unreachableDiscovery) ServerGroups() (*metav1.APIGroupList, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestCapabilitiesDetectFailure(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	discovery := unreachableDiscovery{kubeClient.Discovery().(*fakediscovery.FakeDiscovery)}
+
+	capabilities := NewCapabilities(discovery)
+	assert.Error(t, capabilities.Detect())
+
+	// Features are assumed available until detection succeeds
+	detected, err := capabilities.Detected()
+	assert.False(t, detected)
+	assert.ErrorContains(t, err, "connection refused")
+	for _, feature := range Features {
+		available, _ := capabilities.FeatureAvailable(feature)
+		assert.True(t, available, feature)
+	}
+}
+
+func TestNilCapabilities(t *testing.T) {
+	var capabilities *Capabilities
+
+	available, message := capabilities.FeatureAvailable(FeatureAddons)
+	assert.True(t, available)
+	assert.Empty(t, message)
+
+	detected, err := capabilities.Detected()
+	assert.False(t, detected)
+	assert.NoError(t, err)
+}
+
+func TestOptionalFeature(t *testing.T) {
+	assert.False(t, OptionalFeature(FeatureClusters))
+	assert.True(t, OptionalFeature(FeatureAddons))
+	assert.Equal(t, FeatureAddonTemplates, FeatureOf(AddOnTemplateResource.GroupResource()))
+	assert.Equal(t, FeatureClusterSets, FeatureOf(ManagedClusterSetBindingResource.GroupResource()))
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+)
+
+// legacyClusterSetLabel is the v1beta1 name of the ExclusiveClusterSetLabel selector type
+const legacyClusterSetLabel = "LegacyClusterSetLabel"
+
+// SelectedResource returns gvr at the version selected for the hub. The version
+// gvr was built against is kept while the capabilities are unknown or the hub
+// serves no supported version.
+func (c *OCMClient) SelectedResource(gvr schema.GroupVersionResource) schema.GroupVersionResource {
+	if versions, ok := c.Capabilities.ResourceVersions(gvr.GroupResource()); ok && versions.Selected != "" {
+		gvr.Version = versions.Selected
+	}
+	return gvr
+}
+
+// ListManagedClusterSets lists the ManagedClusterSets of the hub as v1beta2, read
+// through the dynamic client when the hub serves an older version
+func (c *OCMClient) ListManagedClusterSets(ctx context.Context, options metav1.ListOptions) (*clusterv1beta2.ManagedClusterSetList, error) {
+	gvr := c.SelectedResource(ManagedClusterSetResource)
+	if gvr == ManagedClusterSetResource {
+		return c.ClusterClient.ClusterV1beta2().ManagedClusterSets().List(ctx, options)
+	}
+
+	list, err := c.Interface.Resource(gvr).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	result := &clusterv1beta2.ManagedClusterSetList{ListMeta: metav1.ListMeta{ResourceVersion: list.GetResourceVersion()}}
+	for i := range list.Items {
+		var set clusterv1beta2.ManagedClusterSet
+		if err := convertClusterSetResource(&list.Items[i], &set); err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, set)
+	}
+	return result, nil
+}
+
+// GetManagedClusterSet reads a ManagedClusterSet of the hub as v1beta2, through
+// the dynamic client when the hub serves an older version
+func (c *OCMClient) GetManagedClusterSet(ctx context.Context, name string) (*clusterv1beta2.ManagedClusterSet, error) {
+	gvr := c.SelectedResource(ManagedClusterSetResource)
+	if gvr == ManagedClusterSetResource {
+		return c.ClusterClient.ClusterV1beta2().ManagedClusterSets().Get(ctx, name, metav1.GetOptions{})
+	}
+
+	obj, err := c.Interface.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	set := &clusterv1beta2.ManagedClusterSet{}
+	return set, convertClusterSetResource(obj, set)
+}
+
+// ListManagedClusterSetBindings lists the ManagedClusterSetBindings of a namespace,
+// or of every namespace when it is empty, as v1beta2. They are read through the
+// dynamic client when the hub serves an older version.
+func (c *OCMClient) ListManagedClusterSetBindings(ctx context.Context, namespace string, options metav1.ListOptions) (*clusterv1beta2.ManagedClusterSetBindingList, error) {
+	gvr := c.SelectedResource(ManagedClusterSetBindingResource)
+	if gvr == ManagedClusterSetBindingResource {
+		return c.ClusterClient.ClusterV1beta2().ManagedClusterSetBindings(namespace).List(ctx, options)
+	}
+
+	list, err := c.Interface.Resource(gvr).Namespace(namespace).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	result := &clusterv1beta2.ManagedClusterSetBindingList{ListMeta: metav1.ListMeta{ResourceVersion: list.GetResourceVersion()}}
+	for i := range list.Items {
+		var binding clusterv1beta2.ManagedClusterSetBinding
+		if err := convertClusterSetResource(&list.Items[i], &binding); err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, binding)
+	}
+	return result, nil
+}
+
+// GetManagedClusterSetBinding reads a ManagedClusterSetBinding as v1beta2, through
+// the dynamic client when the hub serves an older version
+func (c *OCMClient) GetManagedClusterSetBinding(ctx context.Context, namespace, name string) (*clusterv1beta2.ManagedClusterSetBinding, error) {
+	gvr := c.SelectedResource(ManagedClusterSetBindingResource)
+	if gvr == ManagedClusterSetBindingResource {
+		return c.ClusterClient.ClusterV1beta2().ManagedClusterSetBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+
+	obj, err := c.Interface.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	binding := &clusterv1beta2.ManagedClusterSetBinding{}
+	return binding, convertClusterSetResource(obj, binding)
+}
+
+// convertClusterSetResource converts a v1beta1 ManagedClusterSet or binding to
+// v1beta2. The versions only differ in the name of the exclusive label selector.
+func convertClusterSetResource(obj *unstructured.Unstructured, into runtime.Object) error {
+	converted := obj.DeepCopy()
+	converted.SetAPIVersion(clusterv1beta2.GroupVersion.String())
+
+	selectorType, found, _ := unstructured.NestedString(converted.Object, "spec", "clusterSelector", "selectorType")
+	if found && selectorType == legacyClusterSetLabel {
+		if err := unstructured.SetNestedField(converted.Object, string(clusterv1beta2.ExclusiveClusterSetLabel),
+			"spec", "clusterSelector", "selectorType"); err != nil {
+			return err
+		}
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(converted.Object, into); err != nil {
+		return fmt.Errorf("unable to convert %s %q: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+)
+
+func TestClusterSetsServedInV1beta1(t *testing.T) {
+	ctx := context.Background()
+
+	// The hub serves ManagedClusterSets and their bindings only in v1beta1
+	served := servedResources("cluster.open-cluster-management.io/v1beta2")
+	for _, list := range served {
+		if list.GroupVersion == "cluster.open-cluster-management.io/v1beta1" {
+			list.APIResources = append(list.APIResources,
+				metav1.APIResource{Name: "managedclustersets"}, metav1.APIResource{Name: "managedclustersetbindings"})
+		}
+	}
+	discovery := kubefake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = served
+	capabilities := NewCapabilities(discovery)
+	require.NoError(t, capabilities.Detect())
+
+	set := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cluster.open-cluster-management.io/v1beta1",
+		"kind":       "ManagedClusterSet",
+		"metadata":   map[string]interface{}{"name": "prod"},
+		"spec": map[string]interface{}{
+			"clusterSelector": map[string]interface{}{"selectorType": "LegacyClusterSetLabel"},
+		},
+	}}
+	binding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cluster.open-cluster-management.io/v1beta1",
+		"kind":       "ManagedClusterSetBinding",
+		"metadata":   map[string]interface{}{"name": "prod", "namespace": "default"},
+		"spec":       map[string]interface{}{"clusterSet": "prod"},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Resource: "managedclustersets"}:        "ManagedClusterSetList",
+			{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Resource: "managedclustersetbindings"}: "ManagedClusterSetBindingList",
+		}, set, binding)
+
+	ocmClient := &OCMClient{
+		Interface:     dynamicClient,
+		ClusterClient: clusterfake.NewSimpleClientset(),
+		Capabilities:  capabilities,
+	}
+
+	assert.Equal(t, "v1beta1", ocmClient.SelectedResource(ManagedClusterSetResource).Version)
+	assert.Equal(t, PlacementResource, ocmClient.SelectedResource(PlacementResource))
+	available, _ := capabilities.FeatureAvailable(FeatureClusterSets)
+	assert.True(t, available)
+
+	sets, err := ocmClient.ListManagedClusterSets(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, sets.Items, 1)
+	assert.Equal(t, "prod", sets.Items[0].Name)
+	assert.Equal(t, clusterv1beta2.ExclusiveClusterSetLabel, sets.Items[0].Spec.ClusterSelector.SelectorType)
+
+	got, err := ocmClient.GetManagedClusterSet(ctx, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, clusterv1beta2.ExclusiveClusterSetLabel, got.Spec.ClusterSelector.SelectorType)
+
+	bindings, err := ocmClient.ListManagedClusterSetBindings(ctx, "", metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, bindings.Items, 1)
+	assert.Equal(t, "prod", bindings.Items[0].Spec.ClusterSet)
+
+	gotBinding, err := ocmClient.GetManagedClusterSetBinding(ctx, "default", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "default", gotBinding.Namespace)
+}
+
+func TestClusterSetsServedInV1beta2(t *testing.T) {
+	ctx := context.Background()
+
+	discovery := kubefake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = servedResources()
+	capabilities := NewCapabilities(discovery)
+	require.NoError(t, capabilities.Detect())
+
+	// The typed client is used, the dynamic client is not set
+	ocmClient := &OCMClient{
+		ClusterClient: clusterfake.NewSimpleClientset(&clusterv1beta2.ManagedClusterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		}),
+		Capabilities: capabilities,
+	}
+
+	sets, err := ocmClient.ListManagedClusterSets(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, sets.Items, 1)
+	assert.Equal(t, "prod", sets.Items[0].Name)
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DefaultHubName names the hub when a single hub is configured through the
+// kubeconfig or in-cluster config
+const DefaultHubName = "default"
+
+// Hub sources
+const (
+	HubSourceInCluster  = "in-cluster"
+	HubSourceContext    = "context"
+	HubSourceSecret     = "secret"
+	HubSourceKubeconfig = "kubeconfig"
+	HubSourceInline     = "inline"
+	HubSourceMock       = "mock"
+)
+
+// hubSecretKey is the key holding the kubeconfig in a hub secret
+const hubSecretKey = "kubeconfig"
+
+var hubNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// HubSpec describes how to connect to a hub
+type HubSpec struct {
+	Name string
+	// Source is one of in-cluster, context, secret, inline or kubeconfig
+	Source string
+	// Context is the kubeconfig context of a context hub
+	Context string
+	// SecretNamespace and SecretName locate the kubeconfig of a secret hub
+	SecretNamespace string
+	SecretName      string
+	// KubeconfigData is the kubeconfig of an inline hub
+	KubeconfigData string
+}
+
+// String describes where the hub config is read from
+func (s HubSpec) String() string {
+	switch s.Source {
+	case HubSourceContext:
+		return HubSourceContext + ":" + s.Context
+	case HubSourceSecret:
+		return HubSourceSecret + ":" + s.SecretNamespace + "/" + s.SecretName
+	default:
+		return s.Source
+	}
+}
+
+// Hub is a named OCM hub and its clients
+type Hub struct {
+	Name   string
+	Source string
+	Client *OCMClient
+}
+
+// ParseHubSpecs parses a comma separated list of hubs. Each entry is
+// name=in-cluster, name=context:<context>, name=secret:<namespace>/<secret>
+// (the kubeconfig is read from the "kubeconfig" key of the secret, through the
+// in-cluster config) or a bare name, short for name=context:<name>. The first
+// hub is the default hub.
+func ParseHubSpecs(value string) ([]HubSpec, error) {
+	var specs []HubSpec
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, source, hasSource := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if err := ValidateHubName(name); err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate hub %q", name)
+		}
+		seen[name] = true
+
+		spec := HubSpec{Name: name}
+		source = strings.TrimSpace(source)
+		switch {
+		case !hasSource:
+			spec.Source = HubSourceContext
+			spec.Context = name
+		case source == HubSourceInCluster:
+			spec.Source = HubSourceInCluster
+		case strings.HasPrefix(source, HubSourceContext+":"):
+			spec.Source = HubSourceContext
+			spec.Context = strings.TrimPrefix(source, HubSourceContext+":")
+			if spec.Context == "" {
+				return nil, fmt.Errorf("hub %q: empty kubeconfig context", name)
+			}
+		case strings.HasPrefix(source, HubSourceSecret+":"):
+			namespace, secret, ok := strings.Cut(strings.TrimPrefix(source, HubSourceSecret+":"), "/")
+			if !ok || namespace == "" || secret == "" {
+				return nil, fmt.Errorf("hub %q: expected secret:<namespace>/<name>, got %q", name, source)
+			}
+			spec.Source = HubSourceSecret
+			spec.SecretNamespace = namespace
+			spec.SecretName = secret
+		default:
+			return nil, fmt.Errorf("hub %q: unknown source %q, expected in-cluster, context:<context> or secret:<namespace>/<name>", name, source)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// ValidateHubName checks that a hub name is a lowercase DNS label, as it is
+// used in URL paths and file names
+func ValidateHubName(name string) error {
+	if !hubNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid hub name %q, expected a lowercase DNS label", name)
+	}
+	return nil
+}
+
+// CreateHubs creates the clients of the given hubs, reading kubeconfig contexts
+// from kubeconfigPath or the default loading rules when it is empty. When no hub
+// is given a single default hub is created from the in-cluster config or the
+// kubeconfig, as CreateKubernetesClient does. It returns the error of the first
+// hub whose client cannot be created.
+func CreateHubs(specs []HubSpec, kubeconfigPath string) ([]Hub, error) {
+	if len(specs) == 0 {
+		return []Hub{{Name: DefaultHubName, Source: HubSourceKubeconfig, Client: CreateKubernetesClient(kubeconfigPath)}}, nil
+	}
+
+	hubs := make([]Hub, 0, len(specs))
+	for _, spec := range specs {
+		config, err := hubConfig(spec, kubeconfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading config of hub %q from %s: %w", spec.Name, spec.String(), err)
+		}
+
+		ocmClient, err := CreateOCMClient(config)
+		if err != nil {
+			return nil, fmt.Errorf("creating client of hub %q: %w", spec.Name, err)
+		}
+		slog.Info("Configured hub", "hub", spec.Name, "source", spec.String(), "host", config.Host)
+		hubs = append(hubs, Hub{Name: spec.Name, Source: spec.String(), Client: ocmClient})
+	}
+	return hubs, nil
+}
+
+// hubConfig builds the REST config of a hub
+func hubConfig(spec HubSpec, kubeconfigPath string) (*rest.Config, error) {
+	switch spec.Source {
+	case HubSourceInCluster:
+		return rest.InClusterConfig()
+	case HubSourceContext:
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		loadingRules.ExplicitPath = kubeconfigPath
+		overrides := &clientcmd.ConfigOverrides{CurrentContext: spec.Context}
+		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	case HubSourceSecret:
+		// Remote hub kubeconfigs are stored on the cluster the dashboard runs on
+		inCluster, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("reading a hub secret requires the in-cluster config: %w", err)
+		}
+		kubeClient, err := kubernetes.NewForConfig(inCluster)
+		if err != nil {
+			return nil, err
+		}
+		return hubConfigFromSecret(context.Background(), kubeClient, spec)
+	case HubSourceInline:
+		return clientcmd.RESTConfigFromKubeConfig([]byte(spec.KubeconfigData))
+	default:
+		return nil, fmt.Errorf("unknown hub source %q", spec.Source)
+	}
+}
+
+// hubConfigFromSecret reads the kubeconfig of a hub from a secret
+func hubConfigFromSecret(ctx context.Context, kubeClient kubernetes.Interface, spec HubSpec) (*rest.Config, error) {
+	secret, err := kubeClient.CoreV1().Secrets(spec.SecretNamespace).Get(ctx, spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[hubSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", spec.SecretNamespace, spec.SecretName, hubSecretKey)
+	}
+	return clientcmd.RESTConfigFromKubeConfig(data)
+}
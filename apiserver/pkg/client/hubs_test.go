@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseHubSpecs(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      []HubSpec
+		expectedError string
+	}{
+		{
+			name:  "context shorthand",
+			value: "east, west",
+			expected: []HubSpec{
+				{Name: "east", Source: HubSourceContext, Context: "east"},
+				{Name: "west", Source: HubSourceContext, Context: "west"},
+			},
+		},
+		{
+			name:  "all sources",
+			value: "local=in-cluster,east=context:kind-east,west=secret:ocm-dashboard/west-kubeconfig",
+			expected: []HubSpec{
+				{Name: "local", Source: HubSourceInCluster},
+				{Name: "east", Source: HubSourceContext, Context: "kind-east"},
+				{Name: "west", Source: HubSourceSecret, SecretNamespace: "ocm-dashboard", SecretName: "west-kubeconfig"},
+			},
+		},
+		{
+			name:          "duplicate hub",
+			value:         "east,east=in-cluster",
+			expectedError: `duplicate hub "east"`,
+		},
+		{
+			name:          "invalid name",
+			value:         "East=in-cluster",
+			expectedError: `invalid hub name "East"`,
+		},
+		{
+			name:          "unknown source",
+			value:         "east=file:/tmp/kubeconfig",
+			expectedError: `unknown source "file:/tmp/kubeconfig"`,
+		},
+		{
+			name:          "secret without namespace",
+			value:         "west=secret:west-kubeconfig",
+			expectedError: "expected secret:<namespace>/<name>",
+		},
+		{
+			name:          "empty context",
+			value:         "east=context:",
+			expectedError: "empty kubeconfig context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := ParseHubSpecs(tt.value)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, specs)
+		})
+	}
+}
+
+func TestHubSpecString(t *testing.T) {
+	assert.Equal(t, "in-cluster", HubSpec{Source: HubSourceInCluster}.String())
+	assert.Equal(t, "context:kind-east", HubSpec{Source: HubSourceContext, Context: "kind-east"}.String())
+	assert.Equal(t, "secret:ocm/west", HubSpec{Source: HubSourceSecret, SecretNamespace: "ocm", SecretName: "west"}.String())
+}
+
+func TestHubConfigFromSecret(t *testing.T) {
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: west
+  cluster:
+    server: https://west.example.com:6443
+users:
+- name: dashboard
+  user:
+    token: secret-token
+contexts:
+- name: west
+  context:
+    cluster: west
+    user: dashboard
+current-context: west
+`)
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "west-kubeconfig", Namespace: "ocm"},
+			Data:       map[string][]byte{"kubeconfig": kubeconfig},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "ocm"},
+		},
+	)
+
+	config, err := hubConfigFromSecret(context.Background(), kubeClient,
+		HubSpec{Name: "west", Source: HubSourceSecret, SecretNamespace: "ocm", SecretName: "west-kubeconfig"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://west.example.com:6443", config.Host)
+	assert.Equal(t, "secret-token", config.BearerToken)
+
+	_, err = hubConfigFromSecret(context.Background(), kubeClient,
+		HubSpec{Name: "west", Source: HubSourceSecret, SecretNamespace: "ocm", SecretName: "empty"})
+	assert.ErrorContains(t, err, `no "kubeconfig" key`)
+
+	_, err = hubConfigFromSecret(context.Background(), kubeClient,
+		HubSpec{Name: "west", Source: HubSourceSecret, SecretNamespace: "ocm", SecretName: "missing"})
+	assert.Error(t, err)
+}
+
+func TestCreateHubsReturnsHubError(t *testing.T) {
+	_, err := CreateHubs([]HubSpec{{Name: "west", Source: HubSourceInline, KubeconfigData: "not a kubeconfig"}}, "")
+	assert.ErrorContains(t, err, `hub "west"`)
+}
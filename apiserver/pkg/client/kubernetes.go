@@ -1,7 +1,7 @@
 package client
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 
@@ -11,8 +11,9 @@ import (
 	"k8s.io/client-go/util/homedir"
 )
 
-// CreateKubernetesClient initializes a connection to the Kubernetes API
-func CreateKubernetesClient() *OCMClient {
+// CreateKubernetesClient initializes a connection to the Kubernetes API, using
+// the in-cluster config when available and the given kubeconfig otherwise
+func CreateKubernetesClient(kubeconfigPath string) *OCMClient {
 	// Get kubeconfig
 	var kubeconfig string
 
@@ -33,43 +34,44 @@ func CreateKubernetesClient() *OCMClient {
 		// creates the in-cluster config
 		config, err = rest.InClusterConfig()
 		if err != nil {
-			log.Fatalf("Error creating in-cluster config: %v", err)
+			slog.Error("Error creating in-cluster config", "error", err)
+			os.Exit(1)
 		}
-		log.Println("Using in-cluster configuration")
+		slog.Info("Using in-cluster configuration")
 	} else {
-		// First try to use the KUBECONFIG environment variable
-		kubeconfigEnv := os.Getenv("KUBECONFIG")
-		if kubeconfigEnv != "" {
+		// First try to use the configured kubeconfig
+		if kubeconfigPath != "" {
 			// Convert relative path to absolute path
-			if !filepath.IsAbs(kubeconfigEnv) {
-				absPath, absErr := filepath.Abs(kubeconfigEnv)
+			if !filepath.IsAbs(kubeconfigPath) {
+				absPath, absErr := filepath.Abs(kubeconfigPath)
 				if absErr != nil {
-					log.Printf("Error converting KUBECONFIG to absolute path: %v", absErr)
+					slog.Warn("Error converting kubeconfig to absolute path", "error", absErr)
 				} else {
-					kubeconfigEnv = absPath
+					kubeconfigPath = absPath
 				}
 			}
-			log.Printf("Using KUBECONFIG from environment: %s", kubeconfigEnv)
-			config, err = clientcmd.BuildConfigFromFlags("", kubeconfigEnv)
+			slog.Info("Using configured kubeconfig", "kubeconfig", kubeconfigPath)
+			config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
 			if err != nil {
-				log.Printf("Error building kubeconfig from KUBECONFIG env: %v", err)
-				// Fall back to command line flag or default
+				slog.Warn("Error building configured kubeconfig", "error", err)
+				// Fall back to the default path
 			}
 		}
 
-		// If KUBECONFIG env var didn't work, try the flag or default path
+		// If the configured kubeconfig didn't work, try the default path
 		if config == nil {
-			log.Printf("Using kubeconfig from flag or default: %s", kubeconfig)
+			slog.Info("Using kubeconfig from flag or default", "kubeconfig", kubeconfig)
 			config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
 			if err != nil {
 				// Try the load rules (will check multiple locations)
-				log.Printf("Trying default client config loading rules")
+				slog.Info("Trying default client config loading rules")
 				loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 				configOverrides := &clientcmd.ConfigOverrides{ClusterDefaults: clientcmdapi.Cluster{Server: ""}}
 				kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 				config, err = kubeConfig.ClientConfig()
 				if err != nil {
-					log.Fatalf("Error building kubeconfig using defaults: %v", err)
+					slog.Error("Error building kubeconfig using defaults", "error", err)
+					os.Exit(1)
 				}
 			}
 		}
@@ -78,11 +80,12 @@ func CreateKubernetesClient() *OCMClient {
 	// Create OCM client
 	ocmClient, err := CreateOCMClient(config)
 	if err != nil {
-		log.Fatalf("Error creating OCM client: %v", err)
+		slog.Error("Error creating OCM client", "error", err)
+		os.Exit(1)
 	}
 
 	// Debug message to verify connection
-	log.Println("Successfully created Kubernetes client")
+	slog.Info("Successfully created Kubernetes client")
 
 	return ocmClient
 }
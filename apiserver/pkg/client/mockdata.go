@@ -0,0 +1,175 @@
+package client
+
+import (
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+	addonv1alpha1informers "open-cluster-management.io/api/client/addon/informers/externalversions"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1informers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	workv1informers "open-cluster-management.io/api/client/work/informers/externalversions"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// MockUser is the user every bearer token is authenticated as in mock data mode,
+// allowed every action
+const MockUser = "mock-user"
+
+// CreateMockClient creates clients backed by in-memory fake clientsets holding
+// sample clusters, clustersets, placements, addons and ManifestWorks, for
+// developing the dashboard without a hub. Writes are kept in memory.
+func CreateMockClient() *OCMClient {
+	clusters, sets, placements, addons, works := mockObjects()
+
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.30.2"}
+	kubeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authv1.TokenReview)
+		review.Status = authv1.TokenReviewStatus{
+			Authenticated: true,
+			User:          authv1.UserInfo{Username: MockUser},
+		}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: true}
+		return true, review, nil
+	})
+
+	clusterClient := clusterfake.NewSimpleClientset(append(append(clusters, sets...), placements...)...)
+	addonClient := addonfake.NewSimpleClientset(addons...)
+	workClient := workfake.NewSimpleClientset(works...)
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.Install(scheme)
+	_ = addonv1alpha1.Install(scheme)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+		map[schema.GroupVersionResource]string{
+			ManagedClusterResource:        "ManagedClusterList",
+			AddOnDeploymentConfigResource: "AddOnDeploymentConfigList",
+		}, clusters...)
+
+	return &OCMClient{
+		Interface:              dynamicClient,
+		KubernetesClient:       kubeClient,
+		ClusterClient:          clusterClient,
+		AddonClient:            addonClient,
+		WorkClient:             workClient,
+		ClusterInformerFactory: clusterv1informers.NewSharedInformerFactory(clusterClient, informerResyncPeriod),
+		AddonInformerFactory:   addonv1alpha1informers.NewSharedInformerFactory(addonClient, informerResyncPeriod),
+		WorkInformerFactory:    workv1informers.NewSharedInformerFactory(workClient, informerResyncPeriod),
+	}
+}
+
+// mockObjects returns the sample resources of mock data mode
+func mockObjects() (clusters, sets, placements, addons, works []runtime.Object) {
+	created := metav1.NewTime(time.Now().Add(-72 * time.Hour))
+	heartbeat := metav1.NewTime(time.Now())
+
+	for _, cluster := range []struct {
+		name      string
+		available metav1.ConditionStatus
+		region    string
+	}{
+		{name: "cluster1", available: metav1.ConditionTrue, region: "us-east"},
+		{name: "cluster2", available: metav1.ConditionTrue, region: "us-west"},
+		{name: "cluster3", available: metav1.ConditionUnknown, region: "eu-central"},
+	} {
+		clusters = append(clusters, &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              cluster.name,
+				CreationTimestamp: created,
+				Labels: map[string]string{
+					clusterv1beta2.ClusterSetLabel: "default",
+					"region":                       cluster.region,
+				},
+			},
+			Spec: clusterv1.ManagedClusterSpec{HubAcceptsClient: true},
+			Status: clusterv1.ManagedClusterStatus{
+				Version: clusterv1.ManagedClusterVersion{Kubernetes: "v1.30.2"},
+				Conditions: []metav1.Condition{
+					{Type: clusterv1.ManagedClusterConditionHubAccepted, Status: metav1.ConditionTrue, Reason: "HubClusterAdminAccepted", LastTransitionTime: created},
+					{Type: clusterv1.ManagedClusterConditionJoined, Status: metav1.ConditionTrue, Reason: "ManagedClusterJoined", LastTransitionTime: created},
+					{Type: clusterv1.ManagedClusterConditionAvailable, Status: cluster.available, Reason: "ManagedClusterAvailable", LastTransitionTime: heartbeat},
+				},
+			},
+		})
+
+		addons = append(addons, &addonv1alpha1.ManagedClusterAddOn{
+			ObjectMeta: metav1.ObjectMeta{Name: "application-manager", Namespace: cluster.name, CreationTimestamp: created},
+			Spec:       addonv1alpha1.ManagedClusterAddOnSpec{InstallNamespace: "open-cluster-management-agent-addon"},
+			Status: addonv1alpha1.ManagedClusterAddOnStatus{
+				Conditions: []metav1.Condition{
+					{Type: addonv1alpha1.ManagedClusterAddOnConditionAvailable, Status: cluster.available, Reason: "ManagedClusterAddOnLeaseUpdated", LastTransitionTime: heartbeat},
+				},
+			},
+		})
+
+		works = append(works, &workv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: cluster.name, CreationTimestamp: created},
+			Status: workv1.ManifestWorkStatus{
+				Conditions: []metav1.Condition{
+					{Type: workv1.WorkApplied, Status: metav1.ConditionTrue, Reason: "AppliedManifestWorkComplete", LastTransitionTime: created},
+					{Type: workv1.WorkAvailable, Status: cluster.available, Reason: "ResourcesAvailable", LastTransitionTime: heartbeat},
+				},
+			},
+		})
+	}
+
+	addons = append(addons, &addonv1alpha1.ClusterManagementAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: "application-manager", CreationTimestamp: created},
+		Spec: addonv1alpha1.ClusterManagementAddOnSpec{
+			AddOnMeta: addonv1alpha1.AddOnMeta{DisplayName: "Application Manager"},
+		},
+	})
+
+	sets = []runtime.Object{
+		&clusterv1beta2.ManagedClusterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", CreationTimestamp: created},
+			Spec: clusterv1beta2.ManagedClusterSetSpec{
+				ClusterSelector: clusterv1beta2.ManagedClusterSelector{SelectorType: clusterv1beta2.ExclusiveClusterSetLabel},
+			},
+		},
+		&clusterv1beta2.ManagedClusterSetBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default", CreationTimestamp: created},
+			Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: "default"},
+		},
+	}
+
+	placements = []runtime.Object{
+		&clusterv1beta1.Placement{
+			ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default", CreationTimestamp: created},
+			Spec:       clusterv1beta1.PlacementSpec{ClusterSets: []string{"default"}},
+			Status:     clusterv1beta1.PlacementStatus{NumberOfSelectedClusters: 2},
+		},
+		&clusterv1beta1.PlacementDecision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "placement1-decision-1",
+				Namespace:         "default",
+				CreationTimestamp: created,
+				Labels:            map[string]string{clusterv1beta1.PlacementLabel: "placement1"},
+			},
+			Status: clusterv1beta1.PlacementDecisionStatus{
+				Decisions: []clusterv1beta1.ClusterDecision{{ClusterName: "cluster1"}, {ClusterName: "cluster2"}},
+			},
+		},
+	}
+	return clusters, sets, placements, addons, works
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateMockClient(t *testing.T) {
+	ctx := context.Background()
+	ocmClient := CreateMockClient()
+
+	clusters, err := ocmClient.ClusterClient.ClusterV1().ManagedClusters().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, clusters.Items, 3)
+
+	decisions, err := ocmClient.ClusterClient.ClusterV1beta1().PlacementDecisions("default").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, decisions.Items, 1)
+	assert.Len(t, decisions.Items[0].Status.Decisions, 2)
+
+	addons, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns("cluster1").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, addons.Items, 1)
+
+	works, err := ocmClient.WorkClient.WorkV1().ManifestWorks("").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, works.Items, 3)
+
+	// The dynamic client serves the same clusters
+	unstructuredClusters, err := ocmClient.Interface.Resource(ManagedClusterResource).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, unstructuredClusters.Items, 3)
+
+	// Every token is authenticated as the mock user
+	review, err := ocmClient.KubernetesClient.AuthenticationV1().TokenReviews().Create(ctx,
+		&authv1.TokenReview{Spec: authv1.TokenReviewSpec{Token: "any"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.True(t, review.Status.Authenticated)
+	assert.Equal(t, MockUser, review.Status.User.Username)
+}
@@ -1,11 +1,17 @@
 package client
 
 import (
-	"log"
+	"log/slog"
+	"time"
 
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+
+	"open-cluster-management-io/lab/apiserver/pkg/logging"
+	"open-cluster-management-io/lab/apiserver/pkg/metrics"
+	"open-cluster-management-io/lab/apiserver/pkg/tracing"
+
 	addonv1alpha1client "open-cluster-management.io/api/client/addon/clientset/versioned"
 	addonv1alpha1informers "open-cluster-management.io/api/client/addon/informers/externalversions"
 	clusterv1client "open-cluster-management.io/api/client/cluster/clientset/versioned"
@@ -31,11 +37,31 @@ type OCMClient struct {
 	ClusterInformerFactory clusterv1informers.SharedInformerFactory
 	AddonInformerFactory   addonv1alpha1informers.SharedInformerFactory
 	WorkInformerFactory    workv1informers.SharedInformerFactory
+
+	// OCM resources and versions served by the hub
+	Capabilities *Capabilities
+}
+
+// informerResyncPeriod is the resync period of the informer factories, zero
+// disables resync
+var informerResyncPeriod time.Duration
+
+// SetInformerResyncPeriod sets the resync period of the informer factories of
+// the clients created afterwards
+func SetInformerResyncPeriod(period time.Duration) {
+	informerResyncPeriod = period
 }
 
 // CreateOCMClient initializes OCM clients using the provided config
 func CreateOCMClient(config *rest.Config) (*OCMClient, error) {
-	log.Printf("Creating OCM client with Kubernetes API server: %s", config.Host)
+	slog.Info("Creating OCM client", "host", config.Host)
+
+	// Record hub API call metrics, count the calls made for each request and
+	// trace them as children of the request span
+	config = rest.CopyConfig(config)
+	config.Wrap(metrics.InstrumentTransport)
+	config.Wrap(logging.CountUpstreamCalls)
+	config.Wrap(tracing.WrapTransport)
 
 	// Create dynamic client (for backward compatibility)
 	dynamicClient, err := dynamic.NewForConfig(config)
@@ -68,11 +94,23 @@ func CreateOCMClient(config *rest.Config) (*OCMClient, error) {
 	}
 
 	// Create informer factories
-	clusterInformerFactory := clusterv1informers.NewSharedInformerFactory(clusterClient, 0)
-	addonInformerFactory := addonv1alpha1informers.NewSharedInformerFactory(addonClient, 0)
-	workInformerFactory := workv1informers.NewSharedInformerFactory(workClient, 0)
+	clusterInformerFactory := clusterv1informers.NewSharedInformerFactory(clusterClient, informerResyncPeriod)
+	addonInformerFactory := addonv1alpha1informers.NewSharedInformerFactory(addonClient, informerResyncPeriod)
+	workInformerFactory := workv1informers.NewSharedInformerFactory(workClient, informerResyncPeriod)
+
+	// Detect which OCM APIs the hub serves, a failure is retried on use
+	capabilities := NewCapabilities(kubernetesClient.Discovery())
+	if err := capabilities.Detect(); err != nil {
+		slog.Warn("Failed to detect OCM API capabilities", "error", err)
+	} else {
+		for _, feature := range Features {
+			if available, reason := capabilities.FeatureAvailable(feature); !available {
+				slog.Warn("Feature unavailable", "feature", feature, "reason", reason)
+			}
+		}
+	}
 
-	log.Println("Successfully created OCM clients")
+	slog.Debug("Successfully created OCM clients")
 
 	return &OCMClient{
 		Interface:              dynamicClient,
@@ -83,5 +121,6 @@ func CreateOCMClient(config *rest.Config) (*OCMClient, error) {
 		ClusterInformerFactory: clusterInformerFactory,
 		AddonInformerFactory:   addonInformerFactory,
 		WorkInformerFactory:    workInformerFactory,
+		Capabilities:           capabilities,
 	}, nil
 }
@@ -17,6 +17,13 @@ var ManagedClusterSetResource = schema.GroupVersionResource{
 	Resource: "managedclustersets",
 }
 
+// ManagedClusterSetBinding resource
+var ManagedClusterSetBindingResource = schema.GroupVersionResource{
+	Group:    "cluster.open-cluster-management.io",
+	Version:  "v1beta2",
+	Resource: "managedclustersetbindings",
+}
+
 // ManagedClusterAddon resource
 var ManagedClusterAddonResource = schema.GroupVersionResource{
 	Group:    "addon.open-cluster-management.io",
@@ -24,6 +31,27 @@ var ManagedClusterAddonResource = schema.GroupVersionResource{
 	Resource: "managedclusteraddons",
 }
 
+// ClusterManagementAddon resource
+var ClusterManagementAddonResource = schema.GroupVersionResource{
+	Group:    "addon.open-cluster-management.io",
+	Version:  "v1alpha1",
+	Resource: "clustermanagementaddons",
+}
+
+// AddOnDeploymentConfig resource
+var AddOnDeploymentConfigResource = schema.GroupVersionResource{
+	Group:    "addon.open-cluster-management.io",
+	Version:  "v1alpha1",
+	Resource: "addondeploymentconfigs",
+}
+
+// AddOnTemplate resource
+var AddOnTemplateResource = schema.GroupVersionResource{
+	Group:    "addon.open-cluster-management.io",
+	Version:  "v1alpha1",
+	Resource: "addontemplates",
+}
+
 // Placement resource
 var PlacementResource = schema.GroupVersionResource{
 	Group:    "cluster.open-cluster-management.io",
@@ -37,3 +65,32 @@ var PlacementDecisionResource = schema.GroupVersionResource{
 	Version:  "v1beta1",
 	Resource: "placementdecisions",
 }
+
+// ManifestWork resource
+var ManifestWorkResource = schema.GroupVersionResource{
+	Group:    "work.open-cluster-management.io",
+	Version:  "v1",
+	Resource: "manifestworks",
+}
+
+// ManifestWorkReplicaSet resource
+var ManifestWorkReplicaSetResource = schema.GroupVersionResource{
+	Group:    "work.open-cluster-management.io",
+	Version:  "v1alpha1",
+	Resource: "manifestworkreplicasets",
+}
+
+// Resources lists every OCM resource the dashboard works with
+var Resources = []schema.GroupVersionResource{
+	ManagedClusterResource,
+	ManagedClusterSetResource,
+	ManagedClusterSetBindingResource,
+	ManagedClusterAddonResource,
+	ClusterManagementAddonResource,
+	AddOnDeploymentConfigResource,
+	AddOnTemplateResource,
+	PlacementResource,
+	PlacementDecisionResource,
+	ManifestWorkResource,
+	ManifestWorkReplicaSetResource,
+}
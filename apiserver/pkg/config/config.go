@@ -0,0 +1,305 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/logging"
+)
+
+// Authentication modes
+const (
+	// AuthModeTokenReview validates bearer tokens with a TokenReview on the hub
+	AuthModeTokenReview = "tokenreview"
+	// AuthModeNone accepts every request, for development only
+	AuthModeNone = "none"
+)
+
+// redacted replaces secret values in the configuration served by /api/config
+const redacted = "REDACTED"
+
+// Config is the configuration of the API server
+type Config struct {
+	// ListenAddress is the host:port the server listens on
+	ListenAddress string `json:"listenAddress"`
+	TLS           TLS    `json:"tls"`
+	Auth          Auth   `json:"auth"`
+	CORS          CORS   `json:"cors"`
+	Cache         Cache  `json:"cache"`
+	Log           Log    `json:"log"`
+	// Kubeconfig is read when the server does not run in a cluster and by hubs
+	// configured with a context
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// HistoryDB is the placement history database of the default hub, kept
+	// across restarts when its directory is on a persistent volume
+	HistoryDB string `json:"historyDB"`
+	// Hubs lists the hubs to connect to, the first is the default hub. A single
+	// hub is configured from the in-cluster config or the kubeconfig when empty.
+	Hubs     []Hub    `json:"hubs,omitempty"`
+	Features Features `json:"features"`
+
+	// file is the configuration file the configuration was read from
+	file string
+	// warnings are logged once logging is configured
+	warnings []string
+}
+
+// TLS configures serving HTTPS, the server serves HTTP when no certificate is set
+type TLS struct {
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+// Enabled reports whether the server serves HTTPS
+func (t TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// Auth configures how requests are authenticated
+type Auth struct {
+	// Mode is tokenreview or none
+	Mode string `json:"mode"`
+}
+
+// CORS configures cross-origin requests
+type CORS struct {
+	// AllowedOrigins lists the origins allowed to call the API, * allows any
+	AllowedOrigins []string `json:"allowedOrigins"`
+}
+
+// Cache configures how long hub state is reused
+type Cache struct {
+	// CapabilitiesRetryInterval is how often discovery is repeated while the hub
+	// is unreachable or a feature is unavailable
+	CapabilitiesRetryInterval metav1.Duration `json:"capabilitiesRetryInterval"`
+	// InformerResyncPeriod is the resync period of the informers, 0 disables resync
+	InformerResyncPeriod metav1.Duration `json:"informerResyncPeriod"`
+}
+
+// Log configures the structured logger
+type Log struct {
+	// Level is debug, info, warn or error, debug also enables Gin debug mode
+	Level string `json:"level"`
+	// Format is json or text
+	Format string `json:"format"`
+}
+
+// Hub configures a named hub, exactly one of InCluster, Context, Secret and
+// KubeconfigData is set
+type Hub struct {
+	Name      string `json:"name"`
+	InCluster bool   `json:"inCluster,omitempty"`
+	// Context is a context of the kubeconfig
+	Context string `json:"context,omitempty"`
+	// Secret holds the kubeconfig in its kubeconfig key, read through the
+	// in-cluster config
+	Secret *SecretReference `json:"secret,omitempty"`
+	// KubeconfigData is an inline kubeconfig, it is redacted by /api/config
+	KubeconfigData string `json:"kubeconfigData,omitempty"`
+}
+
+// SecretReference locates a secret
+type SecretReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Features toggles optional components of the server
+type Features struct {
+	// Mock serves sample data from an in-memory hub instead of connecting to one
+	Mock bool `json:"mock"`
+	// PlacementHistory records placement decision changes
+	PlacementHistory bool `json:"placementHistory"`
+	// DeployFollow keeps ManifestWorks deployed in follow mode in sync with
+	// their placement decisions
+	DeployFollow bool `json:"deployFollow"`
+	// FleetMetrics reports the fleet gauges at /metrics
+	FleetMetrics bool `json:"fleetMetrics"`
+	// Apply enables server-side apply through /api/apply. It is disabled by
+	// default as it writes any supported OCM kind on behalf of the user.
+	Apply bool `json:"apply"`
+}
+
+// DefaultHistoryDB is the default placement history database, in the directory
+// the Helm chart mounts a volume on
+const DefaultHistoryDB = "/var/lib/ocm-dashboard/history.db"
+
+// Default returns the configuration used when nothing is configured
+func Default() *Config {
+	return &Config{
+		ListenAddress: ":8080",
+		Auth:          Auth{Mode: AuthModeTokenReview},
+		CORS:          CORS{AllowedOrigins: []string{"*"}},
+		Cache: Cache{
+			CapabilitiesRetryInterval: metav1.Duration{Duration: client.DefaultCapabilitiesRetryInterval},
+		},
+		Log:       Log{Level: "info", Format: logging.FormatJSON},
+		HistoryDB: DefaultHistoryDB,
+		Features: Features{
+			PlacementHistory: true,
+			DeployFollow:     true,
+			FleetMetrics:     true,
+		},
+	}
+}
+
+// File returns the configuration file the configuration was read from, empty
+// if none was
+func (c *Config) File() string {
+	return c.file
+}
+
+// Warnings returns the deprecated settings found while loading
+func (c *Config) Warnings() []string {
+	return c.warnings
+}
+
+// Validate checks the configuration, reporting every invalid setting
+func (c *Config) Validate() error {
+	var errs []string
+	addError := func(format string, args ...any) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if _, port, err := net.SplitHostPort(c.ListenAddress); err != nil || port == "" {
+		addError("listenAddress: expected host:port, got %q", c.ListenAddress)
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		addError("tls: certFile and keyFile must be set together")
+	}
+	for _, file := range []struct{ field, path string }{
+		{"tls.certFile", c.TLS.CertFile},
+		{"tls.keyFile", c.TLS.KeyFile},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			addError("%s: %v", file.field, err)
+		}
+	}
+
+	if c.Auth.Mode != AuthModeTokenReview && c.Auth.Mode != AuthModeNone {
+		addError("auth.mode: unknown mode %q, expected %s or %s", c.Auth.Mode, AuthModeTokenReview, AuthModeNone)
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		addError("cors.allowedOrigins: at least one origin is required")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			addError("cors.allowedOrigins: invalid origin %q, expected * or scheme://host[:port]", origin)
+		}
+	}
+
+	if c.Cache.CapabilitiesRetryInterval.Duration <= 0 {
+		addError("cache.capabilitiesRetryInterval: must be positive")
+	}
+	if c.Cache.InformerResyncPeriod.Duration < 0 {
+		addError("cache.informerResyncPeriod: must not be negative")
+	}
+
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		addError("log.level: %v", err)
+	}
+	if c.Log.Format != logging.FormatJSON && c.Log.Format != logging.FormatText {
+		addError("log.format: unknown format %q, expected json or text", c.Log.Format)
+	}
+
+	if c.HistoryDB == "" && c.Features.PlacementHistory {
+		addError("historyDB: required when features.placementHistory is enabled")
+	}
+
+	seen := make(map[string]bool)
+	for i, hub := range c.Hubs {
+		if err := client.ValidateHubName(hub.Name); err != nil {
+			addError("hubs[%d]: %v", i, err)
+		} else if seen[hub.Name] {
+			addError("hubs[%d]: duplicate hub %q", i, hub.Name)
+		}
+		seen[hub.Name] = true
+
+		sources := 0
+		for _, set := range []bool{hub.InCluster, hub.Context != "", hub.Secret != nil, hub.KubeconfigData != ""} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			addError("hubs[%d]: exactly one of inCluster, context, secret and kubeconfigData must be set", i)
+		}
+		if hub.Secret != nil && (hub.Secret.Namespace == "" || hub.Secret.Name == "") {
+			addError("hubs[%d].secret: namespace and name are required", i)
+		}
+	}
+	if c.Features.Mock && len(c.Hubs) > 0 {
+		addError("features.mock: cannot be combined with hubs")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// HubSpecs returns the hubs to connect to
+func (c *Config) HubSpecs() []client.HubSpec {
+	specs := make([]client.HubSpec, 0, len(c.Hubs))
+	for _, hub := range c.Hubs {
+		spec := client.HubSpec{Name: hub.Name}
+		switch {
+		case hub.InCluster:
+			spec.Source = client.HubSourceInCluster
+		case hub.Context != "":
+			spec.Source = client.HubSourceContext
+			spec.Context = hub.Context
+		case hub.Secret != nil:
+			spec.Source = client.HubSourceSecret
+			spec.SecretNamespace = hub.Secret.Namespace
+			spec.SecretName = hub.Secret.Name
+		default:
+			spec.Source = client.HubSourceInline
+			spec.KubeconfigData = hub.KubeconfigData
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// hubFromSpec converts a hub parsed from DASHBOARD_HUBS or --hubs
+func hubFromSpec(spec client.HubSpec) Hub {
+	hub := Hub{Name: spec.Name}
+	switch spec.Source {
+	case client.HubSourceInCluster:
+		hub.InCluster = true
+	case client.HubSourceContext:
+		hub.Context = spec.Context
+	case client.HubSourceSecret:
+		hub.Secret = &SecretReference{Namespace: spec.SecretNamespace, Name: spec.SecretName}
+	}
+	return hub
+}
+
+// Redacted returns a copy of the configuration with secret values replaced
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Hubs = nil
+	for _, hub := range c.Hubs {
+		if hub.KubeconfigData != "" {
+			hub.KubeconfigData = redacted
+		}
+		out.Hubs = append(out.Hubs, hub)
+	}
+	return &out
+}
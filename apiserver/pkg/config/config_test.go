@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+func TestValidate(t *testing.T) {
+	certFile := filepath.Join(t.TempDir(), "tls.crt")
+	require.NoError(t, os.WriteFile(certFile, []byte("cert"), 0600))
+
+	tests := []struct {
+		name          string
+		modify        func(cfg *Config)
+		expectedError string
+	}{
+		{
+			name:   "defaults",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "all hub sources",
+			modify: func(cfg *Config) {
+				cfg.Hubs = []Hub{
+					{Name: "local", InCluster: true},
+					{Name: "east", Context: "kind-east"},
+					{Name: "west", Secret: &SecretReference{Namespace: "ocm-dashboard", Name: "west-hub"}},
+					{Name: "lab", KubeconfigData: "apiVersion: v1"},
+				}
+			},
+		},
+		{
+			name:          "invalid listen address",
+			modify:        func(cfg *Config) { cfg.ListenAddress = "8080" },
+			expectedError: `listenAddress: expected host:port, got "8080"`,
+		},
+		{
+			name:          "certificate without key",
+			modify:        func(cfg *Config) { cfg.TLS.CertFile = certFile },
+			expectedError: "tls: certFile and keyFile must be set together",
+		},
+		{
+			name: "missing key file",
+			modify: func(cfg *Config) {
+				cfg.TLS = TLS{CertFile: certFile, KeyFile: filepath.Join(t.TempDir(), "tls.key")}
+			},
+			expectedError: "tls.keyFile:",
+		},
+		{
+			name:          "unknown auth mode",
+			modify:        func(cfg *Config) { cfg.Auth.Mode = "basic" },
+			expectedError: `auth.mode: unknown mode "basic"`,
+		},
+		{
+			name: "invalid origin",
+			modify: func(cfg *Config) {
+				cfg.CORS.AllowedOrigins = []string{"https://dashboard.example.com", "dashboard.example.com"}
+			},
+			expectedError: `cors.allowedOrigins: invalid origin "dashboard.example.com"`,
+		},
+		{
+			name:          "no origin",
+			modify:        func(cfg *Config) { cfg.CORS.AllowedOrigins = nil },
+			expectedError: "cors.allowedOrigins: at least one origin is required",
+		},
+		{
+			name:          "zero capabilities retry interval",
+			modify:        func(cfg *Config) { cfg.Cache.CapabilitiesRetryInterval = metav1.Duration{} },
+			expectedError: "cache.capabilitiesRetryInterval: must be positive",
+		},
+		{
+			name:          "unknown log level",
+			modify:        func(cfg *Config) { cfg.Log.Level = "trace" },
+			expectedError: "log.level: unknown log level",
+		},
+		{
+			name:          "duplicate hub",
+			modify:        func(cfg *Config) { cfg.Hubs = []Hub{{Name: "east", InCluster: true}, {Name: "east", Context: "east"}} },
+			expectedError: `hubs[1]: duplicate hub "east"`,
+		},
+		{
+			name:          "hub with two sources",
+			modify:        func(cfg *Config) { cfg.Hubs = []Hub{{Name: "east", InCluster: true, Context: "east"}} },
+			expectedError: "hubs[0]: exactly one of inCluster, context, secret and kubeconfigData must be set",
+		},
+		{
+			name:          "mock with hubs",
+			modify:        func(cfg *Config) { cfg.Features.Mock = true; cfg.Hubs = []Hub{{Name: "east", InCluster: true}} },
+			expectedError: "features.mock: cannot be combined with hubs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateReportsEveryError(t *testing.T) {
+	cfg := Default()
+	cfg.Auth.Mode = "basic"
+	cfg.Log.Format = "xml"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "auth.mode")
+	assert.Contains(t, err.Error(), "log.format")
+}
+
+func TestHubSpecs(t *testing.T) {
+	cfg := Default()
+	cfg.Hubs = []Hub{
+		{Name: "local", InCluster: true},
+		{Name: "east", Context: "kind-east"},
+		{Name: "west", Secret: &SecretReference{Namespace: "ocm-dashboard", Name: "west-hub"}},
+		{Name: "lab", KubeconfigData: "apiVersion: v1"},
+	}
+
+	assert.Equal(t, []client.HubSpec{
+		{Name: "local", Source: client.HubSourceInCluster},
+		{Name: "east", Source: client.HubSourceContext, Context: "kind-east"},
+		{Name: "west", Source: client.HubSourceSecret, SecretNamespace: "ocm-dashboard", SecretName: "west-hub"},
+		{Name: "lab", Source: client.HubSourceInline, KubeconfigData: "apiVersion: v1"},
+	}, cfg.HubSpecs())
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Hubs = []Hub{
+		{Name: "east", InCluster: true},
+		{Name: "lab", KubeconfigData: "apiVersion: v1\nusers:\n- user:\n    token: secret"},
+	}
+	cfg.Cache.InformerResyncPeriod = metav1.Duration{Duration: time.Minute}
+
+	out := cfg.Redacted()
+	assert.Equal(t, "", out.Hubs[0].KubeconfigData)
+	assert.Equal(t, "REDACTED", out.Hubs[1].KubeconfigData)
+	assert.Equal(t, time.Minute, out.Cache.InformerResyncPeriod.Duration)
+
+	// The configuration in use is left unchanged
+	assert.Contains(t, cfg.Hubs[1].KubeconfigData, "token: secret")
+}
@@ -0,0 +1,273 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/logging"
+)
+
+// Environment variables configuring the server
+const (
+	FileEnv               = "DASHBOARD_CONFIG"
+	ListenAddressEnv      = "DASHBOARD_LISTEN_ADDRESS"
+	TLSCertFileEnv        = "DASHBOARD_TLS_CERT_FILE"
+	TLSKeyFileEnv         = "DASHBOARD_TLS_KEY_FILE"
+	AuthModeEnv           = "DASHBOARD_AUTH_MODE"
+	CORSAllowedOriginsEnv = "DASHBOARD_CORS_ALLOWED_ORIGINS"
+	KubeconfigEnv         = "KUBECONFIG"
+	HubsEnv               = "DASHBOARD_HUBS"
+	HistoryDBEnv          = "DASHBOARD_HISTORY_DB"
+	UseMockEnv            = "DASHBOARD_USE_MOCK"
+
+	// portEnv and bypassAuthEnv configured the server before the config file
+	// existed, they are used when the variables replacing them are not set
+	portEnv       = "PORT"
+	bypassAuthEnv = "DASHBOARD_BYPASS_AUTH"
+	// legacyDebugEnv enabled debug logging before DASHBOARD_LOG_LEVEL existed
+	legacyDebugEnv = "DASHBOARD_DEBUG"
+)
+
+// flags holds the command-line flags, only the flags set on the command line
+// override the file and the environment
+type flags struct {
+	set map[string]bool
+
+	file                      string
+	listenAddress             string
+	tlsCertFile               string
+	tlsKeyFile                string
+	authMode                  string
+	corsAllowedOrigins        string
+	kubeconfig                string
+	hubs                      string
+	historyDB                 string
+	logLevel                  string
+	logFormat                 string
+	capabilitiesRetryInterval time.Duration
+	informerResyncPeriod      time.Duration
+	mock                      bool
+}
+
+// parseFlags parses the command-line arguments
+func parseFlags(args []string) (*flags, error) {
+	f := &flags{set: make(map[string]bool)}
+	fs := flag.NewFlagSet("apiserver", flag.ContinueOnError)
+	fs.StringVar(&f.file, "config", "", "Path of the YAML configuration file (env "+FileEnv+")")
+	fs.StringVar(&f.listenAddress, "listen-address", "", "Address to listen on, host:port (env "+ListenAddressEnv+")")
+	fs.StringVar(&f.tlsCertFile, "tls-cert-file", "", "Serving certificate, enables HTTPS (env "+TLSCertFileEnv+")")
+	fs.StringVar(&f.tlsKeyFile, "tls-key-file", "", "Serving certificate key (env "+TLSKeyFileEnv+")")
+	fs.StringVar(&f.authMode, "auth-mode", "", "Authentication mode, tokenreview or none (env "+AuthModeEnv+")")
+	fs.StringVar(&f.corsAllowedOrigins, "cors-allowed-origins", "", "Comma separated origins allowed to call the API (env "+CORSAllowedOriginsEnv+")")
+	fs.StringVar(&f.kubeconfig, "kubeconfig", "", "Path of the kubeconfig (env "+KubeconfigEnv+")")
+	fs.StringVar(&f.hubs, "hubs", "", "Comma separated named hubs (env "+HubsEnv+")")
+	fs.StringVar(&f.historyDB, "history-db", "", "Path of the placement history database (env "+HistoryDBEnv+")")
+	fs.StringVar(&f.logLevel, "log-level", "", "Log level, debug, info, warn or error (env "+logging.LevelEnv+")")
+	fs.StringVar(&f.logFormat, "log-format", "", "Log format, json or text (env "+logging.FormatEnv+")")
+	fs.DurationVar(&f.capabilitiesRetryInterval, "capabilities-retry-interval", 0, "How often discovery is repeated while a feature is unavailable")
+	fs.DurationVar(&f.informerResyncPeriod, "informer-resync-period", 0, "Resync period of the informers, 0 disables resync")
+	fs.BoolVar(&f.mock, "mock", false, "Serve sample data instead of connecting to a hub (env "+UseMockEnv+")")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %v", fs.Args())
+	}
+	fs.Visit(func(fl *flag.Flag) {
+		f.set[fl.Name] = true
+	})
+	return f, nil
+}
+
+// Load reads the configuration from, in increasing precedence, the defaults,
+// the YAML file named by --config or DASHBOARD_CONFIG, the environment and the
+// command-line flags in args, and validates it
+func Load(args []string) (*Config, error) {
+	f, err := parseFlags(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	cfg.file = os.Getenv(FileEnv)
+	if f.set["config"] {
+		cfg.file = f.file
+	}
+	if cfg.file != "" {
+		data, err := os.ReadFile(cfg.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration file: %w", err)
+		}
+		if err := parseFile(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration file %s: %w", cfg.file, err)
+		}
+	}
+
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	if err := applyFlags(cfg, f); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// parseFile decodes a YAML configuration file over cfg, rejecting unknown fields
+func parseFile(data []byte, cfg *Config) error {
+	return yaml.UnmarshalStrict(data, cfg)
+}
+
+// applyEnv overrides cfg with the environment variables that are set
+func applyEnv(cfg *Config) error {
+	if value := os.Getenv(ListenAddressEnv); value != "" {
+		cfg.ListenAddress = value
+	} else if value := os.Getenv(portEnv); value != "" {
+		cfg.ListenAddress = ":" + value
+	}
+
+	if value := os.Getenv(TLSCertFileEnv); value != "" {
+		cfg.TLS.CertFile = value
+	}
+	if value := os.Getenv(TLSKeyFileEnv); value != "" {
+		cfg.TLS.KeyFile = value
+	}
+
+	if value := os.Getenv(AuthModeEnv); value != "" {
+		cfg.Auth.Mode = value
+	} else if value := os.Getenv(bypassAuthEnv); value != "" {
+		bypass, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", bypassAuthEnv, err)
+		}
+		if bypass {
+			cfg.Auth.Mode = AuthModeNone
+		}
+	}
+
+	if value := os.Getenv(CORSAllowedOriginsEnv); value != "" {
+		cfg.CORS.AllowedOrigins = splitList(value)
+	}
+
+	if value := os.Getenv(KubeconfigEnv); value != "" {
+		cfg.Kubeconfig = value
+	}
+
+	if value := os.Getenv(HubsEnv); strings.TrimSpace(value) != "" {
+		hubs, err := parseHubs(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", HubsEnv, err)
+		}
+		cfg.Hubs = hubs
+	}
+
+	if value := os.Getenv(HistoryDBEnv); value != "" {
+		cfg.HistoryDB = value
+	}
+
+	if value := os.Getenv(logging.LevelEnv); value != "" {
+		cfg.Log.Level = value
+	} else if value := os.Getenv(legacyDebugEnv); value != "" {
+		cfg.warnings = append(cfg.warnings, legacyDebugEnv+" is deprecated, set "+logging.LevelEnv+" instead")
+		if value == "true" {
+			cfg.Log.Level = "debug"
+		}
+	}
+	if value := os.Getenv(logging.FormatEnv); value != "" {
+		cfg.Log.Format = strings.ToLower(value)
+	}
+
+	if value := os.Getenv(UseMockEnv); value != "" {
+		mock, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", UseMockEnv, err)
+		}
+		cfg.Features.Mock = mock
+	}
+	return nil
+}
+
+// applyFlags overrides cfg with the flags set on the command line
+func applyFlags(cfg *Config, f *flags) error {
+	if f.set["listen-address"] {
+		cfg.ListenAddress = f.listenAddress
+	}
+	if f.set["tls-cert-file"] {
+		cfg.TLS.CertFile = f.tlsCertFile
+	}
+	if f.set["tls-key-file"] {
+		cfg.TLS.KeyFile = f.tlsKeyFile
+	}
+	if f.set["auth-mode"] {
+		cfg.Auth.Mode = f.authMode
+	}
+	if f.set["cors-allowed-origins"] {
+		cfg.CORS.AllowedOrigins = splitList(f.corsAllowedOrigins)
+	}
+	if f.set["kubeconfig"] {
+		cfg.Kubeconfig = f.kubeconfig
+	}
+	if f.set["hubs"] {
+		hubs, err := parseHubs(f.hubs)
+		if err != nil {
+			return fmt.Errorf("--hubs: %w", err)
+		}
+		cfg.Hubs = hubs
+	}
+	if f.set["history-db"] {
+		cfg.HistoryDB = f.historyDB
+	}
+	if f.set["log-level"] {
+		cfg.Log.Level = f.logLevel
+	}
+	if f.set["log-format"] {
+		cfg.Log.Format = strings.ToLower(f.logFormat)
+	}
+	if f.set["capabilities-retry-interval"] {
+		cfg.Cache.CapabilitiesRetryInterval = metav1.Duration{Duration: f.capabilitiesRetryInterval}
+	}
+	if f.set["informer-resync-period"] {
+		cfg.Cache.InformerResyncPeriod = metav1.Duration{Duration: f.informerResyncPeriod}
+	}
+	if f.set["mock"] {
+		cfg.Features.Mock = f.mock
+	}
+	return nil
+}
+
+// parseHubs parses hubs in the DASHBOARD_HUBS format
+func parseHubs(value string) ([]Hub, error) {
+	specs, err := client.ParseHubSpecs(value)
+	if err != nil {
+		return nil, err
+	}
+	hubs := make([]Hub, 0, len(specs))
+	for _, spec := range specs {
+		hubs = append(hubs, hubFromSpec(spec))
+	}
+	return hubs, nil
+}
+
+// splitList splits a comma separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearEnv unsets the environment variables read by Load for the test
+func clearEnv(t *testing.T) {
+	for _, name := range []string{
+		FileEnv, ListenAddressEnv, TLSCertFileEnv, TLSKeyFileEnv, AuthModeEnv, CORSAllowedOriginsEnv,
+		KubeconfigEnv, HubsEnv, HistoryDBEnv, UseMockEnv, portEnv, bypassAuthEnv, legacyDebugEnv,
+		"DASHBOARD_LOG_LEVEL", "DASHBOARD_LOG_FORMAT",
+	} {
+		if value, ok := os.LookupEnv(name); ok {
+			require.NoError(t, os.Unsetenv(name))
+			t.Cleanup(func() { os.Setenv(name, value) })
+		}
+	}
+}
+
+// writeFile writes a configuration file and returns its path
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	file := `
+listenAddress: ":9000"
+auth:
+  mode: none
+cors:
+  allowedOrigins: ["https://dashboard.example.com"]
+cache:
+  capabilitiesRetryInterval: 1m
+log:
+  level: warn
+hubs:
+  - name: east
+    inCluster: true
+  - name: west
+    secret:
+      namespace: ocm-dashboard
+      name: west-hub
+features:
+  apply: true
+`
+
+	tests := []struct {
+		name          string
+		env           map[string]string
+		args          []string
+		verify        func(t *testing.T, cfg *Config)
+		expectedError string
+	}{
+		{
+			name: "defaults",
+			verify: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, Default().ListenAddress, cfg.ListenAddress)
+				assert.Equal(t, AuthModeTokenReview, cfg.Auth.Mode)
+				assert.Equal(t, []string{"*"}, cfg.CORS.AllowedOrigins)
+				assert.Empty(t, cfg.File())
+				assert.False(t, cfg.Features.Apply)
+			},
+		},
+		{
+			name: "file",
+			env:  map[string]string{FileEnv: "file"},
+			verify: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":9000", cfg.ListenAddress)
+				assert.Equal(t, AuthModeNone, cfg.Auth.Mode)
+				assert.Equal(t, []string{"https://dashboard.example.com"}, cfg.CORS.AllowedOrigins)
+				assert.Equal(t, time.Minute, cfg.Cache.CapabilitiesRetryInterval.Duration)
+				assert.Equal(t, "warn", cfg.Log.Level)
+				assert.Len(t, cfg.Hubs, 2)
+				assert.True(t, cfg.Features.Apply)
+				// Settings missing from the file keep their defaults
+				assert.True(t, cfg.Features.PlacementHistory)
+				assert.Equal(t, "json", cfg.Log.Format)
+			},
+		},
+		{
+			name: "environment overrides file",
+			env: map[string]string{
+				FileEnv:               "file",
+				ListenAddressEnv:      ":9100",
+				CORSAllowedOriginsEnv: "http://localhost:3000, https://dashboard.example.com",
+				HubsEnv:               "lab",
+			},
+			verify: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":9100", cfg.ListenAddress)
+				assert.Equal(t, []string{"http://localhost:3000", "https://dashboard.example.com"}, cfg.CORS.AllowedOrigins)
+				assert.Equal(t, []Hub{{Name: "lab", Context: "lab"}}, cfg.Hubs)
+				assert.Equal(t, "warn", cfg.Log.Level)
+			},
+		},
+		{
+			name: "flags override environment",
+			env:  map[string]string{FileEnv: "file", ListenAddressEnv: ":9100"},
+			args: []string{"--listen-address", ":9200", "--auth-mode=tokenreview", "--log-level", "debug"},
+			verify: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":9200", cfg.ListenAddress)
+				assert.Equal(t, AuthModeTokenReview, cfg.Auth.Mode)
+				assert.Equal(t, "debug", cfg.Log.Level)
+			},
+		},
+		{
+			name: "config flag overrides config environment",
+			env:  map[string]string{FileEnv: "/does/not/exist.yaml"},
+			args: []string{"--config", "file"},
+			verify: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":9000", cfg.ListenAddress)
+			},
+		},
+		{
+			name: "legacy environment",
+			env:  map[string]string{portEnv: "9090", bypassAuthEnv: "true", legacyDebugEnv: "true", UseMockEnv: "true"},
+			verify: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, ":9090", cfg.ListenAddress)
+				assert.Equal(t, AuthModeNone, cfg.Auth.Mode)
+				assert.Equal(t, "debug", cfg.Log.Level)
+				assert.True(t, cfg.Features.Mock)
+				assert.Equal(t, []string{"DASHBOARD_DEBUG is deprecated, set DASHBOARD_LOG_LEVEL instead"}, cfg.Warnings())
+			},
+		},
+		{
+			name: "listen address takes precedence over port",
+			env:  map[string]string{portEnv: "9090", ListenAddressEnv: "127.0.0.1:8081", bypassAuthEnv: "false"},
+			verify: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "127.0.0.1:8081", cfg.ListenAddress)
+				assert.Equal(t, AuthModeTokenReview, cfg.Auth.Mode)
+			},
+		},
+		{
+			name:          "missing file",
+			env:           map[string]string{FileEnv: "/does/not/exist.yaml"},
+			expectedError: "failed to read configuration file",
+		},
+		{
+			name:          "invalid boolean",
+			env:           map[string]string{UseMockEnv: "maybe"},
+			expectedError: "DASHBOARD_USE_MOCK",
+		},
+		{
+			name:          "invalid hubs",
+			args:          []string{"--hubs", "East"},
+			expectedError: `--hubs: invalid hub name "East"`,
+		},
+		{
+			name:          "unknown flag",
+			args:          []string{"--port", "8080"},
+			expectedError: "flag provided but not defined: -port",
+		},
+		{
+			name:          "invalid configuration",
+			args:          []string{"--log-format", "xml"},
+			expectedError: `log.format: unknown format "xml"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			path := writeFile(t, file)
+			for name, value := range tt.env {
+				if value == "file" {
+					value = path
+				}
+				t.Setenv(name, value)
+			}
+			args := make([]string, len(tt.args))
+			for i, arg := range tt.args {
+				if arg == "file" {
+					arg = path
+				}
+				args[i] = arg
+			}
+
+			cfg, err := Load(args)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tt.verify(t, cfg)
+		})
+	}
+}
+
+func TestLoadRejectsUnknownFields(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(FileEnv, writeFile(t, "listenAddress: \":9000\"\nport: 9000\n"))
+
+	_, err := Load(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "port"`)
+}
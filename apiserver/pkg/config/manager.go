@@ -0,0 +1,168 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// watchInterval is how often the configuration file is checked for changes.
+// The file is polled rather than watched so that ConfigMap updates, which swap
+// a symlink, are noticed as well.
+const watchInterval = 5 * time.Second
+
+// Manager holds the configuration in use and reloads it when the configuration
+// file changes. Only the settings that can change safely while the server runs
+// are applied on reload, see reloadable.
+type Manager struct {
+	args []string
+
+	mu        sync.RWMutex
+	current   *Config
+	listeners []func(*Config)
+}
+
+// NewManager creates a manager for a configuration loaded from args, which are
+// loaded again on reload
+func NewManager(cfg *Config, args []string) *Manager {
+	return &Manager{args: args, current: cfg}
+}
+
+// Current returns the configuration in use. It must not be modified.
+func (m *Manager) Current() *Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.current
+}
+
+// OnReload registers a function called with the new configuration after a
+// reload changed a reloadable setting
+func (m *Manager) OnReload(listener func(*Config)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// Reload loads the configuration again and applies the reloadable settings that
+// changed. Other changes are logged and take effect after a restart. An invalid
+// configuration is rejected and the configuration in use is kept.
+func (m *Manager) Reload() error {
+	loaded, err := Load(m.args)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	next := reloadable(m.current, loaded)
+	changed := !reflect.DeepEqual(next, m.current)
+	if changed {
+		m.current = next
+	}
+	listeners := append([]func(*Config){}, m.listeners...)
+	m.mu.Unlock()
+
+	if !changed {
+		return nil
+	}
+	slog.Info("Configuration reloaded", "file", next.file)
+	for _, listener := range listeners {
+		listener(next)
+	}
+	return nil
+}
+
+// reloadableSettings are the settings applied on reload
+var reloadableSettings = map[string]bool{
+	"log.level":                       true,
+	"cors.allowedOrigins":             true,
+	"cache.capabilitiesRetryInterval": true,
+	"features.apply":                  true,
+}
+
+// reloadable returns the current configuration with the reloadable settings of
+// loaded applied, logging the changed settings that need a restart
+func reloadable(current, loaded *Config) *Config {
+	next := *current
+	next.Log.Level = loaded.Log.Level
+	next.CORS = loaded.CORS
+	next.Cache.CapabilitiesRetryInterval = loaded.Cache.CapabilitiesRetryInterval
+	next.Features.Apply = loaded.Features.Apply
+
+	var restart []string
+	for _, setting := range changedSettings(current, loaded) {
+		if !reloadableSettings[setting] {
+			restart = append(restart, setting)
+		}
+	}
+	if len(restart) > 0 {
+		slog.Warn("Configuration changes ignored until the server restarts", "file", loaded.file, "settings", restart)
+	}
+	return &next
+}
+
+// changedSettings lists the settings that differ, named by their YAML path
+func changedSettings(a, b *Config) []string {
+	var changed []string
+	diffFields("", reflect.ValueOf(*a), reflect.ValueOf(*b), &changed)
+	return changed
+}
+
+func diffFields(prefix string, a, b reflect.Value, changed *[]string) {
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		// Nested sections of this package are compared setting by setting
+		if field.Type.Kind() == reflect.Struct && field.Type.PkgPath() == a.Type().PkgPath() {
+			diffFields(name, a.Field(i), b.Field(i), changed)
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			*changed = append(*changed, name)
+		}
+	}
+}
+
+// Watch reloads the configuration whenever the content of the configuration
+// file changes, until ctx is done. It does nothing when no file is used.
+func (m *Manager) Watch(ctx context.Context) {
+	path := m.Current().File()
+	if path == "" {
+		return
+	}
+
+	last, _ := os.ReadFile(path)
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			slog.Warn("Failed to read configuration file", "file", path, "error", err)
+			continue
+		}
+		if bytes.Equal(data, last) {
+			continue
+		}
+		last = data
+
+		if err := m.Reload(); err != nil {
+			slog.Error("Configuration not reloaded, keeping the configuration in use", "file", path, "error", err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerReload(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, `
+listenAddress: ":9000"
+cors:
+  allowedOrigins: ["https://dashboard.example.com"]
+log:
+  level: info
+`)
+	args := []string{"--config", path}
+	cfg, err := Load(args)
+	require.NoError(t, err)
+
+	manager := NewManager(cfg, args)
+	var reloaded []*Config
+	manager.OnReload(func(cfg *Config) {
+		reloaded = append(reloaded, cfg)
+	})
+
+	// Unchanged file
+	require.NoError(t, manager.Reload())
+	assert.Empty(t, reloaded)
+
+	// Reloadable settings are applied, the listen address needs a restart
+	require.NoError(t, os.WriteFile(path, []byte(`
+listenAddress: ":9100"
+cors:
+  allowedOrigins: ["https://dashboard.example.com", "http://localhost:3000"]
+cache:
+  capabilitiesRetryInterval: 10s
+log:
+  level: debug
+features:
+  apply: true
+`), 0600))
+	require.NoError(t, manager.Reload())
+	require.Len(t, reloaded, 1)
+
+	current := manager.Current()
+	assert.Same(t, reloaded[0], current)
+	assert.Equal(t, []string{"https://dashboard.example.com", "http://localhost:3000"}, current.CORS.AllowedOrigins)
+	assert.Equal(t, 10*time.Second, current.Cache.CapabilitiesRetryInterval.Duration)
+	assert.Equal(t, "debug", current.Log.Level)
+	assert.True(t, current.Features.Apply)
+	assert.Equal(t, ":9000", current.ListenAddress)
+
+	// An invalid file is rejected and the configuration in use is kept
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: trace\n"), 0600))
+	assert.ErrorContains(t, manager.Reload(), "log.level")
+	assert.Same(t, current, manager.Current())
+	assert.Len(t, reloaded, 1)
+}
+
+func TestChangedSettings(t *testing.T) {
+	a := Default()
+	b := Default()
+	b.ListenAddress = ":9000"
+	b.Log.Level = "debug"
+	b.Features.Mock = true
+	b.Hubs = []Hub{{Name: "east", InCluster: true}}
+
+	assert.Equal(t, []string{"listenAddress", "log.level", "hubs", "features.mock"}, changedSettings(a, b))
+	assert.Empty(t, changedSettings(a, Default()))
+}
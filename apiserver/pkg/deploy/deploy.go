@@ -0,0 +1,261 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterv1client "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	workv1client "open-cluster-management.io/api/client/work/clientset/versioned"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// Labels set on the ManifestWorks deployed to the clusters of a placement
+const (
+	PlacementNamespaceLabel = "dashboard.open-cluster-management.io/placement-namespace"
+	PlacementNameLabel      = "dashboard.open-cluster-management.io/placement-name"
+	FollowLabel             = "dashboard.open-cluster-management.io/follow"
+)
+
+// FollowedByAnnotation records the user who enabled follow mode on the ManifestWorks
+// of a target. The follower keeps them in sync with the service account of the
+// server, within the permissions of that user.
+const FollowedByAnnotation = "dashboard.open-cluster-management.io/followed-by"
+
+// Deploy actions reported per cluster
+const (
+	ActionCreated   = "Created"
+	ActionUpdated   = "Updated"
+	ActionDeleted   = "Deleted"
+	ActionUnchanged = "Unchanged"
+	ActionFailed    = "Failed"
+	ActionForbidden = "Forbidden"
+)
+
+// Target describes a ManifestWork deployed to every cluster decided by a placement
+type Target struct {
+	Namespace string                  `json:"namespace"`
+	Placement string                  `json:"placement"`
+	WorkName  string                  `json:"workName"`
+	Spec      workv1.ManifestWorkSpec `json:"spec"`
+	Follow    bool                    `json:"follow"`
+	// FollowedBy is the user who enabled follow mode, empty when authentication
+	// is bypassed
+	FollowedBy authv1.UserInfo `json:"followedBy"`
+}
+
+// Authorizer reports whether the ManifestWork of a target may be written as
+// described by attributes, and the reason when it may not
+type Authorizer func(ctx context.Context, attributes authorizationv1.ResourceAttributes) (bool, string, error)
+
+// DefaultWorkName returns the stable ManifestWork name used for a placement. Namespaces
+// cannot contain dots, so the name is unique per placement.
+func DefaultWorkName(namespace, placement string) string {
+	return namespace + "." + placement
+}
+
+// DecidedClusters returns the clusters across all PlacementDecisions of a placement
+func DecidedClusters(ctx context.Context, clusterClient clusterv1client.Interface, namespace, placement string) ([]string, error) {
+	selector := labels.SelectorFromSet(labels.Set{clusterv1beta1.PlacementLabel: placement})
+	list, err := clusterClient.ClusterV1beta1().PlacementDecisions(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := make([]*clusterv1beta1.PlacementDecision, 0, len(list.Items))
+	for i := range list.Items {
+		decisions = append(decisions, &list.Items[i])
+	}
+	return clustersFromDecisions(decisions), nil
+}
+
+func clustersFromDecisions(decisions []*clusterv1beta1.PlacementDecision) []string {
+	seen := make(map[string]bool)
+	var clusters []string
+	for _, pd := range decisions {
+		for _, decision := range pd.Status.Decisions {
+			if !seen[decision.ClusterName] {
+				seen[decision.ClusterName] = true
+				clusters = append(clusters, decision.ClusterName)
+			}
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// Sync creates or updates the ManifestWork of the target in the namespace of every cluster.
+// In follow mode the ManifestWorks deployed by the target to other clusters are deleted.
+// When authorize is set, every write must be authorized first, otherwise the
+// cluster is reported as forbidden.
+func Sync(ctx context.Context, workClient workv1client.Interface, target Target, clusters []string, authorize Authorizer) []models.ClusterDeployResult {
+	results := []models.ClusterDeployResult{}
+
+	decided := make(map[string]bool, len(clusters))
+	for _, cluster := range clusters {
+		decided[cluster] = true
+		if result, ok := authorizeWork(ctx, authorize, target, cluster, "create", "update"); !ok {
+			results = append(results, result)
+			continue
+		}
+		results = append(results, apply(ctx, workClient, target, cluster))
+	}
+
+	if !target.Follow {
+		return results
+	}
+
+	deployed, err := workClient.WorkV1().ManifestWorks(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: targetSelector(target.Namespace, target.Placement).String(),
+	})
+	if err != nil {
+		return append(results, models.ClusterDeployResult{Action: ActionFailed, ManifestWork: target.WorkName, Error: err.Error()})
+	}
+
+	for _, work := range deployed.Items {
+		if decided[work.Namespace] || work.Name != target.WorkName {
+			continue
+		}
+		if result, ok := authorizeWork(ctx, authorize, target, work.Namespace, "delete"); !ok {
+			results = append(results, result)
+			continue
+		}
+
+		result := models.ClusterDeployResult{ClusterName: work.Namespace, ManifestWork: work.Name, Action: ActionDeleted}
+		if err := workClient.WorkV1().ManifestWorks(work.Namespace).Delete(ctx, work.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			result.Action = ActionFailed
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// authorizeWork checks the ManifestWork of the target may be written with every
+// verb in the namespace of cluster. It returns the result to report otherwise.
+func authorizeWork(ctx context.Context, authorize Authorizer, target Target, cluster string, verbs ...string) (models.ClusterDeployResult, bool) {
+	result := models.ClusterDeployResult{ClusterName: cluster, ManifestWork: target.WorkName}
+	if authorize == nil {
+		return result, true
+	}
+
+	for _, verb := range verbs {
+		allowed, reason, err := authorize(ctx, authorizationv1.ResourceAttributes{
+			Namespace: cluster,
+			Verb:      verb,
+			Group:     workv1.GroupName,
+			Resource:  "manifestworks",
+			Name:      target.WorkName,
+		})
+		if err != nil {
+			result.Action, result.Error = ActionFailed, err.Error()
+			return result, false
+		}
+		if !allowed {
+			result.Action, result.Error = ActionForbidden, reason
+			return result, false
+		}
+	}
+	return result, true
+}
+
+// apply creates or updates the ManifestWork of the target on one cluster
+func apply(ctx context.Context, workClient workv1client.Interface, target Target, cluster string) models.ClusterDeployResult {
+	result := models.ClusterDeployResult{ClusterName: cluster, ManifestWork: target.WorkName}
+	works := workClient.WorkV1().ManifestWorks(cluster)
+
+	existing, err := works.Get(ctx, target.WorkName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		work := &workv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        target.WorkName,
+				Namespace:   cluster,
+				Labels:      targetLabels(target),
+				Annotations: targetAnnotations(target),
+			},
+			Spec: target.Spec,
+		}
+		if _, err := works.Create(ctx, work, metav1.CreateOptions{}); err != nil {
+			result.Action, result.Error = ActionFailed, err.Error()
+			return result
+		}
+		result.Action = ActionCreated
+	case err != nil:
+		result.Action, result.Error = ActionFailed, err.Error()
+	default:
+		// Never overwrite a ManifestWork that was not deployed for this placement
+		if existing.Labels[PlacementNamespaceLabel] != target.Namespace || existing.Labels[PlacementNameLabel] != target.Placement {
+			result.Action = ActionFailed
+			result.Error = fmt.Sprintf("ManifestWork %s/%s exists and was not deployed for placement %s/%s", cluster, target.WorkName, target.Namespace, target.Placement)
+			return result
+		}
+
+		metadataChanged := false
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		for key, value := range targetLabels(target) {
+			if existing.Labels[key] != value {
+				existing.Labels[key] = value
+				metadataChanged = true
+			}
+		}
+		if followedBy := targetAnnotations(target)[FollowedByAnnotation]; existing.Annotations[FollowedByAnnotation] != followedBy {
+			if followedBy == "" {
+				delete(existing.Annotations, FollowedByAnnotation)
+			} else {
+				if existing.Annotations == nil {
+					existing.Annotations = map[string]string{}
+				}
+				existing.Annotations[FollowedByAnnotation] = followedBy
+			}
+			metadataChanged = true
+		}
+		if !metadataChanged && equality.Semantic.DeepEqual(existing.Spec, target.Spec) {
+			result.Action = ActionUnchanged
+			return result
+		}
+
+		existing.Spec = target.Spec
+		if _, err := works.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			result.Action, result.Error = ActionFailed, err.Error()
+			return result
+		}
+		result.Action = ActionUpdated
+	}
+
+	return result
+}
+
+func targetLabels(target Target) map[string]string {
+	return map[string]string{
+		PlacementNamespaceLabel: target.Namespace,
+		PlacementNameLabel:      target.Placement,
+		FollowLabel:             fmt.Sprintf("%t", target.Follow),
+	}
+}
+
+func targetAnnotations(target Target) map[string]string {
+	if !target.Follow || target.FollowedBy.Username == "" {
+		return nil
+	}
+	return map[string]string{FollowedByAnnotation: target.FollowedBy.Username}
+}
+
+func targetSelector(namespace, placement string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		PlacementNamespaceLabel: namespace,
+		PlacementNameLabel:      placement,
+	})
+}
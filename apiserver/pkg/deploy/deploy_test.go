@@ -0,0 +1,224 @@
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+func testTarget(follow bool) Target {
+	return Target{
+		Namespace: "default",
+		Placement: "web",
+		WorkName:  DefaultWorkName("default", "web"),
+		Spec: workv1.ManifestWorkSpec{
+			Workload: workv1.ManifestsTemplate{
+				Manifests: []workv1.Manifest{
+					{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web","namespace":"default"}}`)}},
+				},
+			},
+		},
+		Follow: follow,
+	}
+}
+
+func deployedWork(cluster string, target Target) *workv1.ManifestWork {
+	return &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.WorkName,
+			Namespace: cluster,
+			Labels:    targetLabels(target),
+		},
+		Spec: target.Spec,
+	}
+}
+
+func TestDecidedClusters(t *testing.T) {
+	newDecision := func(name, placement string, clusters ...string) *clusterv1beta1.PlacementDecision {
+		pd := &clusterv1beta1.PlacementDecision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1beta1.PlacementLabel: placement},
+			},
+		}
+		for _, cluster := range clusters {
+			pd.Status.Decisions = append(pd.Status.Decisions, clusterv1beta1.ClusterDecision{ClusterName: cluster})
+		}
+		return pd
+	}
+
+	clusterClient := clusterfake.NewSimpleClientset(
+		newDecision("web-decision-1", "web", "cluster2", "cluster1"),
+		newDecision("web-decision-2", "web", "cluster3", "cluster1"),
+		newDecision("db-decision-1", "db", "cluster4"),
+	)
+
+	clusters, err := DecidedClusters(context.Background(), clusterClient, "default", "web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cluster1", "cluster2", "cluster3"}, clusters)
+}
+
+func TestSync(t *testing.T) {
+	stale := testTarget(true)
+	stale.Spec = workv1.ManifestWorkSpec{}
+
+	foreign := &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultWorkName("default", "web"), Namespace: "cluster4"},
+	}
+
+	testCases := []struct {
+		name            string
+		target          Target
+		clusters        []string
+		existing        []runtime.Object
+		expectedActions map[string]string
+		expectedWorks   []string
+	}{
+		{
+			name:            "Create on every decided cluster",
+			target:          testTarget(false),
+			clusters:        []string{"cluster1", "cluster2"},
+			expectedActions: map[string]string{"cluster1": ActionCreated, "cluster2": ActionCreated},
+			expectedWorks:   []string{"cluster1", "cluster2"},
+		},
+		{
+			name:     "Update changed and skip unchanged works",
+			target:   testTarget(false),
+			clusters: []string{"cluster1", "cluster2"},
+			existing: []runtime.Object{
+				deployedWork("cluster1", testTarget(false)),
+				deployedWork("cluster2", stale),
+			},
+			expectedActions: map[string]string{"cluster1": ActionUnchanged, "cluster2": ActionUpdated},
+			expectedWorks:   []string{"cluster1", "cluster2"},
+		},
+		{
+			name:            "Keep works on removed clusters without follow",
+			target:          testTarget(false),
+			clusters:        []string{"cluster1"},
+			existing:        []runtime.Object{deployedWork("cluster3", testTarget(false))},
+			expectedActions: map[string]string{"cluster1": ActionCreated},
+			expectedWorks:   []string{"cluster1", "cluster3"},
+		},
+		{
+			name:            "Delete works on removed clusters with follow",
+			target:          testTarget(true),
+			clusters:        []string{"cluster1"},
+			existing:        []runtime.Object{deployedWork("cluster3", testTarget(true))},
+			expectedActions: map[string]string{"cluster1": ActionCreated, "cluster3": ActionDeleted},
+			expectedWorks:   []string{"cluster1"},
+		},
+		{
+			name:            "Refuse to overwrite a work not deployed for the placement",
+			target:          testTarget(true),
+			clusters:        []string{"cluster4"},
+			existing:        []runtime.Object{foreign},
+			expectedActions: map[string]string{"cluster4": ActionFailed},
+			expectedWorks:   []string{"cluster4"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			workClient := workfake.NewSimpleClientset(tc.existing...)
+
+			results := Sync(context.Background(), workClient, tc.target, tc.clusters, nil)
+
+			actions := make(map[string]string)
+			for _, result := range results {
+				assert.Equal(t, tc.target.WorkName, result.ManifestWork)
+				actions[result.ClusterName] = result.Action
+				if result.Action == ActionFailed {
+					assert.NotEmpty(t, result.Error)
+				}
+			}
+			assert.Equal(t, tc.expectedActions, actions)
+
+			works, err := workClient.WorkV1().ManifestWorks(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			var namespaces []string
+			for _, work := range works.Items {
+				namespaces = append(namespaces, work.Namespace)
+				if work.Namespace != "cluster4" {
+					assert.Equal(t, tc.target.Spec, work.Spec)
+					assert.Equal(t, targetLabels(tc.target)[FollowLabel], work.Labels[FollowLabel])
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedWorks, namespaces)
+		})
+	}
+}
+
+func TestSyncRecordsFollowedBy(t *testing.T) {
+	workClient := workfake.NewSimpleClientset(deployedWork("cluster2", testTarget(false)))
+
+	target := testTarget(true)
+	target.FollowedBy = authv1.UserInfo{Username: "alice"}
+	results := Sync(context.Background(), workClient, target, []string{"cluster1", "cluster2"}, nil)
+	require.Len(t, results, 2)
+	assert.Equal(t, ActionCreated, results[0].Action)
+	assert.Equal(t, ActionUpdated, results[1].Action)
+
+	for _, cluster := range []string{"cluster1", "cluster2"} {
+		work, err := workClient.WorkV1().ManifestWorks(cluster).Get(context.Background(), target.WorkName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", work.Annotations[FollowedByAnnotation])
+	}
+
+	// Stopping follow mode removes the annotation
+	results = Sync(context.Background(), workClient, testTarget(false), []string{"cluster1"}, nil)
+	assert.Equal(t, ActionUpdated, results[0].Action)
+	work, err := workClient.WorkV1().ManifestWorks("cluster1").Get(context.Background(), target.WorkName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, work.Annotations, FollowedByAnnotation)
+}
+
+func TestSyncAuthorizes(t *testing.T) {
+	workClient := workfake.NewSimpleClientset(
+		deployedWork("cluster3", testTarget(true)),
+		deployedWork("cluster4", testTarget(true)),
+	)
+
+	// The user may write ManifestWorks in cluster1 and cluster3 only
+	var reviewed []string
+	authorize := func(_ context.Context, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+		reviewed = append(reviewed, attributes.Verb+" "+attributes.Namespace)
+		assert.Equal(t, workv1.GroupName, attributes.Group)
+		assert.Equal(t, "manifestworks", attributes.Resource)
+		assert.Equal(t, DefaultWorkName("default", "web"), attributes.Name)
+		if attributes.Namespace == "cluster1" || attributes.Namespace == "cluster3" {
+			return true, "", nil
+		}
+		return false, "denied", nil
+	}
+
+	results := Sync(context.Background(), workClient, testTarget(true), []string{"cluster1", "cluster2"}, authorize)
+
+	actions := make(map[string]string)
+	for _, result := range results {
+		actions[result.ClusterName] = result.Action
+	}
+	assert.Equal(t, map[string]string{
+		"cluster1": ActionCreated,
+		"cluster2": ActionForbidden,
+		"cluster3": ActionDeleted,
+		"cluster4": ActionForbidden,
+	}, actions)
+	assert.ElementsMatch(t, []string{"create cluster1", "update cluster1", "create cluster2", "delete cluster3", "delete cluster4"}, reviewed)
+
+	for cluster, exists := range map[string]bool{"cluster1": true, "cluster2": false, "cluster3": false, "cluster4": true} {
+		_, err := workClient.WorkV1().ManifestWorks(cluster).Get(context.Background(), DefaultWorkName("default", "web"), metav1.GetOptions{})
+		assert.Equal(t, exists, err == nil, cluster)
+	}
+}
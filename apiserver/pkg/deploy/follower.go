@@ -0,0 +1,287 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	clusterlisterv1beta1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1beta1"
+	workv1client "open-cluster-management.io/api/client/work/clientset/versioned"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+// FollowStateLabel marks the ConfigMaps holding the followed targets
+const FollowStateLabel = "dashboard.open-cluster-management.io/follow-state"
+
+const (
+	followStatePrefix = "ocm-dashboard-follow-"
+	followStateKey    = "target"
+)
+
+// Review reports whether user may access a resource as described by attributes,
+// and the reason when they may not
+type Review func(ctx context.Context, user authv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, string, error)
+
+// Follower watches PlacementDecisions and keeps the ManifestWorks of followed
+// targets in sync with the decided clusters of their placement. Followed targets
+// are stored in ConfigMaps in the dashboard namespace of the hub, so every
+// replica follows the same targets and only the dashboard can change them.
+// Decision changes are queued by placement and synced by a worker, so the
+// informer handlers never wait on the hub.
+type Follower struct {
+	ctx        context.Context
+	namespace  string
+	kubeClient kubernetes.Interface
+	workClient workv1client.Interface
+	review     Review
+
+	decisions       clusterlisterv1beta1.PlacementDecisionLister
+	decisionsSynced cache.InformerSynced
+	states          corelisterv1.ConfigMapLister
+	statesSynced    cache.InformerSynced
+	queue           workqueue.RateLimitingInterface
+}
+
+// NewFollower registers PlacementDecision event handlers on the OCM cluster
+// informer factory and starts watching the followed targets stored in namespace.
+// Writes for a target are reviewed for the user who followed it, unless review
+// is nil. Targets are synced by a worker, which stops when ctx is done, once the
+// caller starts the factory.
+func NewFollower(ctx context.Context, ocmClient *client.OCMClient, namespace string, review Review) (*Follower, error) {
+	if ocmClient.KubernetesClient == nil {
+		return nil, fmt.Errorf("Kubernetes client not initialized")
+	}
+
+	decisions := ocmClient.ClusterInformerFactory.Cluster().V1beta1().PlacementDecisions()
+	stateFactory := kubeinformers.NewSharedInformerFactoryWithOptions(ocmClient.KubernetesClient, 0,
+		kubeinformers.WithNamespace(namespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = labels.SelectorFromSet(labels.Set{FollowStateLabel: "true"}).String()
+		}))
+	states := stateFactory.Core().V1().ConfigMaps()
+
+	f := &Follower{
+		ctx:             ctx,
+		namespace:       namespace,
+		kubeClient:      ocmClient.KubernetesClient,
+		workClient:      ocmClient.WorkClient,
+		review:          review,
+		decisions:       decisions.Lister(),
+		decisionsSynced: decisions.Informer().HasSynced,
+		states:          states.Lister(),
+		statesSynced:    states.Informer().HasSynced,
+		queue: workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(),
+			workqueue.RateLimitingQueueConfig{Name: "deploy-follower"}),
+	}
+
+	_, err := decisions.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    f.onDecisionChange,
+		UpdateFunc: func(_, newObj interface{}) { f.onDecisionChange(newObj) },
+		DeleteFunc: f.onDecisionChange,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = states.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    f.onStateChange,
+		UpdateFunc: func(_, newObj interface{}) { f.onStateChange(newObj) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stateFactory.Start(ctx.Done())
+	go f.run()
+	go func() {
+		<-ctx.Done()
+		f.queue.ShutDown()
+	}()
+	slog.Info("Registered placement deploy follower", "stateNamespace", namespace)
+
+	return f, nil
+}
+
+// Follow stores a target so its ManifestWorks follow the placement decisions
+func (f *Follower) Follow(ctx context.Context, target Target) error {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	state := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      followStateName(target.Namespace, target.Placement),
+			Namespace: f.namespace,
+			Labels:    map[string]string{FollowStateLabel: "true"},
+		},
+		Data: map[string]string{followStateKey: string(data)},
+	}
+
+	configMaps := f.kubeClient.CoreV1().ConfigMaps(f.namespace)
+	existing, err := configMaps.Get(ctx, state.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = configMaps.Create(ctx, state, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	}
+
+	state.ResourceVersion = existing.ResourceVersion
+	_, err = configMaps.Update(ctx, state, metav1.UpdateOptions{})
+	return err
+}
+
+// Unfollow stops following the decisions of a placement, deployed ManifestWorks are kept
+func (f *Follower) Unfollow(ctx context.Context, namespace, placement string) error {
+	err := f.kubeClient.CoreV1().ConfigMaps(f.namespace).Delete(ctx, followStateName(namespace, placement), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Target returns the followed target of a placement
+func (f *Follower) Target(namespace, placement string) (Target, bool) {
+	return f.targetByName(followStateName(namespace, placement))
+}
+
+func (f *Follower) targetByName(name string) (Target, bool) {
+	state, err := f.states.ConfigMaps(f.namespace).Get(name)
+	if err != nil {
+		return Target{}, false
+	}
+	return targetFromState(state)
+}
+
+func targetFromState(state *corev1.ConfigMap) (Target, bool) {
+	var target Target
+	if err := json.Unmarshal([]byte(state.Data[followStateKey]), &target); err != nil {
+		slog.Warn("Ignoring invalid follow state", "namespace", state.Namespace, "name", state.Name, "error", err)
+		return Target{}, false
+	}
+	return target, target.Follow
+}
+
+func (f *Follower) onDecisionChange(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pd, ok := obj.(*clusterv1beta1.PlacementDecision)
+	if !ok {
+		return
+	}
+
+	placementName := pd.Labels[clusterv1beta1.PlacementLabel]
+	if placementName == "" {
+		return
+	}
+
+	name := followStateName(pd.Namespace, placementName)
+	if _, ok := f.targetByName(name); ok {
+		f.queue.Add(name)
+	}
+}
+
+func (f *Follower) onStateChange(obj interface{}) {
+	if state, ok := obj.(*corev1.ConfigMap); ok {
+		f.queue.Add(state.Name)
+	}
+}
+
+// run syncs the queued placements until the queue is shut down. Placements that
+// fail to sync are queued again with backoff. Nothing is synced before both the
+// decisions and the followed targets are cached, as missing decisions would
+// delete the deployed ManifestWorks.
+func (f *Follower) run() {
+	if !cache.WaitForCacheSync(f.ctx.Done(), f.decisionsSynced, f.statesSynced) {
+		return
+	}
+
+	for {
+		item, shutdown := f.queue.Get()
+		if shutdown {
+			return
+		}
+		name := item.(string)
+
+		target, ok := f.targetByName(name)
+		if !ok {
+			f.queue.Forget(item)
+			f.queue.Done(item)
+			continue
+		}
+
+		if err := f.sync(target); err != nil {
+			slog.Error("Failed to sync deployed ManifestWorks", "namespace", target.Namespace, "placement", target.Placement, "error", err)
+			f.queue.AddRateLimited(item)
+		} else {
+			f.queue.Forget(item)
+		}
+		f.queue.Done(item)
+	}
+}
+
+// sync deploys the target to the current decided clusters of its placement. It
+// fails when the ManifestWork of any cluster could not be synced. Clusters the
+// user who followed the target may not write to are skipped until the next
+// decision change.
+func (f *Follower) sync(target Target) error {
+	selector := labels.SelectorFromSet(labels.Set{clusterv1beta1.PlacementLabel: target.Placement})
+	decisions, err := f.decisions.PlacementDecisions(target.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range Sync(f.ctx, f.workClient, target, clustersFromDecisions(decisions), f.authorizer(target)) {
+		switch result.Action {
+		case ActionCreated, ActionDeleted:
+			slog.Info("Synced deployed ManifestWork", "namespace", target.Namespace, "placement", target.Placement, "action", result.Action, "cluster", result.ClusterName, "manifestWork", result.ManifestWork, "followedBy", target.FollowedBy.Username)
+		case ActionForbidden:
+			slog.Warn("Skipped deploying ManifestWork", "namespace", target.Namespace, "placement", target.Placement, "cluster", result.ClusterName, "manifestWork", result.ManifestWork, "followedBy", target.FollowedBy.Username, "reason", result.Error)
+		case ActionFailed:
+			slog.Error("Failed to deploy ManifestWork", "namespace", target.Namespace, "placement", target.Placement, "cluster", result.ClusterName, "manifestWork", result.ManifestWork, "error", result.Error)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d ManifestWorks failed to sync", failed)
+	}
+	return nil
+}
+
+// authorizer reviews the writes of a target for the user who followed it. Targets
+// followed without authentication are not reviewed.
+func (f *Follower) authorizer(target Target) Authorizer {
+	if f.review == nil || target.FollowedBy.Username == "" {
+		return nil
+	}
+	return func(ctx context.Context, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+		return f.review(ctx, target.FollowedBy, attributes)
+	}
+}
+
+// followStateName returns the name of the ConfigMap holding the followed target
+// of a placement, hashed as namespace and placement names may exceed its length
+func followStateName(namespace, placement string) string {
+	sum := sha256.Sum256([]byte(namespace + "/" + placement))
+	return followStatePrefix + hex.EncodeToString(sum[:])[:16]
+}
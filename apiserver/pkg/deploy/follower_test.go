@@ -0,0 +1,110 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterinformers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+func TestFollower(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	decision := &clusterv1beta1.PlacementDecision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-decision-1",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1beta1.PlacementLabel: "web"},
+		},
+		Status: clusterv1beta1.PlacementDecisionStatus{
+			Decisions: []clusterv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+		},
+	}
+
+	// A target followed through another replica is read from its stored state
+	target := testTarget(true)
+	target.FollowedBy = authv1.UserInfo{Username: "alice"}
+	data, err := json.Marshal(target)
+	require.NoError(t, err)
+	state := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      followStateName("default", "web"),
+			Namespace: "ocm-dashboard",
+			Labels:    map[string]string{FollowStateLabel: "true"},
+		},
+		Data: map[string]string{followStateKey: string(data)},
+	}
+
+	// A follow mode ManifestWork labeled by anyone else is not followed
+	forged := deployedWork("cluster1", Target{Namespace: "default", Placement: "db", WorkName: "db", Follow: true})
+
+	clusterClient := clusterfake.NewSimpleClientset(decision)
+	workClient := workfake.NewSimpleClientset(deployedWork("cluster1", target), forged)
+	kubeClient := kubefake.NewSimpleClientset(state)
+	ocmClient := &client.OCMClient{
+		KubernetesClient:       kubeClient,
+		ClusterClient:          clusterClient,
+		WorkClient:             workClient,
+		ClusterInformerFactory: clusterinformers.NewSharedInformerFactory(clusterClient, 0),
+	}
+
+	// alice may not write ManifestWorks in cluster3
+	review := func(_ context.Context, user authv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+		assert.Equal(t, "alice", user.Username)
+		return attributes.Namespace != "cluster3", "denied", nil
+	}
+
+	follower, err := NewFollower(ctx, ocmClient, "ocm-dashboard", review)
+	require.NoError(t, err)
+
+	ocmClient.ClusterInformerFactory.Start(ctx.Done())
+	ocmClient.ClusterInformerFactory.WaitForCacheSync(ctx.Done())
+
+	require.Eventually(t, func() bool {
+		followed, ok := follower.Target("default", "web")
+		return ok && followed.FollowedBy.Username == "alice"
+	}, 5*time.Second, 10*time.Millisecond)
+	_, ok := follower.Target("default", "db")
+	assert.False(t, ok)
+
+	// A cluster added to the decisions gets the ManifestWork, unless alice may not write it
+	decision.Status.Decisions = append(decision.Status.Decisions,
+		clusterv1beta1.ClusterDecision{ClusterName: "cluster2"}, clusterv1beta1.ClusterDecision{ClusterName: "cluster3"})
+	_, err = clusterClient.ClusterV1beta1().PlacementDecisions("default").UpdateStatus(ctx, decision, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		work, err := workClient.WorkV1().ManifestWorks("cluster2").Get(ctx, target.WorkName, metav1.GetOptions{})
+		return err == nil && work.Annotations[FollowedByAnnotation] == "alice"
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = workClient.WorkV1().ManifestWorks("cluster3").Get(ctx, target.WorkName, metav1.GetOptions{})
+	assert.Error(t, err)
+
+	// Unfollowing removes the stored state
+	require.NoError(t, follower.Unfollow(ctx, "default", "web"))
+	require.NoError(t, follower.Unfollow(ctx, "default", "web"))
+	_, err = kubeClient.CoreV1().ConfigMaps("ocm-dashboard").Get(ctx, state.Name, metav1.GetOptions{})
+	assert.Error(t, err)
+
+	// Following stores the state again
+	require.NoError(t, follower.Follow(ctx, target))
+	assert.Eventually(t, func() bool {
+		_, ok := follower.Target("default", "web")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,148 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// Detect compares the desired manifest with the live values reported through JSONPaths feedback
+// rules. Every feedback value whose path also resolves in the manifest is compared; scalar values
+// must be equal, while raw JSON values only need to contain the fields set in the manifest, since
+// the live object carries defaulted fields.
+func Detect(manifest map[string]interface{}, paths []models.JsonPath, values []models.FeedbackValue) models.ResourceDrift {
+	result := models.ResourceDrift{Differences: []models.FieldDrift{}}
+
+	pathByName := make(map[string]string, len(paths))
+	for _, path := range paths {
+		pathByName[path.Name] = path.Path
+	}
+
+	for _, value := range values {
+		path, ok := pathByName[value.Name]
+		if !ok {
+			// Values of well known status rules have no counterpart in the manifest
+			result.SkippedFields = append(result.SkippedFields, value.Name)
+			continue
+		}
+
+		desired, found, err := lookup(manifest, value.Name, path)
+		if err != nil || !found {
+			result.SkippedFields = append(result.SkippedFields, value.Name)
+			continue
+		}
+
+		live, err := liveValue(value.Value)
+		if err != nil {
+			result.SkippedFields = append(result.SkippedFields, value.Name)
+			continue
+		}
+
+		result.CheckedFields = append(result.CheckedFields, value.Name)
+		for _, diff := range compare(path, normalize(desired), live) {
+			diff.Feedback = value.Name
+			result.Differences = append(result.Differences, diff)
+		}
+	}
+
+	sort.Strings(result.CheckedFields)
+	sort.Strings(result.SkippedFields)
+	result.Drifted = len(result.Differences) > 0
+
+	return result
+}
+
+// lookup evaluates a feedback JSONPath against the manifest
+func lookup(manifest map[string]interface{}, name, path string) (interface{}, bool, error) {
+	jp := jsonpath.New(name).AllowMissingKeys(true)
+	if err := jp.Parse(fmt.Sprintf("{%s}", path)); err != nil {
+		return nil, false, err
+	}
+
+	results, err := jp.FindResults(manifest)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return nil, false, nil
+	}
+
+	return results[0][0].Interface(), true, nil
+}
+
+// liveValue converts a feedback value to the types produced by decoding JSON
+func liveValue(value models.FieldValue) (interface{}, error) {
+	switch {
+	case value.Integer != nil:
+		return float64(*value.Integer), nil
+	case value.String != nil:
+		return *value.String, nil
+	case value.Boolean != nil:
+		return *value.Boolean, nil
+	case value.JsonRaw != nil:
+		var live interface{}
+		if err := json.Unmarshal([]byte(*value.JsonRaw), &live); err != nil {
+			return nil, err
+		}
+		return live, nil
+	default:
+		return nil, fmt.Errorf("feedback value of type %q has no value", value.Type)
+	}
+}
+
+// normalize converts a manifest value to the types produced by decoding JSON
+func normalize(value interface{}) interface{} {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+// compare returns the fields of desired that differ in live, descending into objects and lists
+func compare(path string, desired, live interface{}) []models.FieldDrift {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var diffs []models.FieldDrift
+		for _, key := range keys {
+			diffs = append(diffs, compare(path+"."+key, d[key], l[key])...)
+		}
+		return diffs
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			break
+		}
+
+		var diffs []models.FieldDrift
+		for i := range d {
+			diffs = append(diffs, compare(fmt.Sprintf("%s[%d]", path, i), d[i], l[i])...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(desired, live) {
+		return nil
+	}
+	return []models.FieldDrift{{Path: path, Desired: desired, Live: live}}
+}
@@ -0,0 +1,83 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func testManifest() map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"replicas": float64(3),
+			"paused":   false,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app:v1"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDetect(t *testing.T) {
+	replicas := int64(5)
+	paused := false
+	image := "app:v1"
+	rawTemplate := `{"spec":{"containers":[{"name":"app","image":"app:v2","imagePullPolicy":"IfNotPresent"}],"restartPolicy":"Always"}}`
+	ready := int64(2)
+
+	paths := []models.JsonPath{
+		{Name: "replicas", Path: ".spec.replicas"},
+		{Name: "paused", Path: ".spec.paused"},
+		{Name: "image", Path: ".spec.template.spec.containers[0].image"},
+		{Name: "template", Path: ".spec.template"},
+		{Name: "readyReplicas", Path: ".status.readyReplicas"},
+	}
+	values := []models.FeedbackValue{
+		{Name: "replicas", Value: models.FieldValue{Type: "Integer", Integer: &replicas}},
+		{Name: "paused", Value: models.FieldValue{Type: "Boolean", Boolean: &paused}},
+		{Name: "image", Value: models.FieldValue{Type: "String", String: &image}},
+		{Name: "template", Value: models.FieldValue{Type: "JsonRaw", JsonRaw: &rawTemplate}},
+		{Name: "readyReplicas", Value: models.FieldValue{Type: "Integer", Integer: &ready}},
+		{Name: "AvailableReplicas", Value: models.FieldValue{Type: "Integer", Integer: &ready}},
+	}
+
+	result := Detect(testManifest(), paths, values)
+
+	assert.True(t, result.Drifted)
+	assert.Equal(t, []string{"image", "paused", "replicas", "template"}, result.CheckedFields)
+	assert.Equal(t, []string{"AvailableReplicas", "readyReplicas"}, result.SkippedFields)
+
+	require.Len(t, result.Differences, 2)
+	assert.Equal(t, models.FieldDrift{Feedback: "replicas", Path: ".spec.replicas", Desired: float64(3), Live: float64(5)}, result.Differences[0])
+	// Defaulted fields of the live object are not reported
+	assert.Equal(t, models.FieldDrift{Feedback: "template", Path: ".spec.template.spec.containers[0].image", Desired: "app:v1", Live: "app:v2"}, result.Differences[1])
+}
+
+func TestDetectNoDrift(t *testing.T) {
+	replicas := int64(3)
+	result := Detect(testManifest(),
+		[]models.JsonPath{{Name: "replicas", Path: ".spec.replicas"}},
+		[]models.FeedbackValue{{Name: "replicas", Value: models.FieldValue{Type: "Integer", Integer: &replicas}}},
+	)
+
+	assert.False(t, result.Drifted)
+	assert.Empty(t, result.Differences)
+	assert.Equal(t, []string{"replicas"}, result.CheckedFields)
+}
+
+func TestCompareListLength(t *testing.T) {
+	diffs := compare(".spec.args", []interface{}{"a", "b"}, []interface{}{"a"})
+
+	require.Len(t, diffs, 1)
+	assert.Equal(t, ".spec.args", diffs[0].Path)
+}
@@ -0,0 +1,185 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// Node kinds of the dependency graph
+const (
+	KindCluster           = "ManagedCluster"
+	KindClusterSet        = "ManagedClusterSet"
+	KindClusterSetBinding = "ManagedClusterSetBinding"
+	KindNamespace         = "Namespace"
+	KindPlacement         = "Placement"
+	KindPlacementDecision = "PlacementDecision"
+	KindManifestWork      = "ManifestWork"
+	KindAddon             = "ManagedClusterAddOn"
+)
+
+// Edge types of the dependency graph
+const (
+	EdgeMember      = "member"
+	EdgeBindsTo     = "bindsTo"
+	EdgeBoundTo     = "boundTo"
+	EdgeSelects     = "selectsFrom"
+	EdgeDecision    = "decision"
+	EdgeDecides     = "decides"
+	EdgeDeploysTo   = "deploysTo"
+	EdgeInstalledOn = "installedOn"
+)
+
+// NodeID returns the identifier of a node, namespace is empty for cluster-scoped resources
+func NodeID(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// Builder accumulates nodes and edges, ignoring duplicates
+type Builder struct {
+	nodes map[string]models.GraphNode
+	edges map[models.GraphEdge]bool
+}
+
+// NewBuilder creates an empty graph builder
+func NewBuilder() *Builder {
+	return &Builder{
+		nodes: make(map[string]models.GraphNode),
+		edges: make(map[models.GraphEdge]bool),
+	}
+}
+
+// AddNode adds a node and returns its identifier
+func (b *Builder) AddNode(kind, namespace, name string) string {
+	id := NodeID(kind, namespace, name)
+	if _, ok := b.nodes[id]; !ok {
+		b.nodes[id] = models.GraphNode{ID: id, Kind: kind, Name: name, Namespace: namespace}
+	}
+	return id
+}
+
+// AddEdge adds a directed edge between two nodes
+func (b *Builder) AddEdge(from, to, edgeType string) {
+	b.edges[models.GraphEdge{From: from, To: to, Type: edgeType}] = true
+}
+
+// Graph returns the accumulated graph with nodes and edges in a stable order
+func (b *Builder) Graph() models.Graph {
+	graph := models.Graph{
+		Nodes: make([]models.GraphNode, 0, len(b.nodes)),
+		Edges: make([]models.GraphEdge, 0, len(b.edges)),
+	}
+	for _, node := range b.nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	for edge := range b.edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	sortEdges(graph.Edges)
+
+	return graph
+}
+
+// Focus returns the subgraph reachable from a node within the given depth,
+// following edges in both directions. A negative depth means no limit.
+func Focus(graph models.Graph, id string, depth int) (models.Graph, error) {
+	found := false
+	for _, node := range graph.Nodes {
+		if node.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return models.Graph{}, fmt.Errorf("node %q not found", id)
+	}
+
+	neighbours := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		neighbours[edge.From] = append(neighbours[edge.From], edge.To)
+		neighbours[edge.To] = append(neighbours[edge.To], edge.From)
+	}
+
+	// Breadth-first search from the focused node
+	visited := map[string]bool{id: true}
+	frontier := []string{id}
+	for level := 0; len(frontier) > 0 && (depth < 0 || level < depth); level++ {
+		var next []string
+		for _, current := range frontier {
+			for _, neighbour := range neighbours[current] {
+				if !visited[neighbour] {
+					visited[neighbour] = true
+					next = append(next, neighbour)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	focused := models.Graph{
+		Nodes:  []models.GraphNode{},
+		Edges:  []models.GraphEdge{},
+		Errors: graph.Errors,
+	}
+	for _, node := range graph.Nodes {
+		if visited[node.ID] {
+			focused.Nodes = append(focused.Nodes, node)
+		}
+	}
+	for _, edge := range graph.Edges {
+		if visited[edge.From] && visited[edge.To] {
+			focused.Edges = append(focused.Edges, edge)
+		}
+	}
+
+	return focused, nil
+}
+
+// DOT renders the graph in Graphviz DOT format
+func DOT(graph models.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph ocm {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, node := range graph.Nodes {
+		label := node.Kind + "\\n" + node.Name
+		if node.Namespace != "" {
+			label = node.Kind + "\\n" + node.Namespace + "/" + node.Name
+		}
+		fmt.Fprintf(&sb, "  %s [label=%s];\n", quote(node.ID), quote(label))
+	}
+
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&sb, "  %s -> %s [label=%s];\n", quote(edge.From), quote(edge.To), quote(edge.Type))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// quote quotes a DOT identifier, keeping already escaped newlines
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+func sortEdges(edges []models.GraphEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Type < edges[j].Type
+	})
+}
@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderDeduplicates(t *testing.T) {
+	b := NewBuilder()
+	cluster := b.AddNode(KindCluster, "", "cluster1")
+	work := b.AddNode(KindManifestWork, "cluster1", "work1")
+	b.AddNode(KindCluster, "", "cluster1")
+	b.AddEdge(work, cluster, EdgeDeploysTo)
+	b.AddEdge(work, cluster, EdgeDeploysTo)
+
+	graph := b.Graph()
+	assert.Len(t, graph.Nodes, 2)
+	assert.Len(t, graph.Edges, 1)
+	assert.Equal(t, "ManagedCluster/cluster1", graph.Nodes[0].ID)
+	assert.Equal(t, "ManifestWork/cluster1/work1", graph.Edges[0].From)
+}
+
+func TestFocus(t *testing.T) {
+	b := NewBuilder()
+	clusterSet := b.AddNode(KindClusterSet, "", "default")
+	cluster1 := b.AddNode(KindCluster, "", "cluster1")
+	cluster2 := b.AddNode(KindCluster, "", "cluster2")
+	work := b.AddNode(KindManifestWork, "cluster1", "work1")
+	b.AddNode(KindCluster, "", "isolated")
+	b.AddEdge(clusterSet, cluster1, EdgeMember)
+	b.AddEdge(clusterSet, cluster2, EdgeMember)
+	b.AddEdge(work, cluster1, EdgeDeploysTo)
+	graph := b.Graph()
+
+	tests := []struct {
+		name          string
+		depth         int
+		expectedNodes int
+		expectedEdges int
+	}{
+		{name: "depth zero", depth: 0, expectedNodes: 1, expectedEdges: 0},
+		{name: "depth one", depth: 1, expectedNodes: 3, expectedEdges: 2},
+		{name: "unlimited", depth: -1, expectedNodes: 4, expectedEdges: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			focused, err := Focus(graph, cluster1, tt.depth)
+			require.NoError(t, err)
+			assert.Len(t, focused.Nodes, tt.expectedNodes)
+			assert.Len(t, focused.Edges, tt.expectedEdges)
+		})
+	}
+
+	_, err := Focus(graph, "ManagedCluster/missing", 1)
+	assert.Error(t, err)
+}
+
+func TestDOT(t *testing.T) {
+	b := NewBuilder()
+	b.AddEdge(b.AddNode(KindManifestWork, "cluster1", "work1"), b.AddNode(KindCluster, "", "cluster1"), EdgeDeploysTo)
+
+	dot := DOT(b.Graph())
+	assert.Contains(t, dot, "digraph ocm {")
+	assert.Contains(t, dot, `"ManagedCluster/cluster1" [label="ManagedCluster\ncluster1"];`)
+	assert.Contains(t, dot, `"ManifestWork/cluster1/work1" -> "ManagedCluster/cluster1" [label="deploysTo"];`)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+// UserInfoKey is the gin context key of the authv1.UserInfo authenticated by the auth middleware
+const UserInfoKey = "userInfo"
+
+// checkAccess asks the hub with a SubjectAccessReview whether the authenticated user may perform
+// the action. Without an authenticated user, when authentication is bypassed, the action is
+// allowed and only limited by the RBAC of the dashboard itself.
+func checkAccess(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+	value, ok := c.Get(UserInfoKey)
+	if !ok {
+		return true, "", nil
+	}
+	user, ok := value.(authv1.UserInfo)
+	if !ok {
+		return false, "", fmt.Errorf("unexpected user info type %T", value)
+	}
+	return ReviewAccess(ctx, ocmClient, user, attributes)
+}
+
+// ReviewAccess asks the hub of the client with a SubjectAccessReview whether the user may
+// perform the action, returning the reason when it may not.
+func ReviewAccess(ctx context.Context, ocmClient *client.OCMClient, user authv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+	if ocmClient.KubernetesClient == nil {
+		return false, "", fmt.Errorf("Kubernetes client not initialized")
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, values := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}
+
+	result, err := ocmClient.KubernetesClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	if result.Status.Allowed {
+		return true, "", nil
+	}
+
+	reason := result.Status.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("user %q cannot %s %s in namespace %q", user.Username, attributes.Verb, attributes.Resource, attributes.Namespace)
+	}
+	return false, reason, nil
+}
+
+// requireAccess checks the authenticated user may perform the action and responds with
+// 403 Forbidden, or 500 when the review fails, if not. It returns whether the handler may
+// continue.
+func requireAccess(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context, attributes authorizationv1.ResourceAttributes) bool {
+	allowed, reason, err := checkAccess(c, ocmClient, ctx, attributes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": reason})
+		return false
+	}
+	return true
+}
+
+// requestingUser returns the name of the authenticated user, empty when authentication is bypassed
+func requestingUser(c *gin.Context) string {
+	return requestingUserInfo(c).Username
+}
+
+// requestingUserInfo returns the authenticated user of the request, empty when
+// authentication is bypassed
+func requestingUserInfo(c *gin.Context) authv1.UserInfo {
+	if value, ok := c.Get(UserInfoKey); ok {
+		if user, ok := value.(authv1.UserInfo); ok {
+			return user
+		}
+	}
+	return authv1.UserInfo{}
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+// defaultCertificateWindowDays is the default window of GetAddonCertificates
+const defaultCertificateWindowDays = 30
+
+// GetAddonCertificates handles listing the addon registration certificates that expire within a
+// number of days. The certificates are held by the agents on the managed clusters, the hub only
+// sees them through the CSRs it issued, which are garbage collected about an hour after they are
+// approved, and through the ClusterCertificateRotated condition the registration agent reports on
+// the ManagedClusterAddOn. Registrations found in neither are reported as Unknown.
+// Supported query parameters:
+//   - days: expiry window in days (default 30)
+func GetAddonCertificates(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil || ocmClient.KubernetesClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	days := defaultCertificateWindowDays
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid days, expected a non-negative integer"})
+			return
+		}
+		days = parsed
+	}
+
+	addons, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Only objects issued for addons carry the addon name label
+	addonSelector := metav1.ListOptions{LabelSelector: addonv1alpha1.AddonLabelKey}
+	csrs, err := ocmClient.KubernetesClient.CertificatesV1().CertificateSigningRequests().List(ctx, addonSelector)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	list := models.AddonCertificateList{
+		WithinDays: days,
+		Items:      []models.AddonCertificate{},
+	}
+
+	for _, addon := range addons.Items {
+		for _, registration := range addon.Status.Registrations {
+			certificate := findAddonCertificate(addon, registration, csrs.Items, now, days)
+			list.Total++
+
+			switch certificate.Status {
+			case "Unknown":
+				list.Unknown++
+			case "Expiring", "Expired":
+				list.Items = append(list.Items, certificate)
+			}
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		if list.Items[i].NotAfter != list.Items[j].NotAfter {
+			return list.Items[i].NotAfter < list.Items[j].NotAfter
+		}
+		return list.Items[i].ClusterName < list.Items[j].ClusterName
+	})
+
+	c.JSON(http.StatusOK, list)
+}
+
+// certificateRotatedCondition is the condition the registration agent sets on a ManagedClusterAddOn
+// when it rotates the client certificate of the addon
+const certificateRotatedCondition = "ClusterCertificateRotated"
+
+// certificateRotatedMessage matches the message of the certificateRotatedCondition, for example
+// "client certificate rotated starting from 2024-01-01 00:00:00 +0000 UTC to 2025-01-01 00:00:00 +0000 UTC"
+var certificateRotatedMessage = regexp.MustCompile(`starting from (.+) to (.+)$`)
+
+// certificateRotatedTimeLayout is the layout of the times in the certificateRotatedCondition message
+const certificateRotatedTimeLayout = "2006-01-02 15:04:05 -0700 MST"
+
+// findAddonCertificate returns the certificate of an addon registration from the latest issued
+// CSR with the signer of the registration, falling back to the certificate rotation condition of
+// the addon. The condition does not name the signer, so it is only used for addons with a single
+// registration.
+func findAddonCertificate(addon addonv1alpha1.ManagedClusterAddOn, registration addonv1alpha1.RegistrationConfig,
+	csrs []certificatesv1.CertificateSigningRequest, now time.Time, days int) models.AddonCertificate {
+	certificate := models.AddonCertificate{
+		ClusterName: addon.Namespace,
+		AddonName:   addon.Name,
+		SignerName:  registration.SignerName,
+		Status:      "Unknown",
+	}
+
+	var latest *certificatesv1.CertificateSigningRequest
+	for i, csr := range csrs {
+		if csr.Labels[clusterv1.ClusterNameLabelKey] != addon.Namespace || csr.Labels[addonv1alpha1.AddonLabelKey] != addon.Name {
+			continue
+		}
+		if csr.Spec.SignerName != registration.SignerName || len(csr.Status.Certificate) == 0 {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&csr.CreationTimestamp) {
+			latest = &csrs[i]
+		}
+	}
+
+	var notBefore, notAfter time.Time
+	if latest != nil {
+		if issued, err := parseCertificate(latest.Status.Certificate); err == nil {
+			certificate.Source = "CertificateSigningRequest"
+			certificate.SourceName = latest.Name
+			certificate.Subject = issued.Subject.CommonName
+			notBefore, notAfter = issued.NotBefore, issued.NotAfter
+		}
+	}
+
+	if certificate.Source == "" && len(addon.Status.Registrations) == 1 {
+		condition := meta.FindStatusCondition(addon.Status.Conditions, certificateRotatedCondition)
+		if condition != nil {
+			if from, to, ok := parseCertificateRotatedMessage(condition.Message); ok {
+				certificate.Source = "ManagedClusterAddOnCondition"
+				certificate.SourceName = certificateRotatedCondition
+				if registration.Subject.User != "" {
+					certificate.Subject = registration.Subject.User
+				}
+				notBefore, notAfter = from, to
+			}
+		}
+	}
+
+	if certificate.Source == "" {
+		return certificate
+	}
+
+	remaining := int(math.Floor(notAfter.Sub(now).Hours() / 24))
+	certificate.NotBefore = notBefore.UTC().Format(time.RFC3339)
+	certificate.NotAfter = notAfter.UTC().Format(time.RFC3339)
+	certificate.DaysRemaining = &remaining
+
+	switch {
+	case !now.Before(notAfter):
+		certificate.Status = "Expired"
+	case notAfter.Before(now.AddDate(0, 0, days)):
+		certificate.Status = "Expiring"
+	default:
+		certificate.Status = "Valid"
+	}
+
+	return certificate
+}
+
+// parseCertificateRotatedMessage returns the validity of the certificate named by the message of
+// the certificate rotation condition
+func parseCertificateRotatedMessage(message string) (time.Time, time.Time, bool) {
+	match := certificateRotatedMessage.FindStringSubmatch(message)
+	if match == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	notBefore, err := time.Parse(certificateRotatedTimeLayout, match[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	notAfter, err := time.Parse(certificateRotatedTimeLayout, match[2])
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return notBefore, notAfter, true
+}
+
+// parseCertificate parses the first PEM encoded certificate
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func newTestCertificatePEM(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestGetAddonCertificates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const clientSigner = certificatesv1.KubeAPIServerClientSignerName
+	const customSigner = "open-cluster-management.io/custom"
+	now := time.Now()
+
+	newAddon := func(cluster, name string, signers ...string) *addonv1alpha1.ManagedClusterAddOn {
+		addon := &addonv1alpha1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster}}
+		for _, signer := range signers {
+			addon.Status.Registrations = append(addon.Status.Registrations, addonv1alpha1.RegistrationConfig{SignerName: signer})
+		}
+		return addon
+	}
+	newCSR := func(name, cluster, addon, signer string, created time.Time, certificate []byte) *certificatesv1.CertificateSigningRequest {
+		return &certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabelKey: cluster,
+					addonv1alpha1.AddonLabelKey:   addon,
+				},
+			},
+			Spec:   certificatesv1.CertificateSigningRequestSpec{SignerName: signer},
+			Status: certificatesv1.CertificateSigningRequestStatus{Certificate: certificate},
+		}
+	}
+
+	kubeClient := kubefake.NewSimpleClientset(
+		// cluster1 rotated its certificate, only the latest CSR counts
+		newCSR("cluster1-old", "cluster1", "application-manager", clientSigner, now.AddDate(0, -2, 0), newTestCertificatePEM(t, "old", now.AddDate(0, 0, -1))),
+		newCSR("cluster1-new", "cluster1", "application-manager", clientSigner, now.AddDate(0, -1, 0), newTestCertificatePEM(t, "new", now.AddDate(0, 0, 10))),
+		newCSR("cluster1-custom", "cluster1", "application-manager", customSigner, now.AddDate(0, -1, 0), newTestCertificatePEM(t, "custom", now.AddDate(0, 0, 200))),
+		newCSR("cluster2-expired", "cluster2", "application-manager", clientSigner, now.AddDate(-1, 0, 0), newTestCertificatePEM(t, "expired", now.Add(-time.Hour))),
+	)
+
+	// cluster3 has no CSR left, its certificate is read from the rotation condition of the addon
+	rotated := newAddon("cluster3", "application-manager", clientSigner)
+	rotated.Status.Conditions = []metav1.Condition{{
+		Type:   "ClusterCertificateRotated",
+		Status: metav1.ConditionTrue,
+		Reason: "ClientCertificateUpdated",
+		Message: fmt.Sprintf("client certificate rotated starting from %v to %v",
+			now.AddDate(0, -10, 0).UTC().Truncate(time.Second), now.AddDate(0, 0, 45).UTC().Truncate(time.Second)),
+	}}
+
+	addonClient := addonfake.NewSimpleClientset(
+		newAddon("cluster1", "application-manager", clientSigner, customSigner),
+		newAddon("cluster2", "application-manager", clientSigner),
+		rotated,
+		newAddon("cluster4", "application-manager", clientSigner),
+		newAddon("cluster4", "no-registration"),
+	)
+
+	tests := []struct {
+		name            string
+		client          *client.OCMClient
+		query           string
+		expectedStatus  int
+		expectedSources []string
+		expectedStates  []string
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid days",
+			client:         &client.OCMClient{AddonClient: addonClient, KubernetesClient: kubeClient},
+			query:          "?days=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:            "default window",
+			client:          &client.OCMClient{AddonClient: addonClient, KubernetesClient: kubeClient},
+			expectedStatus:  http.StatusOK,
+			expectedSources: []string{"cluster2-expired", "cluster1-new"},
+			expectedStates:  []string{"Expired", "Expiring"},
+		},
+		{
+			name:            "wider window includes the rotation condition",
+			client:          &client.OCMClient{AddonClient: addonClient, KubernetesClient: kubeClient},
+			query:           "?days=60",
+			expectedStatus:  http.StatusOK,
+			expectedSources: []string{"cluster2-expired", "cluster1-new", "ClusterCertificateRotated"},
+			expectedStates:  []string{"Expired", "Expiring", "Expiring"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			GetAddonCertificates(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var list models.AddonCertificateList
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+			assert.Equal(t, 5, list.Total)
+			assert.Equal(t, 1, list.Unknown)
+
+			var sources, statuses []string
+			for _, item := range list.Items {
+				sources = append(sources, item.SourceName)
+				statuses = append(statuses, item.Status)
+				require.NotNil(t, item.DaysRemaining)
+			}
+			assert.Equal(t, tt.expectedSources, sources)
+			assert.Equal(t, tt.expectedStates, statuses)
+			assert.Equal(t, "new", list.Items[1].Subject)
+			assert.Equal(t, 9, *list.Items[1].DaysRemaining)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+)
+
+// GetAddonMatrix handles retrieving the health of every addon on every cluster from a single
+// cluster-wide list of ManagedClusterAddOns.
+// Supported query parameters:
+//   - unhealthy: true to only return cells whose status is not Available
+func GetAddonMatrix(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	unhealthyOnly := false
+	if value := c.Query("unhealthy"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unhealthy, expected true or false"})
+			return
+		}
+		unhealthyOnly = parsed
+	}
+
+	list, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	cells := make([]models.AddonMatrixCell, 0, len(list.Items))
+	for _, item := range list.Items {
+		cells = append(cells, convertManagedClusterAddonToMatrixCell(item))
+	}
+
+	c.JSON(http.StatusOK, buildAddonMatrix(cells, unhealthyOnly))
+}
+
+// buildAddonMatrix summarizes the cells per cluster and per addon, then applies the unhealthy
+// filter to the cells, clusters and addons
+func buildAddonMatrix(cells []models.AddonMatrixCell, unhealthyOnly bool) models.AddonMatrix {
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].ClusterName != cells[j].ClusterName {
+			return cells[i].ClusterName < cells[j].ClusterName
+		}
+		return cells[i].AddonName < cells[j].AddonName
+	})
+
+	clusterSummaries := make(map[string]*models.AddonMatrixSummary)
+	addonSummaries := make(map[string]*models.AddonMatrixSummary)
+	summaryFor := func(summaries map[string]*models.AddonMatrixSummary, name string) *models.AddonMatrixSummary {
+		if _, ok := summaries[name]; !ok {
+			summaries[name] = &models.AddonMatrixSummary{Name: name}
+		}
+		return summaries[name]
+	}
+
+	matrix := models.AddonMatrix{
+		Clusters:         []string{},
+		Addons:           []string{},
+		Cells:            []models.AddonMatrixCell{},
+		ClusterSummaries: []models.AddonMatrixSummary{},
+		AddonSummaries:   []models.AddonMatrixSummary{},
+	}
+	clusters := make(map[string]bool)
+	addons := make(map[string]bool)
+
+	for _, cell := range cells {
+		countAddonMatrixCell(summaryFor(clusterSummaries, cell.ClusterName), cell)
+		countAddonMatrixCell(summaryFor(addonSummaries, cell.AddonName), cell)
+
+		if unhealthyOnly && cell.Status == "Available" {
+			continue
+		}
+		matrix.Cells = append(matrix.Cells, cell)
+		clusters[cell.ClusterName] = true
+		addons[cell.AddonName] = true
+	}
+
+	for cluster := range clusters {
+		matrix.Clusters = append(matrix.Clusters, cluster)
+	}
+	for addon := range addons {
+		matrix.Addons = append(matrix.Addons, addon)
+	}
+	sort.Strings(matrix.Clusters)
+	sort.Strings(matrix.Addons)
+
+	for _, cluster := range matrix.Clusters {
+		matrix.ClusterSummaries = append(matrix.ClusterSummaries, *clusterSummaries[cluster])
+	}
+	for _, addon := range matrix.Addons {
+		matrix.AddonSummaries = append(matrix.AddonSummaries, *addonSummaries[addon])
+	}
+
+	return matrix
+}
+
+func countAddonMatrixCell(summary *models.AddonMatrixSummary, cell models.AddonMatrixCell) {
+	summary.Total++
+	switch cell.Status {
+	case "Available":
+		summary.Available++
+	case "Degraded":
+		summary.Degraded++
+	case "Progressing":
+		summary.Progressing++
+	}
+	if cell.Status != "Available" {
+		summary.Unhealthy++
+	}
+}
+
+// Helper function to convert a ManagedClusterAddOn to a cell of the addon matrix
+func convertManagedClusterAddonToMatrixCell(item addonv1alpha1.ManagedClusterAddOn) models.AddonMatrixCell {
+	conditions := item.Status.Conditions
+
+	cell := models.AddonMatrixCell{
+		ClusterName:         item.Namespace,
+		AddonName:           item.Name,
+		Status:              addonHealthStatus(conditions),
+		Available:           conditionStatus(conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable),
+		Degraded:            conditionStatus(conditions, addonv1alpha1.ManagedClusterAddOnConditionDegraded),
+		Progressing:         conditionStatus(conditions, addonv1alpha1.ManagedClusterAddOnConditionProgressing),
+		RegistrationApplied: conditionStatus(conditions, addonv1alpha1.ManagedClusterAddOnRegistrationApplied),
+		HealthCheckMode:     string(item.Status.HealthCheck.Mode),
+	}
+
+	// Lease is the default health check mode
+	if cell.HealthCheckMode == "" {
+		cell.HealthCheckMode = string(addonv1alpha1.HealthCheckModeLease)
+	}
+
+	// The Available condition is set from the lease or the health prober, its transition
+	// time is the last time the check changed the addon availability
+	if available := meta.FindStatusCondition(conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable); available != nil && !available.LastTransitionTime.IsZero() {
+		cell.LastProbeTime = available.LastTransitionTime.Format(time.RFC3339)
+	}
+
+	return cell
+}
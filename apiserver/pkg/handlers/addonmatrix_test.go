@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+)
+
+func TestGetAddonMatrix(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	probeTime := metav1.NewTime(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	newAddon := func(cluster, name string, mode addonv1alpha1.HealthCheckMode, conditions ...metav1.Condition) *addonv1alpha1.ManagedClusterAddOn {
+		return &addonv1alpha1.ManagedClusterAddOn{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster},
+			Status: addonv1alpha1.ManagedClusterAddOnStatus{
+				Conditions:  conditions,
+				HealthCheck: addonv1alpha1.HealthCheck{Mode: mode},
+			},
+		}
+	}
+	available := metav1.Condition{Type: addonv1alpha1.ManagedClusterAddOnConditionAvailable, Status: metav1.ConditionTrue, LastTransitionTime: probeTime}
+	registered := metav1.Condition{Type: addonv1alpha1.ManagedClusterAddOnRegistrationApplied, Status: metav1.ConditionTrue}
+	degraded := metav1.Condition{Type: addonv1alpha1.ManagedClusterAddOnConditionDegraded, Status: metav1.ConditionTrue}
+
+	addonClient := addonfake.NewSimpleClientset(
+		newAddon("cluster1", "application-manager", "", available, registered),
+		newAddon("cluster1", "config-policy", addonv1alpha1.HealthCheckModeCustomized, available, degraded),
+		newAddon("cluster2", "application-manager", addonv1alpha1.HealthCheckModeLease, available),
+		newAddon("cluster3", "application-manager", ""),
+	)
+
+	tests := []struct {
+		name             string
+		client           *client.OCMClient
+		query            string
+		expectedStatus   int
+		expectedClusters []string
+		expectedAddons   []string
+		expectedCells    int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid unhealthy filter",
+			client:         &client.OCMClient{AddonClient: addonClient},
+			query:          "?unhealthy=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "full matrix",
+			client:           &client.OCMClient{AddonClient: addonClient},
+			expectedStatus:   http.StatusOK,
+			expectedClusters: []string{"cluster1", "cluster2", "cluster3"},
+			expectedAddons:   []string{"application-manager", "config-policy"},
+			expectedCells:    4,
+		},
+		{
+			name:             "unhealthy cells only",
+			client:           &client.OCMClient{AddonClient: addonClient},
+			query:            "?unhealthy=true",
+			expectedStatus:   http.StatusOK,
+			expectedClusters: []string{"cluster1", "cluster3"},
+			expectedAddons:   []string{"application-manager", "config-policy"},
+			expectedCells:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			GetAddonMatrix(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var matrix models.AddonMatrix
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &matrix))
+			assert.Equal(t, tt.expectedClusters, matrix.Clusters)
+			assert.Equal(t, tt.expectedAddons, matrix.Addons)
+			assert.Len(t, matrix.Cells, tt.expectedCells)
+
+			// Summaries count every cell of the row or column, regardless of the filter
+			require.NotEmpty(t, matrix.ClusterSummaries)
+			assert.Equal(t, models.AddonMatrixSummary{Name: "cluster1", Total: 2, Available: 1, Degraded: 1, Unhealthy: 1}, matrix.ClusterSummaries[0])
+			assert.Equal(t, models.AddonMatrixSummary{Name: "application-manager", Total: 3, Available: 2, Unhealthy: 1}, matrix.AddonSummaries[0])
+		})
+	}
+}
+
+func TestConvertManagedClusterAddonToMatrixCell(t *testing.T) {
+	probeTime := metav1.NewTime(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	addon := addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: "application-manager", Namespace: "cluster1"},
+		Status: addonv1alpha1.ManagedClusterAddOnStatus{
+			Conditions: []metav1.Condition{
+				{Type: addonv1alpha1.ManagedClusterAddOnConditionAvailable, Status: metav1.ConditionTrue, LastTransitionTime: probeTime},
+				{Type: addonv1alpha1.ManagedClusterAddOnConditionProgressing, Status: metav1.ConditionFalse},
+				{Type: addonv1alpha1.ManagedClusterAddOnRegistrationApplied, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+
+	cell := convertManagedClusterAddonToMatrixCell(addon)
+	assert.Equal(t, models.AddonMatrixCell{
+		ClusterName:         "cluster1",
+		AddonName:           "application-manager",
+		Status:              "Available",
+		Available:           "True",
+		Degraded:            "Unknown",
+		Progressing:         "False",
+		RegistrationApplied: "True",
+		HealthCheckMode:     "Lease",
+		LastProbeTime:       "2026-10-01T12:00:00Z",
+	}, cell)
+}
@@ -7,10 +7,14 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
 	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
 )
 
 // GetClusterAddons handles retrieving all addons for a specific cluster
@@ -33,56 +37,7 @@ func GetClusterAddons(c *gin.Context, ocmClient *client.OCMClient, ctx context.C
 	// Convert to our simplified ManagedClusterAddon format
 	addons := make([]models.ManagedClusterAddon, 0, len(list.Items))
 	for _, item := range list.Items {
-		// Extract the basic metadata
-		addon := models.ManagedClusterAddon{
-			ID:                string(item.GetUID()),
-			Name:              item.GetName(),
-			Namespace:         item.GetNamespace(),
-			CreationTimestamp: item.GetCreationTimestamp().Format(time.RFC3339),
-		}
-
-		// Extract installNamespace from spec
-		if item.Spec.InstallNamespace != "" {
-			addon.InstallNamespace = item.Spec.InstallNamespace
-		}
-
-		// Extract conditions from status
-		for _, condition := range item.Status.Conditions {
-			addon.Conditions = append(addon.Conditions, models.Condition{
-				Type:               string(condition.Type),
-				Status:             string(condition.Status),
-				Reason:             condition.Reason,
-				Message:            condition.Message,
-				LastTransitionTime: condition.LastTransitionTime.Format(time.RFC3339),
-			})
-		}
-
-		// Extract registrations from status
-		for _, registration := range item.Status.Registrations {
-			reg := models.AddonRegistration{
-				SignerName: registration.SignerName,
-			}
-
-			if registration.Subject.User != "" {
-				reg.Subject.User = registration.Subject.User
-			}
-
-			if len(registration.Subject.Groups) > 0 {
-				reg.Subject.Groups = registration.Subject.Groups
-			}
-
-			addon.Registrations = append(addon.Registrations, reg)
-		}
-
-		// Extract supportedConfigs from status
-		for _, config := range item.Status.SupportedConfigs {
-			addon.SupportedConfigs = append(addon.SupportedConfigs, models.AddonSupportedConfig{
-				Group:    config.Group,
-				Resource: config.Resource,
-			})
-		}
-
-		addons = append(addons, addon)
+		addons = append(addons, convertManagedClusterAddonToModel(item))
 	}
 
 	c.JSON(http.StatusOK, addons)
@@ -99,6 +54,12 @@ func GetClusterAddon(c *gin.Context, ocmClient *client.OCMClient, ctx context.Co
 		return
 	}
 
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.ManagedClusterAddonResource, clusterName, addonName)
+		return
+	}
+
 	// Get the real managed cluster addon
 	item, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(clusterName).Get(ctx, addonName, metav1.GetOptions{})
 	if err != nil {
@@ -106,6 +67,156 @@ func GetClusterAddon(c *gin.Context, ocmClient *client.OCMClient, ctx context.Co
 		return
 	}
 
+	// Convert to our simplified ManagedClusterAddon format
+	addon := convertManagedClusterAddonToModel(*item)
+
+	c.JSON(http.StatusOK, addon)
+}
+
+// GetClusterAddonConfig handles resolving the config references of an addon on a cluster,
+// returning each referenced config and whether the spoke applied the latest one
+func GetClusterAddonConfig(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	clusterName := c.Param("name")
+	addonName := c.Param("addonName")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	item, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(clusterName).Get(ctx, addonName, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	status := models.AddonConfigStatus{
+		AddonName:   addonName,
+		ClusterName: clusterName,
+		UpToDate:    true,
+		Configs:     []models.ResolvedAddonConfig{},
+	}
+
+	for _, reference := range item.Status.ConfigReferences {
+		resolved := models.ResolvedAddonConfig{Reference: convertConfigReferenceToModel(reference)}
+		status.UpToDate = status.UpToDate && resolved.Reference.Applied
+
+		ref := resolved.Reference
+		if ref.Group == addonv1alpha1.GroupName && ref.Resource == "addondeploymentconfigs" {
+			config, err := ocmClient.AddonClient.AddonV1alpha1().AddOnDeploymentConfigs(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				resolved.Error = err.Error()
+			} else {
+				resolved.DeploymentConfig = convertAddonDeploymentConfigToModel(*config)
+			}
+		} else {
+			object, err := getAddonConfigObject(ctx, ocmClient, ref)
+			if err != nil {
+				resolved.Error = err.Error()
+			} else {
+				resolved.Object = object
+			}
+		}
+
+		status.Configs = append(status.Configs, resolved)
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// getAddonConfigObject fetches a config of any other type with the preferred version of its group
+func getAddonConfigObject(ctx context.Context, ocmClient *client.OCMClient, ref models.AddonConfigReference) (map[string]interface{}, error) {
+	if ocmClient.KubernetesClient == nil || ocmClient.Interface == nil {
+		return nil, fmt.Errorf("Kubernetes client not initialized")
+	}
+
+	groups, err := ocmClient.KubernetesClient.Discovery().ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	version := ""
+	for _, group := range groups.Groups {
+		if group.Name == ref.Group {
+			version = group.PreferredVersion.Version
+			break
+		}
+	}
+	if version == "" {
+		return nil, fmt.Errorf("API group %q is not served by the hub", ref.Group)
+	}
+
+	gvr := schema.GroupVersionResource{Group: ref.Group, Version: version, Resource: ref.Resource}
+	object, err := ocmClient.Interface.Resource(gvr).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	object.SetManagedFields(nil)
+	return object.Object, nil
+}
+
+// Helper function to convert an AddOnDeploymentConfig to our simplified model
+func convertAddonDeploymentConfigToModel(config addonv1alpha1.AddOnDeploymentConfig) *models.AddonDeploymentConfig {
+	result := &models.AddonDeploymentConfig{
+		Name:                  config.Name,
+		Namespace:             config.Namespace,
+		AgentInstallNamespace: config.Spec.AgentInstallNamespace,
+	}
+
+	for _, variable := range config.Spec.CustomizedVariables {
+		result.CustomizedVariables = append(result.CustomizedVariables, models.AddonVariable{Name: variable.Name, Value: variable.Value})
+	}
+
+	if placement := config.Spec.NodePlacement; placement != nil {
+		result.NodePlacement = &models.AddonNodePlacement{NodeSelector: placement.NodeSelector}
+		for _, toleration := range placement.Tolerations {
+			result.NodePlacement.Tolerations = append(result.NodePlacement.Tolerations, models.AddonToleration{
+				Key:               toleration.Key,
+				Operator:          string(toleration.Operator),
+				Value:             toleration.Value,
+				Effect:            string(toleration.Effect),
+				TolerationSeconds: toleration.TolerationSeconds,
+			})
+		}
+	}
+
+	for _, registry := range config.Spec.Registries {
+		result.Registries = append(result.Registries, models.AddonImageMirror{Mirror: registry.Mirror, Source: registry.Source})
+	}
+
+	if proxy := config.Spec.ProxyConfig; proxy.HTTPProxy != "" || proxy.HTTPSProxy != "" || proxy.NoProxy != "" || len(proxy.CABundle) > 0 {
+		result.ProxyConfig = &models.AddonProxyConfig{
+			HTTPProxy:   proxy.HTTPProxy,
+			HTTPSProxy:  proxy.HTTPSProxy,
+			NoProxy:     proxy.NoProxy,
+			HasCABundle: len(proxy.CABundle) > 0,
+		}
+	}
+
+	for _, requirements := range config.Spec.ResourceRequirements {
+		resources := models.AddonResourceRequirements{ContainerID: requirements.ContainerID}
+		for name, quantity := range requirements.Resources.Requests {
+			if resources.Requests == nil {
+				resources.Requests = map[string]string{}
+			}
+			resources.Requests[string(name)] = quantity.String()
+		}
+		for name, quantity := range requirements.Resources.Limits {
+			if resources.Limits == nil {
+				resources.Limits = map[string]string{}
+			}
+			resources.Limits[string(name)] = quantity.String()
+		}
+		result.ResourceRequirements = append(result.ResourceRequirements, resources)
+	}
+
+	return result
+}
+
+// Helper function to convert a ManagedClusterAddOn to our simplified model
+func convertManagedClusterAddonToModel(item addonv1alpha1.ManagedClusterAddOn) models.ManagedClusterAddon {
 	// Extract the basic metadata
 	addon := models.ManagedClusterAddon{
 		ID:                string(item.GetUID()),
@@ -155,5 +266,69 @@ func GetClusterAddon(c *gin.Context, ocmClient *client.OCMClient, ctx context.Co
 		})
 	}
 
-	c.JSON(http.StatusOK, addon)
+	// Extract configReferences from status
+	for _, reference := range item.Status.ConfigReferences {
+		addon.ConfigReferences = append(addon.ConfigReferences, convertConfigReferenceToModel(reference))
+	}
+	addon.Template = convertAddonTemplateVersionToModel(item.Status.ConfigReferences)
+
+	return addon
+}
+
+// Helper function to convert a config reference of an addon, the spoke applied the config
+// once the last applied spec hash matches the desired one
+func convertConfigReferenceToModel(reference addonv1alpha1.ConfigReference) models.AddonConfigReference {
+	result := models.AddonConfigReference{
+		Group:                  reference.Group,
+		Resource:               reference.Resource,
+		Namespace:              reference.Namespace,
+		Name:                   reference.Name,
+		LastObservedGeneration: reference.LastObservedGeneration,
+	}
+
+	if desired := reference.DesiredConfig; desired != nil {
+		result.Namespace = desired.Namespace
+		result.Name = desired.Name
+		result.DesiredSpecHash = desired.SpecHash
+	}
+	if applied := reference.LastAppliedConfig; applied != nil {
+		result.LastAppliedSpecHash = applied.SpecHash
+		result.Applied = reference.DesiredConfig != nil && result.DesiredSpecHash != "" &&
+			applied.Namespace == result.Namespace && applied.Name == result.Name &&
+			applied.SpecHash == result.DesiredSpecHash
+	}
+
+	return result
+}
+
+// Helper function to summarize the health of a ManagedClusterAddOn
+func convertManagedClusterAddonToHealth(item addonv1alpha1.ManagedClusterAddOn) models.AddonHealth {
+	addon := convertManagedClusterAddonToModel(item)
+
+	return models.AddonHealth{
+		Name:             addon.Name,
+		Namespace:        addon.Namespace,
+		InstallNamespace: addon.InstallNamespace,
+		Status:           addonHealthStatus(item.Status.Conditions),
+		Conditions:       addon.Conditions,
+	}
+}
+
+// addonHealthStatus derives a single health status from the addon conditions
+func addonHealthStatus(conditions []metav1.Condition) string {
+	if meta.IsStatusConditionTrue(conditions, addonv1alpha1.ManagedClusterAddOnConditionDegraded) {
+		return "Degraded"
+	}
+
+	available := meta.FindStatusCondition(conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable)
+	switch {
+	case available != nil && available.Status == metav1.ConditionTrue:
+		return "Available"
+	case meta.IsStatusConditionTrue(conditions, addonv1alpha1.ManagedClusterAddOnConditionProgressing):
+		return "Progressing"
+	case available != nil && available.Status == metav1.ConditionFalse:
+		return "Unavailable"
+	default:
+		return "Unknown"
+	}
 }
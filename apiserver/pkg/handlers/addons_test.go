@@ -2,14 +2,23 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
 )
 
 func TestGetClusterAddons(t *testing.T) {
@@ -93,3 +102,158 @@ func TestGetClusterAddon(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertConfigReferenceToModel(t *testing.T) {
+	referent := addonv1alpha1.ConfigReferent{Namespace: "cluster1", Name: "deploy-config"}
+	groupResource := addonv1alpha1.ConfigGroupResource{Group: addonv1alpha1.GroupName, Resource: "addondeploymentconfigs"}
+
+	tests := []struct {
+		name            string
+		reference       addonv1alpha1.ConfigReference
+		expectedApplied bool
+	}{
+		{
+			name: "latest config applied",
+			reference: addonv1alpha1.ConfigReference{
+				ConfigGroupResource: groupResource,
+				DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: referent, SpecHash: "abc"},
+				LastAppliedConfig:   &addonv1alpha1.ConfigSpecHash{ConfigReferent: referent, SpecHash: "abc"},
+			},
+			expectedApplied: true,
+		},
+		{
+			name: "older config applied",
+			reference: addonv1alpha1.ConfigReference{
+				ConfigGroupResource: groupResource,
+				DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: referent, SpecHash: "abc"},
+				LastAppliedConfig:   &addonv1alpha1.ConfigSpecHash{ConfigReferent: referent, SpecHash: "def"},
+			},
+		},
+		{
+			name: "config not applied yet",
+			reference: addonv1alpha1.ConfigReference{
+				ConfigGroupResource: groupResource,
+				DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: referent, SpecHash: "abc"},
+			},
+		},
+		{
+			name: "no desired config",
+			reference: addonv1alpha1.ConfigReference{
+				ConfigGroupResource: groupResource,
+				ConfigReferent:      referent,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := convertConfigReferenceToModel(tt.reference)
+			assert.Equal(t, "addondeploymentconfigs", result.Resource)
+			assert.Equal(t, "cluster1", result.Namespace)
+			assert.Equal(t, "deploy-config", result.Name)
+			assert.Equal(t, tt.expectedApplied, result.Applied)
+		})
+	}
+}
+
+func TestGetClusterAddonConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cpu := resource.MustParse("100m")
+	deployConfig := &addonv1alpha1.AddOnDeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy-config", Namespace: "cluster1"},
+		Spec: addonv1alpha1.AddOnDeploymentConfigSpec{
+			CustomizedVariables: []addonv1alpha1.CustomizedVariable{{Name: "LOG_LEVEL", Value: "debug"}},
+			NodePlacement: &addonv1alpha1.NodePlacement{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations:  []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+			},
+			Registries:  []addonv1alpha1.ImageMirror{{Mirror: "quay.io/mirror", Source: "quay.io/open-cluster-management"}},
+			ProxyConfig: addonv1alpha1.ProxyConfig{HTTPSProxy: "https://proxy:3129", CABundle: []byte("ca")},
+			ResourceRequirements: []addonv1alpha1.ContainerResourceRequirements{
+				{ContainerID: "deployments:*:*", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: cpu}}},
+			},
+		},
+	}
+
+	addon := &addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: "application-manager", Namespace: "cluster1"},
+		Status: addonv1alpha1.ManagedClusterAddOnStatus{
+			ConfigReferences: []addonv1alpha1.ConfigReference{
+				{
+					ConfigGroupResource: addonv1alpha1.ConfigGroupResource{Group: addonv1alpha1.GroupName, Resource: "addondeploymentconfigs"},
+					DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Namespace: "cluster1", Name: "deploy-config"}, SpecHash: "abc"},
+					LastAppliedConfig:   &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Namespace: "cluster1", Name: "deploy-config"}, SpecHash: "abc"},
+				},
+				{
+					ConfigGroupResource: addonv1alpha1.ConfigGroupResource{Group: "example.io", Resource: "widgets"},
+					DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Name: "widget"}, SpecHash: "def"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		addonName      string
+		expectedStatus int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			addonName:      "application-manager",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "addon not found",
+			client:         &client.OCMClient{AddonClient: addonfake.NewSimpleClientset()},
+			addonName:      "application-manager",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "resolve config references",
+			client:         &client.OCMClient{AddonClient: addonfake.NewSimpleClientset(addon, deployConfig)},
+			addonName:      "application-manager",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{
+				{Key: "name", Value: "cluster1"},
+				{Key: "addonName", Value: tt.addonName},
+			}
+
+			GetClusterAddonConfig(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var status models.AddonConfigStatus
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+			assert.False(t, status.UpToDate)
+			require.Len(t, status.Configs, 2)
+
+			deployment := status.Configs[0]
+			assert.True(t, deployment.Reference.Applied)
+			assert.Empty(t, deployment.Error)
+			require.NotNil(t, deployment.DeploymentConfig)
+			assert.Equal(t, []models.AddonVariable{{Name: "LOG_LEVEL", Value: "debug"}}, deployment.DeploymentConfig.CustomizedVariables)
+			assert.Equal(t, "infra", deployment.DeploymentConfig.NodePlacement.Tolerations[0].Key)
+			assert.Equal(t, "quay.io/mirror", deployment.DeploymentConfig.Registries[0].Mirror)
+			assert.True(t, deployment.DeploymentConfig.ProxyConfig.HasCABundle)
+			assert.Equal(t, "100m", deployment.DeploymentConfig.ResourceRequirements[0].Requests["cpu"])
+
+			widget := status.Configs[1]
+			assert.False(t, widget.Reference.Applied)
+			assert.Equal(t, "def", widget.Reference.DesiredSpecHash)
+			assert.NotEmpty(t, widget.Error)
+		})
+	}
+}
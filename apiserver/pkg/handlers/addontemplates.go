@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+)
+
+// addonTemplateResource is the config resource template-based addons reference their AddOnTemplate with
+const addonTemplateResource = "addontemplates"
+
+// GetAddonTemplates handles retrieving all AddOnTemplates
+func GetAddonTemplates(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	list, err := ocmClient.AddonClient.AddonV1alpha1().AddOnTemplates().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	templates := make([]models.AddonTemplate, 0, len(list.Items))
+	for _, item := range list.Items {
+		templates = append(templates, convertAddonTemplateToModel(item))
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetAddonTemplate handles retrieving a specific AddOnTemplate
+func GetAddonTemplate(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.AddOnTemplateResource, "", name)
+		return
+	}
+
+	item, err := ocmClient.AddonClient.AddonV1alpha1().AddOnTemplates().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertAddonTemplateToModel(*item))
+}
+
+// Helper function to convert an AddOnTemplate to our simplified model
+func convertAddonTemplateToModel(item addonv1alpha1.AddOnTemplate) models.AddonTemplate {
+	template := models.AddonTemplate{
+		ID:                string(item.GetUID()),
+		Name:              item.GetName(),
+		AddonName:         item.Spec.AddonName,
+		AgentSpec:         convertManifestWorkSpecToModel(item.Spec.AgentSpec),
+		CreationTimestamp: item.GetCreationTimestamp().Format(time.RFC3339),
+	}
+
+	for _, registration := range item.Spec.Registration {
+		result := models.AddonTemplateRegistration{Type: string(registration.Type)}
+
+		if kubeClient := registration.KubeClient; kubeClient != nil {
+			for _, permission := range kubeClient.HubPermissions {
+				hubPermission := models.AddonHubPermission{Type: string(permission.Type)}
+				if permission.CurrentCluster != nil {
+					hubPermission.ClusterRoleName = permission.CurrentCluster.ClusterRoleName
+				}
+				if permission.SingleNamespace != nil {
+					hubPermission.Namespace = permission.SingleNamespace.Namespace
+					hubPermission.RoleKind = permission.SingleNamespace.RoleRef.Kind
+					hubPermission.RoleName = permission.SingleNamespace.RoleRef.Name
+				}
+				result.HubPermissions = append(result.HubPermissions, hubPermission)
+			}
+		}
+
+		if signer := registration.CustomSigner; signer != nil {
+			result.SignerName = signer.SignerName
+			if signer.Subject != nil {
+				result.Subject = &models.AddonRegistrationSubject{User: signer.Subject.User, Groups: signer.Subject.Groups}
+			}
+			result.SigningCA = signer.SigningCA.Name
+			if signer.SigningCA.Namespace != "" {
+				result.SigningCA = signer.SigningCA.Namespace + "/" + signer.SigningCA.Name
+			}
+		}
+
+		template.Registration = append(template.Registration, result)
+	}
+
+	return template
+}
+
+// Helper function to resolve the AddOnTemplate of a template-based addon from its config references
+func convertAddonTemplateVersionToModel(references []addonv1alpha1.ConfigReference) *models.AddonTemplateVersion {
+	for _, reference := range references {
+		if reference.Group != addonv1alpha1.GroupName || reference.Resource != addonTemplateResource {
+			continue
+		}
+
+		config := convertConfigReferenceToModel(reference)
+		version := &models.AddonTemplateVersion{
+			Name:            config.Name,
+			DesiredSpecHash: config.DesiredSpecHash,
+			RunningSpecHash: config.LastAppliedSpecHash,
+			Applied:         config.Applied,
+		}
+		if reference.LastAppliedConfig != nil {
+			version.RunningName = reference.LastAppliedConfig.Name
+		}
+		return version
+	}
+	return nil
+}
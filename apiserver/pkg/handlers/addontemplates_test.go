@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+func newTestAddonTemplate(name string) *addonv1alpha1.AddOnTemplate {
+	return &addonv1alpha1.AddOnTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: addonv1alpha1.AddOnTemplateSpec{
+			AddonName: "hello-template",
+			AgentSpec: workv1.ManifestWorkSpec{
+				Workload: workv1.ManifestsTemplate{
+					Manifests: []workv1.Manifest{
+						{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"hello-agent","namespace":"default"}}`)}},
+					},
+				},
+			},
+			Registration: []addonv1alpha1.RegistrationSpec{
+				{
+					Type: addonv1alpha1.RegistrationTypeKubeClient,
+					KubeClient: &addonv1alpha1.KubeClientRegistrationConfig{
+						HubPermissions: []addonv1alpha1.HubPermissionConfig{
+							{
+								Type:           addonv1alpha1.HubPermissionsBindingCurrentCluster,
+								CurrentCluster: &addonv1alpha1.CurrentClusterBindingConfig{ClusterRoleName: "hello-template"},
+							},
+							{
+								Type: addonv1alpha1.HubPermissionsBindingSingleNamespace,
+								SingleNamespace: &addonv1alpha1.SingleNamespaceBindingConfig{
+									Namespace: "open-cluster-management",
+									RoleRef:   rbacv1.RoleRef{Kind: "Role", Name: "hello-reader"},
+								},
+							},
+						},
+					},
+				},
+				{
+					Type: addonv1alpha1.RegistrationTypeCustomSigner,
+					CustomSigner: &addonv1alpha1.CustomSignerRegistrationConfig{
+						SignerName: "example.com/hello",
+						Subject:    &addonv1alpha1.Subject{User: "hello-agent"},
+						SigningCA:  addonv1alpha1.SigningCARef{Namespace: "open-cluster-management-hub", Name: "hello-ca"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetAddonTemplates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	addonClient := addonfake.NewSimpleClientset(newTestAddonTemplate("hello-template-v2"), newTestAddonTemplate("hello-template-v1"))
+
+	t.Run("nil client", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		GetAddonTemplates(c, nil, context.Background())
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("list templates", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		GetAddonTemplates(c, &client.OCMClient{AddonClient: addonClient}, context.Background())
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var templates []models.AddonTemplate
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &templates))
+		require.Len(t, templates, 2)
+		assert.Equal(t, "hello-template-v1", templates[0].Name)
+		assert.Equal(t, "hello-template-v2", templates[1].Name)
+	})
+}
+
+func TestGetAddonTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		templateName   string
+		expectedStatus int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			templateName:   "hello-template-v1",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "template not found",
+			client:         &client.OCMClient{AddonClient: addonfake.NewSimpleClientset()},
+			templateName:   "hello-template-v1",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "template found",
+			client:         &client.OCMClient{AddonClient: addonfake.NewSimpleClientset(newTestAddonTemplate("hello-template-v1"))},
+			templateName:   "hello-template-v1",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: tt.templateName}}
+
+			GetAddonTemplate(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var template models.AddonTemplate
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &template))
+			assert.Equal(t, "hello-template", template.AddonName)
+			require.Len(t, template.AgentSpec.Workload, 1)
+			assert.Equal(t, "Deployment", template.AgentSpec.Workload[0].RawExtension["kind"])
+
+			require.Len(t, template.Registration, 2)
+			assert.Equal(t, []models.AddonHubPermission{
+				{Type: "CurrentCluster", ClusterRoleName: "hello-template"},
+				{Type: "SingleNamespace", Namespace: "open-cluster-management", RoleKind: "Role", RoleName: "hello-reader"},
+			}, template.Registration[0].HubPermissions)
+			assert.Equal(t, "example.com/hello", template.Registration[1].SignerName)
+			assert.Equal(t, "hello-agent", template.Registration[1].Subject.User)
+			assert.Equal(t, "open-cluster-management-hub/hello-ca", template.Registration[1].SigningCA)
+		})
+	}
+}
+
+func TestConvertAddonTemplateVersionToModel(t *testing.T) {
+	templateResource := addonv1alpha1.ConfigGroupResource{Group: addonv1alpha1.GroupName, Resource: "addontemplates"}
+
+	tests := []struct {
+		name       string
+		references []addonv1alpha1.ConfigReference
+		expected   *models.AddonTemplateVersion
+	}{
+		{
+			name: "not a template-based addon",
+			references: []addonv1alpha1.ConfigReference{
+				{
+					ConfigGroupResource: addonv1alpha1.ConfigGroupResource{Group: addonv1alpha1.GroupName, Resource: "addondeploymentconfigs"},
+					DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Name: "config"}, SpecHash: "abc"},
+				},
+			},
+		},
+		{
+			name: "running the desired template",
+			references: []addonv1alpha1.ConfigReference{
+				{
+					ConfigGroupResource: templateResource,
+					DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Name: "hello-template-v1"}, SpecHash: "abc"},
+					LastAppliedConfig:   &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Name: "hello-template-v1"}, SpecHash: "abc"},
+				},
+			},
+			expected: &models.AddonTemplateVersion{
+				Name: "hello-template-v1", DesiredSpecHash: "abc", RunningName: "hello-template-v1", RunningSpecHash: "abc", Applied: true,
+			},
+		},
+		{
+			name: "upgrading to a new template",
+			references: []addonv1alpha1.ConfigReference{
+				{
+					ConfigGroupResource: templateResource,
+					DesiredConfig:       &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Name: "hello-template-v2"}, SpecHash: "def"},
+					LastAppliedConfig:   &addonv1alpha1.ConfigSpecHash{ConfigReferent: addonv1alpha1.ConfigReferent{Name: "hello-template-v1"}, SpecHash: "abc"},
+				},
+			},
+			expected: &models.AddonTemplateVersion{
+				Name: "hello-template-v2", DesiredSpecHash: "def", RunningName: "hello-template-v1", RunningSpecHash: "abc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, convertAddonTemplateVersionToModel(tt.references))
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// defaultFieldManager is the field manager used for server-side apply when none is given
+const defaultFieldManager = "ocm-dashboard"
+
+// maxApplyBodyBytes bounds the size of the documents accepted by ApplyResources
+const maxApplyBodyBytes = 4 << 20
+
+// applyResource describes an OCM kind that can be applied
+type applyResource struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+	// subresourceAccess returns the subresource permissions the OCM webhooks
+	// require of the writer of the object. The dashboard writes with its own
+	// service account, so they are checked for the user first.
+	subresourceAccess func(obj *unstructured.Unstructured) []authorizationv1.ResourceAttributes
+}
+
+// applyResources are the OCM kinds accepted by ApplyResources, keyed by apiVersion and kind.
+// PlacementDecisions are left out since they are written by the placement controller.
+var applyResources = map[schema.GroupVersionKind]applyResource{
+	{Group: "cluster.open-cluster-management.io", Version: "v1", Kind: "ManagedCluster"}:                {gvr: client.ManagedClusterResource, subresourceAccess: acceptAccess},
+	{Group: "cluster.open-cluster-management.io", Version: "v1beta2", Kind: "ManagedClusterSet"}:        {gvr: client.ManagedClusterSetResource},
+	{Group: "cluster.open-cluster-management.io", Version: "v1beta2", Kind: "ManagedClusterSetBinding"}: {gvr: client.ManagedClusterSetBindingResource, namespaced: true, subresourceAccess: bindAccess},
+	{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Kind: "Placement"}:                {gvr: client.PlacementResource, namespaced: true},
+	{Group: "work.open-cluster-management.io", Version: "v1", Kind: "ManifestWork"}:                     {gvr: client.ManifestWorkResource, namespaced: true},
+	{Group: "work.open-cluster-management.io", Version: "v1alpha1", Kind: "ManifestWorkReplicaSet"}:     {gvr: client.ManifestWorkReplicaSetResource, namespaced: true},
+	{Group: "addon.open-cluster-management.io", Version: "v1alpha1", Kind: "ManagedClusterAddOn"}:       {gvr: client.ManagedClusterAddonResource, namespaced: true},
+	{Group: "addon.open-cluster-management.io", Version: "v1alpha1", Kind: "ClusterManagementAddOn"}:    {gvr: client.ClusterManagementAddonResource},
+	{Group: "addon.open-cluster-management.io", Version: "v1alpha1", Kind: "AddOnDeploymentConfig"}:     {gvr: client.AddOnDeploymentConfigResource, namespaced: true},
+}
+
+// acceptAccess requires managedclusters/accept to set hubAcceptsClient on a ManagedCluster
+func acceptAccess(obj *unstructured.Unstructured) []authorizationv1.ResourceAttributes {
+	if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "hubAcceptsClient"); !found {
+		return nil
+	}
+	return []authorizationv1.ResourceAttributes{{
+		Verb:        "update",
+		Group:       client.ManagedClusterResource.Group,
+		Resource:    client.ManagedClusterResource.Resource,
+		Subresource: "accept",
+		Name:        obj.GetName(),
+	}}
+}
+
+// bindAccess requires managedclustersets/bind on the clusterset a ManagedClusterSetBinding binds
+func bindAccess(obj *unstructured.Unstructured) []authorizationv1.ResourceAttributes {
+	clusterSet, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterSet")
+	return []authorizationv1.ResourceAttributes{{
+		Verb:        "create",
+		Group:       client.ManagedClusterSetResource.Group,
+		Resource:    client.ManagedClusterSetResource.Resource,
+		Subresource: "bind",
+		Name:        clusterSet,
+	}}
+}
+
+// ApplyResources handles server-side applying a multi-document YAML of OCM resources.
+// Supported query parameters:
+//   - fieldManager: field manager recorded for the applied fields (default ocm-dashboard)
+//   - force: take ownership of fields owned by other managers
+//   - dryRun: validate the documents on the hub without persisting them
+//
+// Every document is applied independently and reported in the results. The
+// requesting user must be allowed to patch each object, or to create it when it
+// does not exist yet, and to use the subresources the OCM webhooks check, such
+// as managedclustersets/bind, otherwise the document is reported as forbidden.
+// Bodies larger than 4 MiB are rejected.
+func ApplyResources(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.Interface == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid force, expected true or false"})
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dryRun, expected true or false"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxApplyBodyBytes)
+	body, err := c.GetRawData()
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Request body exceeds %d bytes", tooLarge.Limit)})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := models.ApplyResponse{
+		FieldManager: c.DefaultQuery("fieldManager", defaultFieldManager),
+		DryRun:       dryRun,
+		Results:      []models.ApplyResult{},
+	}
+
+	options := metav1.ApplyOptions{FieldManager: response.FieldManager, Force: force}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(body), 4096)
+	for index := 0; ; {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			// The rest of the body cannot be read once a document fails to parse
+			response.Results = append(response.Results, models.ApplyResult{Index: index, Status: "Failed", Code: http.StatusBadRequest, Error: err.Error()})
+			break
+		}
+
+		// Skip empty documents, e.g. a leading or trailing separator
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		response.Results = append(response.Results, applyDocument(c, ocmClient, ctx, index, obj, options))
+		index++
+	}
+
+	if len(response.Results) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No documents to apply"})
+		return
+	}
+
+	for _, result := range response.Results {
+		if result.Status == "Applied" {
+			response.Applied++
+		} else {
+			response.Failed++
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// applyDocument server-side applies a single decoded document on behalf of the requesting user
+func applyDocument(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context, index int, obj *unstructured.Unstructured, options metav1.ApplyOptions) models.ApplyResult {
+	result := models.ApplyResult{
+		Index:      index,
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Status:     "Failed",
+		Code:       http.StatusUnprocessableEntity,
+	}
+
+	resource, ok := applyResources[obj.GroupVersionKind()]
+	if !ok {
+		result.Error = fmt.Sprintf("unsupported kind %s %s", obj.GetAPIVersion(), obj.GetKind())
+		return result
+	}
+	if obj.GetName() == "" {
+		result.Error = "metadata.name is required"
+		return result
+	}
+	if resource.namespaced && obj.GetNamespace() == "" {
+		result.Error = "metadata.namespace is required"
+		return result
+	}
+	if !resource.namespaced && obj.GetNamespace() != "" {
+		result.Error = fmt.Sprintf("%s is cluster-scoped, metadata.namespace must be empty", obj.GetKind())
+		return result
+	}
+
+	if available, reason := ocmClient.Capabilities.FeatureAvailable(client.FeatureOf(resource.gvr.GroupResource())); !available {
+		result.Code, result.Error = http.StatusNotImplemented, reason
+		return result
+	}
+
+	resourceClient := ocmClient.Interface.Resource(resource.gvr).Namespace(obj.GetNamespace())
+	allowed, reason, err := authorizeApply(c, ocmClient, ctx, resource, resourceClient, obj)
+	if err != nil {
+		result.Code, result.Error = statusForError(err), err.Error()
+		return result
+	}
+	if !allowed {
+		result.Status, result.Code, result.Error = "Forbidden", http.StatusForbidden, reason
+		return result
+	}
+
+	// Server-side apply rejects objects carrying managedFields
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	if _, err := resourceClient.Apply(ctx, obj.GetName(), obj, options); err != nil {
+		result.Code, result.Error = statusForError(err), err.Error()
+		return result
+	}
+
+	result.Status, result.Code = "Applied", http.StatusOK
+	return result
+}
+
+// authorizeApply checks the requesting user may write the object the way applying
+// it does. The dashboard applies with its own service account, so the user must
+// be allowed to patch the object, or to create it when it does not exist yet.
+// The object is only read when the user holds one of the two permissions, so
+// users without either do not learn whether it exists.
+func authorizeApply(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context, resource applyResource,
+	resourceClient dynamic.ResourceInterface, obj *unstructured.Unstructured) (bool, string, error) {
+	attributes := func(verb string) authorizationv1.ResourceAttributes {
+		return authorizationv1.ResourceAttributes{
+			Namespace: obj.GetNamespace(),
+			Verb:      verb,
+			Group:     resource.gvr.Group,
+			Resource:  resource.gvr.Resource,
+			Name:      obj.GetName(),
+		}
+	}
+
+	canPatch, patchReason, err := checkAccess(c, ocmClient, ctx, attributes("patch"))
+	if err != nil {
+		return false, "", err
+	}
+	canCreate, createReason, err := checkAccess(c, ocmClient, ctx, attributes("create"))
+	if err != nil {
+		return false, "", err
+	}
+
+	switch {
+	case !canPatch && !canCreate:
+		return false, patchReason, nil
+	case !canPatch || !canCreate:
+		_, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		exists := err == nil
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, "", err
+		}
+		if exists && !canPatch {
+			return false, patchReason, nil
+		}
+		if !exists && !canCreate {
+			return false, createReason, nil
+		}
+	}
+
+	if resource.subresourceAccess == nil {
+		return true, "", nil
+	}
+	for _, attributes := range resource.subresourceAccess(obj) {
+		allowed, reason, err := checkAccess(c, ocmClient, ctx, attributes)
+		if err != nil || !allowed {
+			return false, reason, err
+		}
+	}
+	return true, "", nil
+}
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+const applyTestDocuments = `---
+apiVersion: cluster.open-cluster-management.io/v1beta1
+kind: Placement
+metadata:
+  name: placement
+  namespace: default
+spec:
+  numberOfClusters: 2
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+---
+apiVersion: work.open-cluster-management.io/v1
+kind: ManifestWork
+metadata:
+  name: work
+---
+apiVersion: cluster.open-cluster-management.io/v1
+kind: ManagedCluster
+metadata:
+  name: cluster1
+  namespace: default
+`
+
+func TestApplyResources(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The fake dynamic client does not implement server-side apply, so record the applied objects instead
+	var applied []string
+	newClient := func() *client.OCMClient {
+		dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patch := action.(k8stesting.PatchAction)
+			if patch.GetPatchType() != types.ApplyPatchType {
+				return false, nil, nil
+			}
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON(patch.GetPatch()); err != nil {
+				return true, nil, err
+			}
+			applied = append(applied, action.GetResource().Resource+"/"+patch.GetNamespace()+"/"+patch.GetName())
+			return true, obj, nil
+		})
+		return &client.OCMClient{Interface: dynamicClient}
+	}
+
+	tests := []struct {
+		name            string
+		client          *client.OCMClient
+		query           string
+		body            string
+		expectedStatus  int
+		expectedResults []string
+		expectedApplied []string
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			body:           applyTestDocuments,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid force",
+			client:         newClient(),
+			query:          "force=maybe",
+			body:           applyTestDocuments,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty body",
+			client:         newClient(),
+			body:           "---\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:            "per document results",
+			client:          newClient(),
+			query:           "fieldManager=tester",
+			body:            applyTestDocuments,
+			expectedStatus:  http.StatusOK,
+			expectedResults: []string{"Applied", "Failed", "Failed", "Failed"},
+			expectedApplied: []string{"placements/default/placement"},
+		},
+		{
+			name:            "invalid yaml",
+			client:          newClient(),
+			body:            "apiVersion: [",
+			expectedStatus:  http.StatusOK,
+			expectedResults: []string{"Failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied = nil
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/apply?"+tt.query, strings.NewReader(tt.body))
+
+			ApplyResources(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response models.ApplyResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			statuses := make([]string, 0, len(response.Results))
+			for _, result := range response.Results {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.expectedResults, statuses)
+			assert.Equal(t, tt.expectedApplied, applied)
+		})
+	}
+}
+
+func TestApplyDocumentErrors(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/apply?fieldManager=tester", strings.NewReader(applyTestDocuments))
+
+	ApplyResources(c, &client.OCMClient{Interface: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())}, context.Background())
+
+	var response models.ApplyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Results, 4)
+	assert.Equal(t, "tester", response.FieldManager)
+	assert.Equal(t, 4, response.Failed)
+	assert.Contains(t, response.Results[1].Error, "unsupported kind v1 ConfigMap")
+	assert.Equal(t, "metadata.namespace is required", response.Results[2].Error)
+	assert.Contains(t, response.Results[3].Error, "cluster-scoped")
+}
+
+func TestApplyResourcesAccessDenied(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("cluster.open-cluster-management.io/v1beta1")
+	existing.SetKind("Placement")
+	existing.SetNamespace("default")
+	existing.SetName("existing")
+
+	var applied []string
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{client.PlacementResource: "PlacementList"}, existing)
+	dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		applied = append(applied, patch.GetName())
+		return true, existing, nil
+	})
+
+	// The user may create objects but not change existing ones, nor use subresources
+	var verbs []string
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		verb := attributes.Verb
+		if attributes.Subresource != "" {
+			verb += " " + attributes.Resource + "/" + attributes.Subresource
+		}
+		verbs = append(verbs, verb+" "+attributes.Name)
+		review.Status.Allowed = attributes.Verb == "create" && attributes.Subresource == ""
+		return true, review, nil
+	})
+
+	body := `---
+apiVersion: cluster.open-cluster-management.io/v1beta1
+kind: Placement
+metadata:
+  name: existing
+  namespace: default
+---
+apiVersion: cluster.open-cluster-management.io/v1beta1
+kind: Placement
+metadata:
+  name: new
+  namespace: default
+---
+apiVersion: cluster.open-cluster-management.io/v1beta2
+kind: ManagedClusterSetBinding
+metadata:
+  name: global
+  namespace: default
+spec:
+  clusterSet: global
+---
+apiVersion: cluster.open-cluster-management.io/v1
+kind: ManagedCluster
+metadata:
+  name: cluster1
+spec:
+  hubAcceptsClient: true
+`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/apply", strings.NewReader(body))
+	c.Set(UserInfoKey, authv1.UserInfo{Username: "alice"})
+
+	ApplyResources(c, &client.OCMClient{Interface: dynamicClient, KubernetesClient: kubeClient}, context.Background())
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ApplyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Results, 4)
+	assert.Equal(t, "Forbidden", response.Results[0].Status)
+	assert.Equal(t, http.StatusForbidden, response.Results[0].Code)
+	assert.Equal(t, "Applied", response.Results[1].Status)
+	assert.Equal(t, "Forbidden", response.Results[2].Status)
+	assert.Equal(t, "Forbidden", response.Results[3].Status)
+	assert.Equal(t, []string{
+		"patch existing", "create existing",
+		"patch new", "create new",
+		"patch global", "create global", "create managedclustersets/bind global",
+		"patch cluster1", "create cluster1", "update managedclusters/accept cluster1",
+	}, verbs)
+	assert.Equal(t, []string{"new"}, applied)
+}
+
+func TestApplyResourcesHidesExistence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The user may neither patch nor create, the object is not read
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, action.(k8stesting.CreateAction).GetObject(), nil
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/apply", strings.NewReader(`---
+apiVersion: cluster.open-cluster-management.io/v1beta1
+kind: Placement
+metadata:
+  name: placement
+  namespace: default
+`))
+	c.Set(UserInfoKey, authv1.UserInfo{Username: "alice"})
+
+	ApplyResources(c, &client.OCMClient{Interface: dynamicClient, KubernetesClient: kubeClient}, context.Background())
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ApplyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, "Forbidden", response.Results[0].Status)
+	assert.Empty(t, dynamicClient.Actions())
+}
+
+func TestApplyResourcesBodyTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/apply", strings.NewReader(strings.Repeat("#", maxApplyBodyBytes+1)))
+
+	ApplyResources(c, &client.OCMClient{Interface: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())}, context.Background())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestApplyResourcesUnavailableFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The hub does not serve the addon framework
+	kubeClient := kubefake.NewSimpleClientset()
+	discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	for _, list := range newTestServedResources() {
+		if !strings.HasPrefix(list.GroupVersion, "addon.") {
+			discovery.Resources = append(discovery.Resources, list)
+		}
+	}
+	capabilities := client.NewCapabilities(discovery)
+	require.NoError(t, capabilities.Detect())
+
+	body := `---
+apiVersion: addon.open-cluster-management.io/v1alpha1
+kind: ManagedClusterAddOn
+metadata:
+  name: application-manager
+  namespace: cluster1
+`
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/apply", strings.NewReader(body))
+
+	ApplyResources(c, &client.OCMClient{Interface: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), Capabilities: capabilities}, context.Background())
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ApplyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, "Failed", response.Results[0].Status)
+	assert.Equal(t, http.StatusNotImplemented, response.Results[0].Code)
+	assert.Contains(t, response.Results[0].Error, "is not installed on the hub")
+}
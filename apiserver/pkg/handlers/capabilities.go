@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// GetCapabilities handles reporting the OCM APIs served by the hub, whether the
+// version the dashboard supports is served for each resource and the features
+// that are available
+func GetCapabilities(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	capabilities := ocmClient.Capabilities
+	detected, err := capabilities.Detected()
+	result := models.Capabilities{
+		Detected:  detected,
+		Features:  make(map[string]models.FeatureCapability, len(client.Features)),
+		Resources: make([]models.ResourceCapability, 0, len(client.Resources)),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	for _, feature := range client.Features {
+		available, message := capabilities.FeatureAvailable(feature)
+		result.Features[feature] = models.FeatureCapability{
+			Available: available,
+			Optional:  client.OptionalFeature(feature),
+			Message:   message,
+		}
+	}
+
+	for _, gvr := range client.Resources {
+		versions, _ := capabilities.ResourceVersions(gvr.GroupResource())
+		served := versions.Served
+		if served == nil {
+			served = []string{}
+		}
+		result.Resources = append(result.Resources, models.ResourceCapability{
+			Group:             gvr.Group,
+			Resource:          gvr.Resource,
+			Version:           versions.Selected,
+			ServedVersions:    served,
+			SupportedVersions: client.SupportedVersions[gvr.GroupResource()],
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	withoutTemplates := func(lists []*metav1.APIResourceList) []*metav1.APIResourceList {
+		for _, list := range lists {
+			var resources []metav1.APIResource
+			for _, resource := range list.APIResources {
+				if resource.Name != "addontemplates" {
+					resources = append(resources, resource)
+				}
+			}
+			list.APIResources = resources
+		}
+		return lists
+	}
+
+	tests := []struct {
+		name                string
+		served              func([]*metav1.APIResourceList) []*metav1.APIResourceList
+		detect              bool
+		expectedDetected    bool
+		expectedUnavailable []string
+		expectedUnselected  []string
+	}{
+		{
+			name:             "all features available",
+			detect:           true,
+			expectedDetected: true,
+		},
+		{
+			name:                "addon templates not installed",
+			served:              withoutTemplates,
+			detect:              true,
+			expectedDetected:    true,
+			expectedUnavailable: []string{client.FeatureAddonTemplates},
+			expectedUnselected:  []string{"addontemplates"},
+		},
+		{
+			name: "not detected",
+			expectedUnselected: func() []string {
+				var resources []string
+				for _, gvr := range client.Resources {
+					resources = append(resources, gvr.Resource)
+				}
+				return resources
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+			discovery.Resources = newTestServedResources()
+			if tt.served != nil {
+				discovery.Resources = tt.served(discovery.Resources)
+			}
+
+			ocmClient := &client.OCMClient{KubernetesClient: kubeClient}
+			if tt.detect {
+				ocmClient.Capabilities = client.NewCapabilities(discovery)
+				require.NoError(t, ocmClient.Capabilities.Detect())
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/capabilities", nil)
+
+			GetCapabilities(c, ocmClient, context.Background())
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var capabilities models.Capabilities
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &capabilities))
+			assert.Equal(t, tt.expectedDetected, capabilities.Detected)
+			assert.Len(t, capabilities.Features, len(client.Features))
+			assert.Len(t, capabilities.Resources, len(client.Resources))
+
+			var unavailable []string
+			for name, feature := range capabilities.Features {
+				if !feature.Available {
+					unavailable = append(unavailable, name)
+					assert.NotEmpty(t, feature.Message, name)
+				}
+			}
+			assert.ElementsMatch(t, tt.expectedUnavailable, unavailable)
+			assert.False(t, capabilities.Features[client.FeatureClusters].Optional)
+			assert.True(t, capabilities.Features[client.FeatureAddons].Optional)
+
+			var unselected []string
+			for _, resource := range capabilities.Resources {
+				if resource.Version == "" {
+					unselected = append(unselected, resource.Resource)
+				}
+			}
+			assert.ElementsMatch(t, tt.expectedUnselected, unselected)
+		})
+	}
+
+	t.Run("client not initialized", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/capabilities", nil)
+
+		GetCapabilities(c, nil, context.Background())
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
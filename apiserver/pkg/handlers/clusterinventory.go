@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+)
+
+// GetClusterInventory handles retrieving everything that runs on, or targets, a specific cluster.
+// The sub-resources are fetched concurrently and failures are reported per section.
+func GetClusterInventory(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	clusterName := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil || ocmClient.AddonClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	inventory := models.ClusterInventory{
+		Addons:        []models.AddonHealth{},
+		ManifestWorks: []models.ManifestWorkSummary{},
+		ClusterSets:   []string{},
+		Placements:    []models.ClusterPlacement{},
+	}
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		clusterSets []clusterv1beta2.ManagedClusterSet
+		clusterErr  error
+	)
+
+	recordError := func(section string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if inventory.Errors == nil {
+			inventory.Errors = make(map[string]string)
+		}
+		inventory.Errors[section] = err.Error()
+	}
+
+	wg.Add(5)
+
+	go func() {
+		defer wg.Done()
+		managedCluster, err := ocmClient.ClusterClient.ClusterV1().ManagedClusters().Get(ctx, clusterName, metav1.GetOptions{})
+		if err != nil {
+			clusterErr = err
+			recordError("cluster", err)
+			return
+		}
+		cluster := convertManagedClusterToCluster(*managedCluster)
+		inventory.Cluster = &cluster
+	}()
+
+	go func() {
+		defer wg.Done()
+		list, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(clusterName).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			recordError("addons", err)
+			return
+		}
+		for _, item := range list.Items {
+			inventory.Addons = append(inventory.Addons, convertManagedClusterAddonToHealth(item))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		list, err := ocmClient.WorkClient.WorkV1().ManifestWorks(clusterName).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			recordError("manifestWorks", err)
+			return
+		}
+		for _, item := range list.Items {
+			inventory.ManifestWorks = append(inventory.ManifestWorks, convertManifestWorkToSummary(item))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		list, err := ocmClient.ListManagedClusterSets(ctx, metav1.ListOptions{})
+		if err != nil {
+			recordError("clusterSets", err)
+			return
+		}
+		clusterSets = list.Items
+	}()
+
+	go func() {
+		defer wg.Done()
+		placements, err := listClusterPlacements(ctx, ocmClient, clusterName)
+		if err != nil {
+			recordError("placements", err)
+			return
+		}
+		inventory.Placements = placements
+	}()
+
+	wg.Wait()
+
+	if apierrors.IsNotFound(clusterErr) {
+		c.JSON(http.StatusNotFound, gin.H{"error": clusterErr.Error()})
+		return
+	}
+
+	// Clusterset membership depends on the cluster labels
+	if inventory.Cluster != nil && clusterSets != nil {
+		inventory.ClusterSets = clusterSetsForCluster(inventory.Cluster.Labels, clusterSets)
+	}
+
+	c.JSON(http.StatusOK, inventory)
+}
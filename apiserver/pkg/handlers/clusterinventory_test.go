@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func TestGetClusterInventory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+			Labels: map[string]string{
+				clusterv1beta2.ClusterSetLabel: "default",
+				"env":                          "prod",
+			},
+		},
+	}
+	clusterSets := []*clusterv1beta2.ManagedClusterSet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: clusterv1beta2.ManagedClusterSetSpec{
+				ClusterSelector: clusterv1beta2.ManagedClusterSelector{
+					SelectorType:  clusterv1beta2.LabelSelector,
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+			},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	}
+	addon := &addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: "application-manager", Namespace: "cluster1"},
+		Status: addonv1alpha1.ManagedClusterAddOnStatus{
+			Conditions: []metav1.Condition{
+				{Type: addonv1alpha1.ManagedClusterAddOnConditionAvailable, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	work := &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+		Status: workv1.ManifestWorkStatus{
+			Conditions: []metav1.Condition{
+				{Type: workv1.WorkApplied, Status: metav1.ConditionTrue},
+			},
+			ResourceStatus: workv1.ManifestResourceStatus{
+				Manifests: []workv1.ManifestCondition{
+					{
+						ResourceMeta: workv1.ManifestResourceMeta{Kind: "Deployment", Name: "app"},
+						Conditions: []metav1.Condition{
+							{Type: workv1.ManifestApplied, Status: metav1.ConditionTrue},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	clusterObjects := []runtime.Object{
+		cluster,
+		newTestPlacementDecision("default", "p-decision-1", "p", "0", "", "cluster1"),
+	}
+	for _, clusterSet := range clusterSets {
+		clusterObjects = append(clusterObjects, clusterSet)
+	}
+
+	ocmClient := &client.OCMClient{
+		ClusterClient: clusterfake.NewSimpleClientset(clusterObjects...),
+		AddonClient:   addonfake.NewSimpleClientset(addon),
+		WorkClient:    workfake.NewSimpleClientset(work),
+	}
+
+	tests := []struct {
+		name           string
+		clusterName    string
+		client         *client.OCMClient
+		expectedStatus int
+	}{
+		{
+			name:           "nil client",
+			clusterName:    "cluster1",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "cluster not found",
+			clusterName:    "missing",
+			client:         ocmClient,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "cluster inventory",
+			clusterName:    "cluster1",
+			client:         ocmClient,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: tt.clusterName}}
+
+			GetClusterInventory(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var inventory models.ClusterInventory
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &inventory))
+			require.NotNil(t, inventory.Cluster)
+			assert.Equal(t, "cluster1", inventory.Cluster.Name)
+			assert.Empty(t, inventory.Errors)
+			assert.Equal(t, []string{"default", "prod"}, inventory.ClusterSets)
+			require.Len(t, inventory.Addons, 1)
+			assert.Equal(t, "Available", inventory.Addons[0].Status)
+			require.Len(t, inventory.ManifestWorks, 1)
+			assert.Equal(t, "Applied", inventory.ManifestWorks[0].Status)
+			require.Len(t, inventory.ManifestWorks[0].Resources, 1)
+			assert.Equal(t, "True", inventory.ManifestWorks[0].Resources[0].Applied)
+			assert.Equal(t, "Unknown", inventory.ManifestWorks[0].Resources[0].Available)
+			require.Len(t, inventory.Placements, 1)
+			assert.Equal(t, "p", inventory.Placements[0].PlacementName)
+		})
+	}
+}
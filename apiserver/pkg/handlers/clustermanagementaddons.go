@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+)
+
+// Actions reported per cluster by InstallAddon and UninstallAddon
+const (
+	addonActionInstalled        = "Installed"
+	addonActionAlreadyInstalled = "AlreadyInstalled"
+	addonActionUninstalled      = "Uninstalled"
+	addonActionNotInstalled     = "NotInstalled"
+	addonActionForbidden        = "Forbidden"
+	addonActionFailed           = "Failed"
+)
+
+// GetClusterManagementAddons handles retrieving the addon catalog with the installations per cluster
+func GetClusterManagementAddons(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	list, err := ocmClient.AddonClient.AddonV1alpha1().ClusterManagementAddOns().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	installations, err := listAddonInstallations(ctx, ocmClient)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	addons := make([]models.ClusterManagementAddon, 0, len(list.Items))
+	for _, item := range list.Items {
+		addons = append(addons, convertClusterManagementAddonToModel(item, installations[item.Name]))
+	}
+	sort.Slice(addons, func(i, j int) bool { return addons[i].Name < addons[j].Name })
+
+	c.JSON(http.StatusOK, addons)
+}
+
+// GetClusterManagementAddon handles retrieving a single addon of the catalog with its installations
+func GetClusterManagementAddon(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	addonName := c.Param("addonName")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.ClusterManagementAddonResource, "", addonName)
+		return
+	}
+
+	item, err := ocmClient.AddonClient.AddonV1alpha1().ClusterManagementAddOns().Get(ctx, addonName, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	installations, err := listAddonInstallations(ctx, ocmClient)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertClusterManagementAddonToModel(*item, installations[addonName]))
+}
+
+// InstallAddon handles installing an addon on the requested clusters by creating a
+// ManagedClusterAddOn in each cluster namespace the user is allowed to create it in
+func InstallAddon(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	changeAddonInstallations(c, ocmClient, ctx, "create", installAddonOnCluster)
+}
+
+// UninstallAddon handles uninstalling an addon from the requested clusters by deleting the
+// ManagedClusterAddOn in each cluster namespace the user is allowed to delete it from
+func UninstallAddon(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	changeAddonInstallations(c, ocmClient, ctx, "delete", uninstallAddonFromCluster)
+}
+
+// changeAddonInstallations validates the request and applies the change to every requested
+// cluster after a SubjectAccessReview for the verb on its ManagedClusterAddOn
+func changeAddonInstallations(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context, verb string,
+	change func(ctx context.Context, ocmClient *client.OCMClient, addonName, cluster string, request models.AddonInstallRequest) models.AddonInstallResult) {
+	addonName := c.Param("addonName")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.AddonClient == nil || ocmClient.ClusterClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	var request models.AddonInstallRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if len(request.Clusters) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one cluster is required"})
+		return
+	}
+
+	if _, err := ocmClient.AddonClient.AddonV1alpha1().ClusterManagementAddOns().Get(ctx, addonName, metav1.GetOptions{}); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	response := models.AddonInstallResponse{
+		AddonName: addonName,
+		Results:   []models.AddonInstallResult{},
+	}
+
+	seen := make(map[string]bool, len(request.Clusters))
+	for _, cluster := range request.Clusters {
+		if seen[cluster] {
+			continue
+		}
+		seen[cluster] = true
+
+		result := models.AddonInstallResult{ClusterName: cluster}
+		allowed, reason, err := checkAccess(c, ocmClient, ctx, authorizationv1.ResourceAttributes{
+			Namespace: cluster,
+			Verb:      verb,
+			Group:     addonv1alpha1.GroupName,
+			Resource:  "managedclusteraddons",
+			Name:      addonName,
+		})
+		switch {
+		case err != nil:
+			result.Action, result.Error = addonActionFailed, err.Error()
+		case !allowed:
+			result.Action, result.Error = addonActionForbidden, reason
+		default:
+			result = change(ctx, ocmClient, addonName, cluster, request)
+		}
+
+		if result.Action == addonActionFailed || result.Action == addonActionForbidden {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func installAddonOnCluster(ctx context.Context, ocmClient *client.OCMClient, addonName, cluster string, request models.AddonInstallRequest) models.AddonInstallResult {
+	result := models.AddonInstallResult{ClusterName: cluster}
+
+	// The cluster namespace only exists for registered clusters
+	if _, err := ocmClient.ClusterClient.ClusterV1().ManagedClusters().Get(ctx, cluster, metav1.GetOptions{}); err != nil {
+		result.Action, result.Error = addonActionFailed, err.Error()
+		return result
+	}
+
+	addon := &addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: addonName, Namespace: cluster},
+		Spec:       addonv1alpha1.ManagedClusterAddOnSpec{InstallNamespace: request.InstallNamespace},
+	}
+	_, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(cluster).Create(ctx, addon, metav1.CreateOptions{})
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		result.Action = addonActionAlreadyInstalled
+	case err != nil:
+		result.Action, result.Error = addonActionFailed, err.Error()
+	default:
+		result.Action = addonActionInstalled
+	}
+
+	return result
+}
+
+func uninstallAddonFromCluster(ctx context.Context, ocmClient *client.OCMClient, addonName, cluster string, _ models.AddonInstallRequest) models.AddonInstallResult {
+	result := models.AddonInstallResult{ClusterName: cluster}
+
+	err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(cluster).Delete(ctx, addonName, metav1.DeleteOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		result.Action = addonActionNotInstalled
+	case err != nil:
+		result.Action, result.Error = addonActionFailed, err.Error()
+	default:
+		result.Action = addonActionUninstalled
+	}
+
+	return result
+}
+
+// listAddonInstallations lists the ManagedClusterAddOns of every cluster grouped by addon name
+func listAddonInstallations(ctx context.Context, ocmClient *client.OCMClient) (map[string][]addonv1alpha1.ManagedClusterAddOn, error) {
+	list, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	installations := make(map[string][]addonv1alpha1.ManagedClusterAddOn)
+	for _, item := range list.Items {
+		installations[item.Name] = append(installations[item.Name], item)
+	}
+	return installations, nil
+}
+
+// Helper function to convert a ClusterManagementAddOn and its installations to our simplified model
+func convertClusterManagementAddonToModel(item addonv1alpha1.ClusterManagementAddOn, installations []addonv1alpha1.ManagedClusterAddOn) models.ClusterManagementAddon {
+	addon := models.ClusterManagementAddon{
+		ID:                string(item.GetUID()),
+		Name:              item.GetName(),
+		DisplayName:       item.Spec.AddOnMeta.DisplayName,
+		Description:       item.Spec.AddOnMeta.Description,
+		InstallStrategy:   models.AddonInstallStrategy{Type: item.Spec.InstallStrategy.Type},
+		Installations:     []models.AddonHealth{},
+		CreationTimestamp: item.GetCreationTimestamp().Format(time.RFC3339),
+	}
+
+	// An empty install strategy means the addon is installed manually
+	if addon.InstallStrategy.Type == "" {
+		addon.InstallStrategy.Type = addonv1alpha1.AddonInstallStrategyManual
+	}
+
+	for _, config := range item.Spec.SupportedConfigs {
+		configType := models.AddonConfigType{
+			Group:    config.Group,
+			Resource: config.Resource,
+		}
+		if config.DefaultConfig != nil {
+			configType.DefaultConfig = &models.AddonConfigReferent{
+				Group:     config.Group,
+				Resource:  config.Resource,
+				Namespace: config.DefaultConfig.Namespace,
+				Name:      config.DefaultConfig.Name,
+			}
+		}
+		addon.SupportedConfigs = append(addon.SupportedConfigs, configType)
+	}
+
+	for _, placement := range item.Spec.InstallStrategy.Placements {
+		strategy := models.AddonPlacementStrategy{
+			Namespace:       placement.Namespace,
+			Name:            placement.Name,
+			RolloutStrategy: convertRolloutStrategyToModel(placement.RolloutStrategy),
+		}
+		for _, config := range placement.Configs {
+			strategy.Configs = append(strategy.Configs, models.AddonConfigReferent{
+				Group:     config.Group,
+				Resource:  config.Resource,
+				Namespace: config.Namespace,
+				Name:      config.Name,
+			})
+		}
+		addon.InstallStrategy.Placements = append(addon.InstallStrategy.Placements, strategy)
+	}
+
+	for _, installation := range installations {
+		health := convertManagedClusterAddonToHealth(installation)
+		addon.Installations = append(addon.Installations, health)
+		if health.Status == "Available" {
+			addon.AvailableCount++
+		}
+	}
+	sort.Slice(addon.Installations, func(i, j int) bool { return addon.Installations[i].Namespace < addon.Installations[j].Namespace })
+	addon.InstalledCount = len(addon.Installations)
+
+	return addon
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func newTestClusterManagementAddon(name string) *addonv1alpha1.ClusterManagementAddOn {
+	return &addonv1alpha1.ClusterManagementAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: addonv1alpha1.ClusterManagementAddOnSpec{
+			AddOnMeta: addonv1alpha1.AddOnMeta{DisplayName: "Application Manager"},
+			SupportedConfigs: []addonv1alpha1.ConfigMeta{
+				{
+					ConfigGroupResource: addonv1alpha1.ConfigGroupResource{Group: addonv1alpha1.GroupName, Resource: "addondeploymentconfigs"},
+					DefaultConfig:       &addonv1alpha1.ConfigReferent{Namespace: "open-cluster-management", Name: "default"},
+				},
+			},
+			InstallStrategy: addonv1alpha1.InstallStrategy{
+				Type: addonv1alpha1.AddonInstallStrategyPlacements,
+				Placements: []addonv1alpha1.PlacementStrategy{
+					{PlacementRef: addonv1alpha1.PlacementRef{Namespace: "default", Name: "all"}},
+				},
+			},
+		},
+	}
+}
+
+func newTestInstalledAddon(cluster, name string, available metav1.ConditionStatus) *addonv1alpha1.ManagedClusterAddOn {
+	return &addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster},
+		Status: addonv1alpha1.ManagedClusterAddOnStatus{
+			Conditions: []metav1.Condition{{Type: addonv1alpha1.ManagedClusterAddOnConditionAvailable, Status: available}},
+		},
+	}
+}
+
+func TestGetClusterManagementAddons(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	addonClient := addonfake.NewSimpleClientset(
+		newTestClusterManagementAddon("application-manager"),
+		&addonv1alpha1.ClusterManagementAddOn{ObjectMeta: metav1.ObjectMeta{Name: "config-policy"}},
+		newTestInstalledAddon("cluster2", "application-manager", metav1.ConditionFalse),
+		newTestInstalledAddon("cluster1", "application-manager", metav1.ConditionTrue),
+	)
+
+	t.Run("nil client", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		GetClusterManagementAddons(c, nil, context.Background())
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("list catalog", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		GetClusterManagementAddons(c, &client.OCMClient{AddonClient: addonClient}, context.Background())
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var addons []models.ClusterManagementAddon
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &addons))
+		require.Len(t, addons, 2)
+
+		addon := addons[0]
+		assert.Equal(t, "application-manager", addon.Name)
+		assert.Equal(t, "Application Manager", addon.DisplayName)
+		assert.Equal(t, "Placements", addon.InstallStrategy.Type)
+		require.Len(t, addon.InstallStrategy.Placements, 1)
+		assert.Equal(t, "all", addon.InstallStrategy.Placements[0].Name)
+		require.Len(t, addon.SupportedConfigs, 1)
+		assert.Equal(t, "default", addon.SupportedConfigs[0].DefaultConfig.Name)
+		assert.Equal(t, 2, addon.InstalledCount)
+		assert.Equal(t, 1, addon.AvailableCount)
+		assert.Equal(t, "cluster1", addon.Installations[0].Namespace)
+		assert.Equal(t, "Available", addon.Installations[0].Status)
+		assert.Equal(t, "Unavailable", addon.Installations[1].Status)
+
+		assert.Equal(t, "config-policy", addons[1].Name)
+		assert.Equal(t, "Manual", addons[1].InstallStrategy.Type)
+		assert.Empty(t, addons[1].Installations)
+	})
+
+	t.Run("get missing addon", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "addonName", Value: "missing"}}
+
+		GetClusterManagementAddon(c, &client.OCMClient{AddonClient: addonClient}, context.Background())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestInstallAndUninstallAddon(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newOCMClient := func() *client.OCMClient {
+		kubeClient := kubefake.NewSimpleClientset()
+		// Only cluster2 is forbidden for the user
+		kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			review.Status.Allowed = review.Spec.User == "alice" && review.Spec.ResourceAttributes.Namespace != "cluster2"
+			return true, review, nil
+		})
+
+		return &client.OCMClient{
+			KubernetesClient: kubeClient,
+			ClusterClient: clusterfake.NewSimpleClientset(
+				&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}},
+				&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster2"}},
+				&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster3"}},
+			),
+			AddonClient: addonfake.NewSimpleClientset(
+				newTestClusterManagementAddon("application-manager"),
+				newTestInstalledAddon("cluster3", "application-manager", metav1.ConditionTrue),
+			),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		handler         func(*gin.Context, *client.OCMClient, context.Context)
+		addon           string
+		body            string
+		user            *authv1.UserInfo
+		expectedStatus  int
+		expectedActions map[string]string
+	}{
+		{
+			name:           "missing clusters",
+			handler:        InstallAddon,
+			addon:          "application-manager",
+			body:           `{"clusters":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown addon",
+			handler:        InstallAddon,
+			addon:          "missing",
+			body:           `{"clusters":["cluster1"]}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "install with access review",
+			handler:        InstallAddon,
+			addon:          "application-manager",
+			body:           `{"clusters":["cluster1","cluster2","cluster3","cluster4","cluster1"]}`,
+			user:           &authv1.UserInfo{Username: "alice"},
+			expectedStatus: http.StatusOK,
+			expectedActions: map[string]string{
+				"cluster1": addonActionInstalled,
+				"cluster2": addonActionForbidden,
+				"cluster3": addonActionAlreadyInstalled,
+				"cluster4": addonActionFailed,
+			},
+		},
+		{
+			name:           "install without authenticated user",
+			handler:        InstallAddon,
+			addon:          "application-manager",
+			body:           `{"clusters":["cluster2"]}`,
+			expectedStatus: http.StatusOK,
+			expectedActions: map[string]string{
+				"cluster2": addonActionInstalled,
+			},
+		},
+		{
+			name:           "uninstall with access review",
+			handler:        UninstallAddon,
+			addon:          "application-manager",
+			body:           `{"clusters":["cluster1","cluster3"]}`,
+			user:           &authv1.UserInfo{Username: "alice"},
+			expectedStatus: http.StatusOK,
+			expectedActions: map[string]string{
+				"cluster1": addonActionNotInstalled,
+				"cluster3": addonActionUninstalled,
+			},
+		},
+		{
+			name:           "uninstall denied for other users",
+			handler:        UninstallAddon,
+			addon:          "application-manager",
+			body:           `{"clusters":["cluster3"]}`,
+			user:           &authv1.UserInfo{Username: "bob"},
+			expectedStatus: http.StatusOK,
+			expectedActions: map[string]string{
+				"cluster3": addonActionForbidden,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ocmClient := newOCMClient()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			c.Params = gin.Params{{Key: "addonName", Value: tt.addon}}
+			if tt.user != nil {
+				c.Set(UserInfoKey, *tt.user)
+			}
+
+			tt.handler(c, ocmClient, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response models.AddonInstallResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			actions := make(map[string]string)
+			for _, result := range response.Results {
+				actions[result.ClusterName] = result.Action
+			}
+			assert.Equal(t, tt.expectedActions, actions)
+
+			_, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns("cluster2").Get(context.Background(), tt.addon, metav1.GetOptions{})
+			assert.Equal(t, tt.expectedActions["cluster2"] == addonActionInstalled, err == nil)
+		})
+	}
+}
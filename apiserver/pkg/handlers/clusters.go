@@ -49,6 +49,12 @@ func GetCluster(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context
 		return
 	}
 
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.ManagedClusterResource, "", name)
+		return
+	}
+
 	// Use the OCM typed client to get a specific ManagedCluster
 	managedCluster, err := ocmClient.ClusterClient.ClusterV1().ManagedClusters().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
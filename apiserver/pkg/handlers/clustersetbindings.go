@@ -22,7 +22,7 @@ func GetAllClusterSetBindings(c *gin.Context, ocmClient *client.OCMClient, ctx c
 
 	// Since we don't have a direct way to list resources across all namespaces with the OCM client,
 	// we'll use an empty string to indicate "all namespaces" if the API supports it
-	list, err := ocmClient.ClusterClient.ClusterV1beta2().ManagedClusterSetBindings("").List(ctx, metav1.ListOptions{})
+	list, err := ocmClient.ListManagedClusterSetBindings(ctx, "", metav1.ListOptions{})
 	if err != nil {
 		// If listing across all namespaces is not supported, we'll handle the error
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to list clustersetbindings across all namespaces: " + err.Error()})
@@ -71,7 +71,7 @@ func GetClusterSetBindings(c *gin.Context, ocmClient *client.OCMClient, ctx cont
 	}
 
 	// Get the cluster set bindings for the specified namespace
-	list, err := ocmClient.ClusterClient.ClusterV1beta2().ManagedClusterSetBindings(namespace).List(ctx, metav1.ListOptions{})
+	list, err := ocmClient.ListManagedClusterSetBindings(ctx, namespace, metav1.ListOptions{})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -118,8 +118,14 @@ func GetClusterSetBinding(c *gin.Context, ocmClient *client.OCMClient, ctx conte
 		return
 	}
 
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.ManagedClusterSetBindingResource, namespace, name)
+		return
+	}
+
 	// Get the cluster set binding by name
-	item, err := ocmClient.ClusterClient.ClusterV1beta2().ManagedClusterSetBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+	item, err := ocmClient.GetManagedClusterSetBinding(ctx, namespace, name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -3,13 +3,17 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
 	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 )
 
 // GetClusterSets handles retrieving all cluster sets
@@ -21,7 +25,7 @@ func GetClusterSets(c *gin.Context, ocmClient *client.OCMClient, ctx context.Con
 	}
 
 	// Normal processing - list real managed cluster sets using OCM client
-	list, err := ocmClient.ClusterClient.ClusterV1beta2().ManagedClusterSets().List(ctx, metav1.ListOptions{})
+	list, err := ocmClient.ListManagedClusterSets(ctx, metav1.ListOptions{})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -30,35 +34,7 @@ func GetClusterSets(c *gin.Context, ocmClient *client.OCMClient, ctx context.Con
 	// Convert to our simplified ClusterSet format
 	clusterSets := make([]models.ClusterSet, 0, len(list.Items))
 	for _, item := range list.Items {
-		// Extract the basic metadata
-		clusterSet := models.ClusterSet{
-			ID:                string(item.GetUID()),
-			Name:              item.GetName(),
-			Labels:            item.GetLabels(),
-			CreationTimestamp: item.GetCreationTimestamp().Format(time.RFC3339),
-		}
-
-		// Extract spec info
-		clusterSet.Spec.ClusterSelector.SelectorType = string(item.Spec.ClusterSelector.SelectorType)
-
-		if item.Spec.ClusterSelector.LabelSelector != nil {
-			clusterSet.Spec.ClusterSelector.LabelSelector = &models.LabelSelector{
-				MatchLabels: item.Spec.ClusterSelector.LabelSelector.MatchLabels,
-			}
-		}
-
-		// Extract status info
-		for _, condition := range item.Status.Conditions {
-			clusterSet.Status.Conditions = append(clusterSet.Status.Conditions, models.Condition{
-				Type:               string(condition.Type),
-				Status:             string(condition.Status),
-				LastTransitionTime: condition.LastTransitionTime.Format(time.RFC3339),
-				Reason:             condition.Reason,
-				Message:            condition.Message,
-			})
-		}
-
-		clusterSets = append(clusterSets, clusterSet)
+		clusterSets = append(clusterSets, convertManagedClusterSetToModel(item))
 	}
 
 	c.JSON(http.StatusOK, clusterSets)
@@ -74,14 +50,27 @@ func GetClusterSet(c *gin.Context, ocmClient *client.OCMClient, ctx context.Cont
 		return
 	}
 
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.ManagedClusterSetResource, "", name)
+		return
+	}
+
 	// Get the cluster set by name using OCM client
-	item, err := ocmClient.ClusterClient.ClusterV1beta2().ManagedClusterSets().Get(ctx, name, metav1.GetOptions{})
+	item, err := ocmClient.GetManagedClusterSet(ctx, name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Convert to our simplified ClusterSet format
+	clusterSet := convertManagedClusterSetToModel(*item)
+
+	c.JSON(http.StatusOK, clusterSet)
+}
+
+// Helper function to convert a ManagedClusterSet to our simplified model
+func convertManagedClusterSetToModel(item clusterv1beta2.ManagedClusterSet) models.ClusterSet {
 	clusterSet := models.ClusterSet{
 		ID:                string(item.GetUID()),
 		Name:              item.GetName(),
@@ -109,5 +98,35 @@ func GetClusterSet(c *gin.Context, ocmClient *client.OCMClient, ctx context.Cont
 		})
 	}
 
-	c.JSON(http.StatusOK, clusterSet)
+	return clusterSet
+}
+
+// clusterSetsForCluster returns the names of the clustersets selecting a cluster with the given labels
+func clusterSetsForCluster(clusterLabels map[string]string, clusterSets []clusterv1beta2.ManagedClusterSet) []string {
+	names := make([]string, 0)
+	for _, clusterSet := range clusterSets {
+		selector := clusterSet.Spec.ClusterSelector
+
+		switch selector.SelectorType {
+		case clusterv1beta2.LabelSelector:
+			if selector.LabelSelector == nil {
+				continue
+			}
+			labelSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+			if err != nil {
+				continue
+			}
+			if labelSelector.Matches(labels.Set(clusterLabels)) {
+				names = append(names, clusterSet.Name)
+			}
+		default:
+			// ExclusiveClusterSetLabel is the default selector type
+			if clusterLabels[clusterv1beta2.ClusterSetLabel] == clusterSet.Name {
+				names = append(names, clusterSet.Name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
 }
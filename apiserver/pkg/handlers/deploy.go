@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/deploy"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// DeployToPlacement handles deploying a ManifestWork spec to every cluster decided by a placement.
+// Supported query parameters:
+//   - name: ManifestWork name (default <namespace>.<placement>)
+//   - follow: true to keep the ManifestWorks in sync with later decision changes, false to stop
+func DeployToPlacement(c *gin.Context, ocmClient *client.OCMClient, follower *deploy.Follower, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	follow := false
+	if value := c.Query("follow"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid follow, expected true or false"})
+			return
+		}
+		follow = parsed
+	}
+	if follow && follower == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Follow mode is not available"})
+		return
+	}
+
+	workName := c.DefaultQuery("name", deploy.DefaultWorkName(namespace, name))
+	if msgs := validation.IsDNS1123Subdomain(workName); len(msgs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ManifestWork name: " + strings.Join(msgs, ", ")})
+		return
+	}
+
+	var specModel models.ManifestWorkSpec
+	if err := c.ShouldBindJSON(&specModel); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if len(specModel.Workload) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Workload must contain at least one manifest"})
+		return
+	}
+	spec, err := convertModelToManifestWorkSpec(specModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The placement must exist before anything is deployed for it
+	if _, err := ocmClient.ClusterClient.ClusterV1beta1().Placements(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	clusters, err := deploy.DecidedClusters(ctx, ocmClient.ClusterClient, namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ManifestWorks are written with the service account of the server, so the
+	// user must be allowed to write them in the namespace of every decided cluster
+	for _, cluster := range clusters {
+		for _, verb := range []string{"create", "update", "delete"} {
+			if !requireAccess(c, ocmClient, ctx, authorizationv1.ResourceAttributes{
+				Namespace: cluster,
+				Verb:      verb,
+				Group:     workv1.GroupName,
+				Resource:  "manifestworks",
+				Name:      workName,
+			}) {
+				return
+			}
+		}
+	}
+
+	target := deploy.Target{
+		Namespace: namespace,
+		Placement: name,
+		WorkName:  workName,
+		Spec:      spec,
+		Follow:    follow,
+	}
+	if follow {
+		target.FollowedBy = requestingUserInfo(c)
+	}
+
+	// The follow state is stored first, so a failed deploy is still retried by the follower
+	if follower != nil {
+		var err error
+		if follow {
+			slog.Info("Following placement decisions", "namespace", namespace, "placement", name, "manifestWork", workName, "followedBy", target.FollowedBy.Username)
+			err = follower.Follow(ctx, target)
+		} else {
+			err = follower.Unfollow(ctx, namespace, name)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store follow mode: " + err.Error()})
+			return
+		}
+	}
+
+	result := models.DeployResult{
+		PlacementName:    name,
+		Namespace:        namespace,
+		ManifestWorkName: workName,
+		Follow:           follow,
+		Results:          deploy.Sync(ctx, ocmClient.WorkClient, target, clusters, nil),
+	}
+	for _, clusterResult := range result.Results {
+		if clusterResult.Action == deploy.ActionFailed {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterinformers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/deploy"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func TestDeployToPlacement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const validBody = `{"workload":[{"rawExtension":{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web","namespace":"default"}}}]}`
+
+	newClients := func() (*clusterfake.Clientset, *workfake.Clientset) {
+		clusterClient := clusterfake.NewSimpleClientset(
+			&clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+			&clusterv1beta1.PlacementDecision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web-decision-1",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1beta1.PlacementLabel: "web"},
+				},
+				Status: clusterv1beta1.PlacementDecisionStatus{
+					Decisions: []clusterv1beta1.ClusterDecision{{ClusterName: "cluster1"}, {ClusterName: "cluster2"}},
+				},
+			},
+		)
+		return clusterClient, workfake.NewSimpleClientset()
+	}
+
+	tests := []struct {
+		name             string
+		nilClient        bool
+		user             string
+		placement        string
+		query            string
+		body             string
+		expectedStatus   int
+		expectedWorkName string
+		expectedClusters []string
+	}{
+		{
+			name:           "Nil client",
+			nilClient:      true,
+			placement:      "web",
+			body:           validBody,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:             "Deploy with default name",
+			placement:        "web",
+			body:             validBody,
+			expectedStatus:   http.StatusOK,
+			expectedWorkName: "default.web",
+			expectedClusters: []string{"cluster1", "cluster2"},
+		},
+		{
+			name:             "Deploy with custom name",
+			placement:        "web",
+			query:            "?name=web-app",
+			body:             validBody,
+			expectedStatus:   http.StatusOK,
+			expectedWorkName: "web-app",
+			expectedClusters: []string{"cluster1", "cluster2"},
+		},
+		{
+			name:             "Deploy as an authorized user",
+			user:             "alice",
+			placement:        "web",
+			body:             validBody,
+			expectedStatus:   http.StatusOK,
+			expectedWorkName: "default.web",
+			expectedClusters: []string{"cluster1", "cluster2"},
+		},
+		{
+			name:           "Deploy as a user who may not write ManifestWorks on every cluster",
+			user:           "bob",
+			placement:      "web",
+			body:           validBody,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Follow without follower",
+			placement:      "web",
+			query:          "?follow=true",
+			body:           validBody,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "Invalid follow",
+			placement:      "web",
+			query:          "?follow=maybe",
+			body:           validBody,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid name",
+			placement:      "web",
+			query:          "?name=Web_App",
+			body:           validBody,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Empty workload",
+			placement:      "web",
+			body:           `{"workload":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Manifest without kind",
+			placement:      "web",
+			body:           `{"workload":[{"rawExtension":{"apiVersion":"v1"}}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Placement not found",
+			placement:      "missing",
+			body:           validBody,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterClient, workClient := newClients()
+			var ocmClient *client.OCMClient
+			if !tt.nilClient {
+				// bob may not delete ManifestWorks on cluster2
+				kubeClient := kubefake.NewSimpleClientset()
+				kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+					attributes := review.Spec.ResourceAttributes
+					review.Status.Allowed = attributes.Resource == "manifestworks" &&
+						(review.Spec.User == "alice" || attributes.Namespace != "cluster2" || attributes.Verb != "delete")
+					return true, review, nil
+				})
+				ocmClient = &client.OCMClient{ClusterClient: clusterClient, WorkClient: workClient, KubernetesClient: kubeClient}
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/"+tt.query, bytes.NewBufferString(tt.body))
+			c.Params = gin.Params{
+				{Key: "namespace", Value: "default"},
+				{Key: "name", Value: tt.placement},
+			}
+			if tt.user != "" {
+				c.Set(UserInfoKey, authv1.UserInfo{Username: tt.user})
+			}
+
+			DeployToPlacement(c, ocmClient, nil, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				works, err := workClient.WorkV1().ManifestWorks(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+				require.NoError(t, err)
+				assert.Empty(t, works.Items)
+				return
+			}
+
+			var result models.DeployResult
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			assert.Equal(t, tt.expectedWorkName, result.ManifestWorkName)
+			assert.Equal(t, len(tt.expectedClusters), result.Succeeded)
+			assert.Zero(t, result.Failed)
+
+			for i, cluster := range tt.expectedClusters {
+				assert.Equal(t, cluster, result.Results[i].ClusterName)
+				assert.Equal(t, deploy.ActionCreated, result.Results[i].Action)
+
+				work, err := workClient.WorkV1().ManifestWorks(cluster).Get(context.Background(), tt.expectedWorkName, metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, "web", work.Labels[deploy.PlacementNameLabel])
+				assert.Len(t, work.Spec.Workload.Manifests, 1)
+			}
+		})
+	}
+}
+
+func TestDeployToPlacementFollow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("POD_NAMESPACE", "ocm-dashboard")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clusterClient := clusterfake.NewSimpleClientset(
+		&clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	ocmClient := &client.OCMClient{
+		ClusterClient:          clusterClient,
+		WorkClient:             workfake.NewSimpleClientset(),
+		KubernetesClient:       kubeClient,
+		ClusterInformerFactory: clusterinformers.NewSharedInformerFactory(clusterClient, 0),
+	}
+	follower, err := deploy.NewFollower(ctx, ocmClient, DashboardNamespace(), nil)
+	require.NoError(t, err)
+
+	deployToPlacement := func(query string) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/"+query,
+			bytes.NewBufferString(`{"workload":[{"rawExtension":{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web","namespace":"default"}}}]}`))
+		c.Params = gin.Params{{Key: "namespace", Value: "default"}, {Key: "name", Value: "web"}}
+		c.Set(UserInfoKey, authv1.UserInfo{Username: "alice", Groups: []string{"developers"}})
+
+		DeployToPlacement(c, ocmClient, follower, ctx)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	followState := func() []corev1.ConfigMap {
+		states, err := kubeClient.CoreV1().ConfigMaps("ocm-dashboard").List(ctx, metav1.ListOptions{
+			LabelSelector: deploy.FollowStateLabel + "=true",
+		})
+		require.NoError(t, err)
+		return states.Items
+	}
+
+	// Following stores the target with the requesting user in the dashboard namespace
+	deployToPlacement("?follow=true")
+	states := followState()
+	require.Len(t, states, 1)
+	var target deploy.Target
+	require.NoError(t, json.Unmarshal([]byte(states[0].Data["target"]), &target))
+	assert.Equal(t, "web", target.Placement)
+	assert.Equal(t, authv1.UserInfo{Username: "alice", Groups: []string{"developers"}}, target.FollowedBy)
+
+	// Deploying without follow removes it
+	deployToPlacement("?follow=false")
+	assert.Empty(t, followState())
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/graph"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// graphResources holds the hub resources the dependency graph is built from
+type graphResources struct {
+	clusters           []clusterv1.ManagedCluster
+	clusterSets        []clusterv1beta2.ManagedClusterSet
+	clusterSetBindings []clusterv1beta2.ManagedClusterSetBinding
+	placements         []clusterv1beta1.Placement
+	placementDecisions []clusterv1beta1.PlacementDecision
+	manifestWorks      []workv1.ManifestWork
+	addons             []addonv1alpha1.ManagedClusterAddOn
+}
+
+// GetGraph handles retrieving the dependency graph across OCM resources.
+// Supported query parameters:
+//   - focus: node ID (e.g. ManagedCluster/cluster1) to restrict the graph to
+//   - depth: maximum number of hops from the focused node (default unlimited)
+//   - format: json (default) or dot
+func GetGraph(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil || ocmClient.AddonClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "dot" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected json or dot"})
+		return
+	}
+
+	depth := -1
+	if value := c.Query("depth"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid depth, expected a non-negative integer"})
+			return
+		}
+		depth = parsed
+	}
+
+	resources, errs := fetchGraphResources(ctx, ocmClient)
+	result := buildGraph(resources)
+	if len(errs) > 0 {
+		result.Errors = errs
+	}
+
+	if focus := c.Query("focus"); focus != "" {
+		focused, err := graph.Focus(result, focus, depth)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		result = focused
+	}
+
+	if format == "dot" {
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph.DOT(result)))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// fetchGraphResources lists every resource kind concurrently, reporting failures per kind
+func fetchGraphResources(ctx context.Context, ocmClient *client.OCMClient) (graphResources, map[string]string) {
+	var (
+		resources graphResources
+		errs      map[string]string
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+	)
+
+	recordError := func(kind string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if errs == nil {
+			errs = make(map[string]string)
+		}
+		errs[kind] = err.Error()
+	}
+
+	fetchers := []func(){
+		func() {
+			list, err := ocmClient.ClusterClient.ClusterV1().ManagedClusters().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				recordError(graph.KindCluster, err)
+				return
+			}
+			resources.clusters = list.Items
+		},
+		func() {
+			list, err := ocmClient.ListManagedClusterSets(ctx, metav1.ListOptions{})
+			if err != nil {
+				recordError(graph.KindClusterSet, err)
+				return
+			}
+			resources.clusterSets = list.Items
+		},
+		func() {
+			list, err := ocmClient.ListManagedClusterSetBindings(ctx, "", metav1.ListOptions{})
+			if err != nil {
+				recordError(graph.KindClusterSetBinding, err)
+				return
+			}
+			resources.clusterSetBindings = list.Items
+		},
+		func() {
+			list, err := ocmClient.ClusterClient.ClusterV1beta1().Placements("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				recordError(graph.KindPlacement, err)
+				return
+			}
+			resources.placements = list.Items
+		},
+		func() {
+			list, err := ocmClient.ClusterClient.ClusterV1beta1().PlacementDecisions("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				recordError(graph.KindPlacementDecision, err)
+				return
+			}
+			resources.placementDecisions = list.Items
+		},
+		func() {
+			list, err := ocmClient.WorkClient.WorkV1().ManifestWorks("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				recordError(graph.KindManifestWork, err)
+				return
+			}
+			resources.manifestWorks = list.Items
+		},
+		func() {
+			list, err := ocmClient.AddonClient.AddonV1alpha1().ManagedClusterAddOns("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				recordError(graph.KindAddon, err)
+				return
+			}
+			resources.addons = list.Items
+		},
+	}
+
+	wg.Add(len(fetchers))
+	for _, fetch := range fetchers {
+		go func(fetch func()) {
+			defer wg.Done()
+			fetch()
+		}(fetch)
+	}
+	wg.Wait()
+
+	return resources, errs
+}
+
+// buildGraph creates the nodes and edges between the fetched resources
+func buildGraph(resources graphResources) models.Graph {
+	b := graph.NewBuilder()
+
+	// ClusterSet -> Cluster membership
+	for _, clusterSet := range resources.clusterSets {
+		b.AddNode(graph.KindClusterSet, "", clusterSet.Name)
+	}
+	for _, cluster := range resources.clusters {
+		clusterID := b.AddNode(graph.KindCluster, "", cluster.Name)
+		for _, clusterSetName := range clusterSetsForCluster(cluster.Labels, resources.clusterSets) {
+			b.AddEdge(graph.NodeID(graph.KindClusterSet, "", clusterSetName), clusterID, graph.EdgeMember)
+		}
+	}
+
+	// ClusterSetBinding -> Namespace and ClusterSet
+	boundClusterSets := make(map[string][]string)
+	for _, binding := range resources.clusterSetBindings {
+		bindingID := b.AddNode(graph.KindClusterSetBinding, binding.Namespace, binding.Name)
+		b.AddEdge(bindingID, b.AddNode(graph.KindNamespace, "", binding.Namespace), graph.EdgeBindsTo)
+		b.AddEdge(bindingID, b.AddNode(graph.KindClusterSet, "", binding.Spec.ClusterSet), graph.EdgeBoundTo)
+		boundClusterSets[binding.Namespace] = append(boundClusterSets[binding.Namespace], binding.Spec.ClusterSet)
+	}
+
+	// Placement -> ClusterSet, all bound clustersets are used when none are listed
+	for _, placement := range resources.placements {
+		placementID := b.AddNode(graph.KindPlacement, placement.Namespace, placement.Name)
+
+		clusterSets := placement.Spec.ClusterSets
+		if len(clusterSets) == 0 {
+			clusterSets = boundClusterSets[placement.Namespace]
+		}
+		for _, clusterSet := range clusterSets {
+			b.AddEdge(placementID, b.AddNode(graph.KindClusterSet, "", clusterSet), graph.EdgeSelects)
+		}
+	}
+
+	// Placement -> PlacementDecision -> Cluster
+	for _, pd := range resources.placementDecisions {
+		decisionID := b.AddNode(graph.KindPlacementDecision, pd.Namespace, pd.Name)
+
+		if placementName := pd.Labels[clusterv1beta1.PlacementLabel]; placementName != "" {
+			b.AddEdge(b.AddNode(graph.KindPlacement, pd.Namespace, placementName), decisionID, graph.EdgeDecision)
+		}
+		for _, decision := range pd.Status.Decisions {
+			b.AddEdge(decisionID, b.AddNode(graph.KindCluster, "", decision.ClusterName), graph.EdgeDecides)
+		}
+	}
+
+	// ManifestWork -> Cluster, the work namespace is the cluster name
+	for _, work := range resources.manifestWorks {
+		workID := b.AddNode(graph.KindManifestWork, work.Namespace, work.Name)
+		b.AddEdge(workID, b.AddNode(graph.KindCluster, "", work.Namespace), graph.EdgeDeploysTo)
+	}
+
+	// Addon -> Cluster, the addon namespace is the cluster name
+	for _, addon := range resources.addons {
+		addonID := b.AddNode(graph.KindAddon, addon.Namespace, addon.Name)
+		b.AddEdge(addonID, b.AddNode(graph.KindCluster, "", addon.Namespace), graph.EdgeInstalledOn)
+	}
+
+	return b.Graph()
+}
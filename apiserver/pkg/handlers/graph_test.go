@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func newGraphTestClient() *client.OCMClient {
+	return &client.OCMClient{
+		ClusterClient: clusterfake.NewSimpleClientset(
+			&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+				Name:   "cluster1",
+				Labels: map[string]string{clusterv1beta2.ClusterSetLabel: "default"},
+			}},
+			&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster2"}},
+			&clusterv1beta2.ManagedClusterSet{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			&clusterv1beta2.ManagedClusterSetBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "app"},
+				Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: "default"},
+			},
+			&clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "app"}},
+			newTestPlacementDecision("app", "p-decision-1", "p", "0", "", "cluster1"),
+		),
+		WorkClient: workfake.NewSimpleClientset(
+			&workv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"}},
+		),
+		AddonClient: addonfake.NewSimpleClientset(
+			&addonv1alpha1.ManagedClusterAddOn{ObjectMeta: metav1.ObjectMeta{Name: "addon1", Namespace: "cluster2"}},
+		),
+	}
+}
+
+func TestGetGraph(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		query          string
+		expectedStatus int
+		expectedNodes  int
+		expectedEdges  int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid depth",
+			client:         newGraphTestClient(),
+			query:          "depth=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown focus",
+			client:         newGraphTestClient(),
+			query:          "focus=ManagedCluster/missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "full graph",
+			client:         newGraphTestClient(),
+			expectedStatus: http.StatusOK,
+			expectedNodes:  9,
+			expectedEdges:  8,
+		},
+		{
+			name:           "focused graph",
+			client:         newGraphTestClient(),
+			query:          "focus=ManagedCluster/cluster2&depth=1",
+			expectedStatus: http.StatusOK,
+			expectedNodes:  2,
+			expectedEdges:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/graph?"+tt.query, nil)
+
+			GetGraph(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var result models.Graph
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			assert.Len(t, result.Nodes, tt.expectedNodes)
+			assert.Len(t, result.Edges, tt.expectedEdges)
+			assert.Empty(t, result.Errors)
+		})
+	}
+}
+
+func TestGetGraphDOT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/graph?format=dot&focus=ManifestWork/cluster1/work1&depth=1", nil)
+
+	GetGraph(c, newGraphTestClient(), context.Background())
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/vnd.graphviz")
+	assert.Contains(t, w.Body.String(), `"ManifestWork/cluster1/work1" -> "ManagedCluster/cluster1" [label="deploysTo"];`)
+}
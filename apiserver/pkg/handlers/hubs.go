@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// hubTimeout bounds the calls made to each hub by the fan-out endpoints
+const hubTimeout = 5 * time.Second
+
+// GetHubs handles listing the given hubs with their connectivity status, checked
+// concurrently by reading the version of every hub API server. defaultHub names
+// the hub served by the routes without a hub in the path.
+func GetHubs(c *gin.Context, hubs []client.Hub, defaultHub string, ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, hubTimeout)
+	defer cancel()
+
+	result := make([]models.Hub, len(hubs))
+	var wg sync.WaitGroup
+	for i, hub := range hubs {
+		result[i] = models.Hub{Name: hub.Name, Source: hub.Source, Default: hub.Name == defaultHub}
+		wg.Add(1)
+		go func(status *models.Hub, ocmClient *client.OCMClient) {
+			defer wg.Done()
+			serverVersion, err := hubVersion(ctx, ocmClient)
+			if err != nil {
+				status.Status = models.HubUnreachable
+				status.Message = err.Error()
+				return
+			}
+			status.Status = models.HubConnected
+			status.Version = serverVersion
+		}(&result[i], hub.Client)
+	}
+	wg.Wait()
+
+	c.JSON(http.StatusOK, result)
+}
+
+// hubVersion reads the version of the hub API server, giving up when the
+// context expires
+func hubVersion(ctx context.Context, ocmClient *client.OCMClient) (string, error) {
+	if ocmClient == nil || ocmClient.KubernetesClient == nil {
+		return "", fmt.Errorf("OCM client not initialized")
+	}
+
+	// The discovery client takes no context, so the version is read through its
+	// REST client. Fake clientsets have none and answer from memory.
+	discovery := ocmClient.KubernetesClient.Discovery()
+	restClient := discovery.RESTClient()
+	if restClient == nil {
+		info, err := discovery.ServerVersion()
+		if err != nil {
+			return "", err
+		}
+		return info.GitVersion, nil
+	}
+
+	body, err := restClient.Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return "", err
+	}
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("unable to parse the server version: %w", err)
+	}
+	return info.GitVersion, nil
+}
+
+// GetFleetClusters handles listing the ManagedClusters of every hub, each tagged
+// with its hub. Hubs that cannot be listed are reported per hub.
+func GetFleetClusters(c *gin.Context, hubs []client.Hub, ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, hubTimeout)
+	defer cancel()
+
+	perHub := make([][]models.Cluster, len(hubs))
+	errs := make([]error, len(hubs))
+	var wg sync.WaitGroup
+	for i, hub := range hubs {
+		wg.Add(1)
+		go func(i int, hub client.Hub) {
+			defer wg.Done()
+			perHub[i], errs[i] = listHubClusters(ctx, hub)
+		}(i, hub)
+	}
+	wg.Wait()
+
+	fleet := models.FleetClusters{Clusters: []models.Cluster{}}
+	for i, hub := range hubs {
+		if errs[i] != nil {
+			if fleet.Errors == nil {
+				fleet.Errors = make(map[string]string)
+			}
+			fleet.Errors[hub.Name] = errs[i].Error()
+			continue
+		}
+		fleet.Clusters = append(fleet.Clusters, perHub[i]...)
+	}
+
+	c.JSON(http.StatusOK, fleet)
+}
+
+// listHubClusters lists the ManagedClusters of a hub tagged with the hub name
+func listHubClusters(ctx context.Context, hub client.Hub) ([]models.Cluster, error) {
+	if hub.Client == nil || hub.Client.ClusterClient == nil {
+		return nil, fmt.Errorf("OCM client not initialized")
+	}
+	if available, reason := hub.Client.Capabilities.FeatureAvailable(client.FeatureClusters); !available {
+		return nil, fmt.Errorf("feature %s is not available: %s", client.FeatureClusters, reason)
+	}
+
+	list, err := hub.Client.ClusterClient.ClusterV1().ManagedClusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]models.Cluster, 0, len(list.Items))
+	for _, item := range list.Items {
+		// Cluster names are only unique within a hub
+		cluster := convertManagedClusterToCluster(item)
+		cluster.ID = hub.Name + "/" + cluster.Name
+		cluster.Hub = hub.Name
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
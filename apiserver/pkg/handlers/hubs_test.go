@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// newTestHub creates a hub backed by fake clientsets serving the given clusters
+func newTestHub(name string, versionErr error, clusterNames ...string) client.Hub {
+	kubeClient := kubefake.NewSimpleClientset()
+	discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.FakedServerVersion = &version.Info{GitVersion: "v1.30.0"}
+	if versionErr != nil {
+		kubeClient.PrependReactor("get", "version", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, versionErr
+		})
+	}
+
+	var clusters []runtime.Object
+	for _, clusterName := range clusterNames {
+		clusters = append(clusters, &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}})
+	}
+	clusterClient := clusterfake.NewSimpleClientset(clusters...)
+	if versionErr != nil {
+		clusterClient.PrependReactor("list", "managedclusters", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, versionErr
+		})
+	}
+
+	return client.Hub{
+		Name:   name,
+		Source: client.HubSourceContext + ":" + name,
+		Client: &client.OCMClient{KubernetesClient: kubeClient, ClusterClient: clusterClient},
+	}
+}
+
+func TestGetHubs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	hubs := []client.Hub{
+		newTestHub("east", nil),
+		newTestHub("west", errors.New("connection refused")),
+		{Name: "broken", Source: client.HubSourceInCluster},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/hubs", nil)
+
+	GetHubs(c, hubs, "east", context.Background())
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var result []models.Hub
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Len(t, result, 3)
+
+	assert.Equal(t, models.Hub{Name: "east", Source: "context:east", Default: true, Status: models.HubConnected, Version: "v1.30.0"}, result[0])
+	assert.Equal(t, "west", result[1].Name)
+	assert.False(t, result[1].Default)
+	assert.Equal(t, models.HubUnreachable, result[1].Status)
+	assert.Equal(t, "connection refused", result[1].Message)
+	assert.Equal(t, models.HubUnreachable, result[2].Status)
+	assert.Equal(t, "OCM client not initialized", result[2].Message)
+}
+
+func TestHubVersionHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = hubVersion(ctx, &client.OCMClient{KubernetesClient: kubeClient})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGetFleetClusters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		hubs             []client.Hub
+		expectedClusters []string
+		expectedErrors   []string
+	}{
+		{
+			name:             "clusters of every hub",
+			hubs:             []client.Hub{newTestHub("east", nil, "cluster1", "cluster2"), newTestHub("west", nil, "cluster1")},
+			expectedClusters: []string{"east/cluster1", "east/cluster2", "west/cluster1"},
+		},
+		{
+			name:             "unreachable hub",
+			hubs:             []client.Hub{newTestHub("east", nil, "cluster1"), newTestHub("west", errors.New("connection refused"), "cluster1")},
+			expectedClusters: []string{"east/cluster1"},
+			expectedErrors:   []string{"west"},
+		},
+		{
+			name:             "no hubs",
+			expectedClusters: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/fleet/clusters", nil)
+
+			GetFleetClusters(c, tt.hubs, context.Background())
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var fleet models.FleetClusters
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fleet))
+
+			clusters := []string{}
+			for _, cluster := range fleet.Clusters {
+				assert.Equal(t, cluster.Hub+"/"+cluster.Name, cluster.ID)
+				clusters = append(clusters, cluster.ID)
+			}
+			assert.Equal(t, tt.expectedClusters, clusters)
+
+			var errs []string
+			for hub := range fleet.Errors {
+				errs = append(errs, hub)
+			}
+			assert.ElementsMatch(t, tt.expectedErrors, errs)
+		})
+	}
+}
@@ -3,16 +3,95 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/drift"
 	"open-cluster-management-io/lab/apiserver/pkg/models"
+	"open-cluster-management-io/lab/apiserver/pkg/workdiff"
+
+	workv1 "open-cluster-management.io/api/work/v1"
 )
 
+// manifestWorkStatuses are the values accepted by the status filter of GetAllManifestWorks
+var manifestWorkStatuses = map[string]bool{
+	"Applied":     true,
+	"Available":   true,
+	"Degraded":    true,
+	"Progressing": true,
+	"Failed":      true,
+	"Unknown":     true,
+}
+
+// GetAllManifestWorks retrieves ManifestWorks across all cluster namespaces with a status histogram.
+// Supported query parameters:
+//   - labelSelector: label selector the ManifestWorks must match
+//   - name: only ManifestWorks with this name
+//   - kind: only ManifestWorks embedding a manifest of this kind
+//   - status: only ManifestWorks with this overall status
+func GetAllManifestWorks(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Kubernetes client not initialized"})
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && !manifestWorkStatuses[status] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid status %q, expected Applied, Available, Degraded, Progressing, Failed or Unknown", status)})
+		return
+	}
+
+	// Get the manifest works across all namespaces
+	list, err := ocmClient.WorkClient.WorkV1().ManifestWorks("").List(ctx, metav1.ListOptions{LabelSelector: c.Query("labelSelector")})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	name := c.Query("name")
+	kind := c.Query("kind")
+	fleet := models.ManifestWorkFleet{
+		Items:        []models.ManifestWorkSummary{},
+		StatusCounts: make(map[string]int),
+	}
+	for _, item := range list.Items {
+		if name != "" && item.Name != name {
+			continue
+		}
+		if kind != "" && !manifestWorkHasKind(item, kind) {
+			continue
+		}
+
+		summary := convertManifestWorkToSummary(item)
+		fleet.StatusCounts[summary.Status]++
+		if status != "" && summary.Status != status {
+			continue
+		}
+		fleet.Items = append(fleet.Items, summary)
+	}
+	fleet.Total = len(fleet.Items)
+
+	sort.Slice(fleet.Items, func(i, j int) bool {
+		if fleet.Items[i].Name != fleet.Items[j].Name {
+			return fleet.Items[i].Name < fleet.Items[j].Name
+		}
+		return fleet.Items[i].Namespace < fleet.Items[j].Namespace
+	})
+
+	c.JSON(http.StatusOK, fleet)
+}
+
 // GetManifestWorks retrieves all ManifestWorks for a specific namespace
 func GetManifestWorks(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
 	namespace := c.Param("namespace")
@@ -33,78 +112,101 @@ func GetManifestWorks(c *gin.Context, ocmClient *client.OCMClient, ctx context.C
 	// Convert to our simplified ManifestWork models
 	manifestWorks := make([]models.ManifestWork, 0, len(list.Items))
 	for _, item := range list.Items {
-		manifestWork := models.ManifestWork{
-			ID:                string(item.GetUID()),
-			Name:              item.GetName(),
-			Namespace:         item.GetNamespace(),
-			Labels:            item.GetLabels(),
-			CreationTimestamp: item.GetCreationTimestamp().Format(time.RFC3339),
-		}
-
-		// Process manifests
-		if len(item.Spec.Workload.Manifests) > 0 {
-			manifestWork.Manifests = make([]models.Manifest, len(item.Spec.Workload.Manifests))
-			for i, manifest := range item.Spec.Workload.Manifests {
-				// Convert raw bytes to map[string]interface{}
-				var rawObj map[string]interface{}
-				if err := json.Unmarshal(manifest.Raw, &rawObj); err == nil {
-					manifestWork.Manifests[i] = models.Manifest{
-						RawExtension: rawObj,
-					}
-				}
-			}
-		}
+		manifestWorks = append(manifestWorks, convertManifestWorkToModel(item))
+	}
 
-		// Extract conditions
-		for _, condition := range item.Status.Conditions {
-			manifestWork.Conditions = append(manifestWork.Conditions, models.Condition{
-				Type:               string(condition.Type),
-				Status:             string(condition.Status),
-				LastTransitionTime: condition.LastTransitionTime.Format(time.RFC3339),
-				Reason:             condition.Reason,
-				Message:            condition.Message,
-			})
-		}
+	c.JSON(http.StatusOK, manifestWorks)
+}
 
-		// Process resource status
-		if len(item.Status.ResourceStatus.Manifests) > 0 {
-			manifestWork.ResourceStatus.Manifests = make([]models.ManifestCondition, len(item.Status.ResourceStatus.Manifests))
-			for i, manifestStatus := range item.Status.ResourceStatus.Manifests {
-				manifestCondition := models.ManifestCondition{
-					ResourceMeta: models.ManifestResourceMeta{
-						Ordinal:   manifestStatus.ResourceMeta.Ordinal,
-						Group:     manifestStatus.ResourceMeta.Group,
-						Version:   manifestStatus.ResourceMeta.Version,
-						Kind:      manifestStatus.ResourceMeta.Kind,
-						Resource:  manifestStatus.ResourceMeta.Resource,
-						Name:      manifestStatus.ResourceMeta.Name,
-						Namespace: manifestStatus.ResourceMeta.Namespace,
-					},
-				}
+// GetManifestWork retrieves a specific ManifestWork by name in a namespace
+func GetManifestWork(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
 
-				// Process conditions for this manifest
-				for _, condition := range manifestStatus.Conditions {
-					manifestCondition.Conditions = append(manifestCondition.Conditions, models.Condition{
-						Type:               string(condition.Type),
-						Status:             string(condition.Status),
-						LastTransitionTime: condition.LastTransitionTime.Format(time.RFC3339),
-						Reason:             condition.Reason,
-						Message:            condition.Message,
-					})
-				}
+	// Ensure we have a client before proceeding
+	if ocmClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Kubernetes client not initialized"})
+		return
+	}
 
-				manifestWork.ResourceStatus.Manifests[i] = manifestCondition
-			}
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.ManifestWorkResource, namespace, name)
+		return
+	}
+
+	// Get the manifest work by name
+	item, err := ocmClient.WorkClient.WorkV1().ManifestWorks(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Convert to our simplified ManifestWork model
+	manifestWork := convertManifestWorkToModel(*item)
+
+	c.JSON(http.StatusOK, manifestWork)
+}
+
+// GetManifestWorkResource retrieves a single manifest of a ManifestWork by ordinal,
+// together with its applied conditions, status feedback and manifest config
+func GetManifestWorkResource(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Kubernetes client not initialized"})
+		return
+	}
+
+	ordinal, err := strconv.ParseInt(c.Param("ordinal"), 10, 32)
+	if err != nil || ordinal < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ordinal, expected a non-negative integer"})
+		return
+	}
+
+	// Get the manifest work by name
+	item, err := ocmClient.WorkClient.WorkV1().ManifestWorks(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	manifestWork := convertManifestWorkToModel(*item)
+	if int(ordinal) >= len(manifestWork.Manifests) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("manifest %d not found in ManifestWork %s/%s", ordinal, namespace, name)})
+		return
+	}
+
+	resource := models.ManifestWorkResource{
+		Ordinal:  int32(ordinal),
+		Manifest: manifestWork.Manifests[ordinal],
+	}
+
+	// The resource status is keyed by ordinal, it is missing until the work agent reports back
+	for i := range manifestWork.ResourceStatus.Manifests {
+		status := manifestWork.ResourceStatus.Manifests[i]
+		if status.ResourceMeta.Ordinal != int32(ordinal) {
+			continue
 		}
+		resource.ResourceMeta = &status.ResourceMeta
+		resource.Conditions = status.Conditions
+		resource.StatusFeedbacks = status.StatusFeedbacks
+		break
+	}
 
-		manifestWorks = append(manifestWorks, manifestWork)
+	// Match the manifest config by resource identifier
+	if resource.ResourceMeta != nil {
+		resource.ManifestConfig = findManifestConfig(manifestWork.ManifestConfigs, *resource.ResourceMeta)
 	}
 
-	c.JSON(http.StatusOK, manifestWorks)
+	c.JSON(http.StatusOK, resource)
 }
 
-// GetManifestWork retrieves a specific ManifestWork by name in a namespace
-func GetManifestWork(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+// GetManifestWorkDrift compares the manifests of a ManifestWork with the live values reported
+// through status feedback and lists the field-level differences per resource
+func GetManifestWorkDrift(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("name")
 
@@ -117,11 +219,90 @@ func GetManifestWork(c *gin.Context, ocmClient *client.OCMClient, ctx context.Co
 	// Get the manifest work by name
 	item, err := ocmClient.WorkClient.WorkV1().ManifestWorks(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Convert to our simplified ManifestWork model
+	manifestWork := convertManifestWorkToModel(*item)
+	report := models.DriftReport{
+		Name:      name,
+		Namespace: namespace,
+		Resources: []models.ResourceDrift{},
+	}
+
+	for _, status := range manifestWork.ResourceStatus.Manifests {
+		ordinal := int(status.ResourceMeta.Ordinal)
+		if ordinal < 0 || ordinal >= len(manifestWork.Manifests) {
+			continue
+		}
+
+		// Only JSONPaths rules name the manifest fields a feedback value was read from
+		var paths []models.JsonPath
+		if config := findManifestConfig(manifestWork.ManifestConfigs, status.ResourceMeta); config != nil {
+			for _, rule := range config.FeedbackRules {
+				if rule.Type == string(workv1.JSONPathsType) {
+					paths = append(paths, rule.JsonPaths...)
+				}
+			}
+		}
+
+		resourceDrift := drift.Detect(manifestWork.Manifests[ordinal].RawExtension, paths, status.StatusFeedbacks)
+		resourceDrift.ResourceMeta = status.ResourceMeta
+		report.Drifted = report.Drifted || resourceDrift.Drifted
+		report.Resources = append(report.Resources, resourceDrift)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// DiffManifestWork previews updating a ManifestWork to the proposed spec in the request body,
+// returning a structured and unified diff per manifest and the resources the update would
+// delete or orphan
+func DiffManifestWork(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	var specModel models.ManifestWorkSpec
+	if err := c.ShouldBindJSON(&specModel); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+
+	// Round-trip the proposed spec so it is compared in the form it would be stored
+	spec, err := convertModelToManifestWorkSpec(specModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := ocmClient.WorkClient.WorkV1().ManifestWorks(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workdiff.Compare(convertManifestWorkToModel(*item), convertManifestWorkSpecToModel(spec)))
+}
+
+// findManifestConfig returns the manifest config whose resource identifier matches the resource
+func findManifestConfig(configs []models.ManifestConfigOption, meta models.ManifestResourceMeta) *models.ManifestConfigOption {
+	for i, config := range configs {
+		id := config.ResourceIdentifier
+		if id.Group == meta.Group && id.Resource == meta.Resource && id.Name == meta.Name && id.Namespace == meta.Namespace {
+			return &configs[i]
+		}
+	}
+	return nil
+}
+
+// Helper function to convert a ManifestWork to our simplified model
+func convertManifestWorkToModel(item workv1.ManifestWork) models.ManifestWork {
 	manifestWork := models.ManifestWork{
 		ID:                string(item.GetUID()),
 		Name:              item.GetName(),
@@ -130,19 +311,11 @@ func GetManifestWork(c *gin.Context, ocmClient *client.OCMClient, ctx context.Co
 		CreationTimestamp: item.GetCreationTimestamp().Format(time.RFC3339),
 	}
 
-	// Process manifests
-	if len(item.Spec.Workload.Manifests) > 0 {
-		manifestWork.Manifests = make([]models.Manifest, len(item.Spec.Workload.Manifests))
-		for i, manifest := range item.Spec.Workload.Manifests {
-			// Convert raw bytes to map[string]interface{}
-			var rawObj map[string]interface{}
-			if err := json.Unmarshal(manifest.Raw, &rawObj); err == nil {
-				manifestWork.Manifests[i] = models.Manifest{
-					RawExtension: rawObj,
-				}
-			}
-		}
-	}
+	// Process manifests, delete option and manifest configs
+	spec := convertManifestWorkSpecToModel(item.Spec)
+	manifestWork.Manifests = spec.Workload
+	manifestWork.DeleteOption = spec.DeleteOption
+	manifestWork.ManifestConfigs = spec.ManifestConfigs
 
 	// Extract conditions
 	for _, condition := range item.Status.Conditions {
@@ -169,6 +342,7 @@ func GetManifestWork(c *gin.Context, ocmClient *client.OCMClient, ctx context.Co
 					Name:      manifestStatus.ResourceMeta.Name,
 					Namespace: manifestStatus.ResourceMeta.Namespace,
 				},
+				StatusFeedbacks: convertFeedbackValuesToModel(manifestStatus.StatusFeedbacks.Values),
 			}
 
 			// Process conditions for this manifest
@@ -186,5 +360,260 @@ func GetManifestWork(c *gin.Context, ocmClient *client.OCMClient, ctx context.Co
 		}
 	}
 
-	c.JSON(http.StatusOK, manifestWork)
+	return manifestWork
+}
+
+// Helper function to convert a ManifestWork spec, also used for ManifestWorkReplicaSet templates
+func convertManifestWorkSpecToModel(spec workv1.ManifestWorkSpec) models.ManifestWorkSpec {
+	result := models.ManifestWorkSpec{
+		DeleteOption: convertDeleteOptionToModel(spec.DeleteOption),
+	}
+
+	if len(spec.Workload.Manifests) > 0 {
+		result.Workload = make([]models.Manifest, len(spec.Workload.Manifests))
+		for i, manifest := range spec.Workload.Manifests {
+			// Convert raw bytes to map[string]interface{}
+			var rawObj map[string]interface{}
+			if err := json.Unmarshal(manifest.Raw, &rawObj); err == nil {
+				result.Workload[i] = models.Manifest{
+					RawExtension: rawObj,
+				}
+			}
+		}
+	}
+
+	for _, config := range spec.ManifestConfigs {
+		result.ManifestConfigs = append(result.ManifestConfigs, convertManifestConfigToModel(config))
+	}
+
+	return result
+}
+
+// Helper function to convert the delete option of a ManifestWork
+func convertDeleteOptionToModel(option *workv1.DeleteOption) *models.DeleteOption {
+	if option == nil {
+		return nil
+	}
+
+	deleteOption := &models.DeleteOption{
+		PropagationPolicy: string(option.PropagationPolicy),
+	}
+	if option.SelectivelyOrphan != nil {
+		deleteOption.SelectivelyOrphan = &models.SelectivelyOrphan{}
+		for _, rule := range option.SelectivelyOrphan.OrphaningRules {
+			deleteOption.SelectivelyOrphan.OrphaningRules = append(deleteOption.SelectivelyOrphan.OrphaningRules, models.OrphaningRule{
+				Group:     rule.Group,
+				Resource:  rule.Resource,
+				Name:      rule.Name,
+				Namespace: rule.Namespace,
+			})
+		}
+	}
+
+	return deleteOption
+}
+
+// Helper function to convert a manifest config option of a ManifestWork
+func convertManifestConfigToModel(config workv1.ManifestConfigOption) models.ManifestConfigOption {
+	option := models.ManifestConfigOption{
+		ResourceIdentifier: models.ResourceIdentifier{
+			Group:     config.ResourceIdentifier.Group,
+			Resource:  config.ResourceIdentifier.Resource,
+			Name:      config.ResourceIdentifier.Name,
+			Namespace: config.ResourceIdentifier.Namespace,
+		},
+	}
+
+	for _, rule := range config.FeedbackRules {
+		feedbackRule := models.FeedbackRule{Type: string(rule.Type)}
+		for _, path := range rule.JsonPaths {
+			feedbackRule.JsonPaths = append(feedbackRule.JsonPaths, models.JsonPath{
+				Name:    path.Name,
+				Version: path.Version,
+				Path:    path.Path,
+			})
+		}
+		option.FeedbackRules = append(option.FeedbackRules, feedbackRule)
+	}
+
+	if strategy := config.UpdateStrategy; strategy != nil {
+		option.UpdateStrategy = &models.UpdateStrategy{Type: string(strategy.Type)}
+		if ssa := strategy.ServerSideApply; ssa != nil {
+			option.UpdateStrategy.ServerSideApply = &models.ServerSideApplyConfig{
+				Force:        ssa.Force,
+				FieldManager: ssa.FieldManager,
+			}
+			for _, field := range ssa.IgnoreFields {
+				option.UpdateStrategy.ServerSideApply.IgnoreFields = append(option.UpdateStrategy.ServerSideApply.IgnoreFields, models.IgnoreField{
+					Condition: string(field.Condition),
+					JSONPaths: field.JSONPaths,
+				})
+			}
+		}
+	}
+
+	return option
+}
+
+// Helper function to convert the status feedback values of a manifest
+func convertFeedbackValuesToModel(values []workv1.FeedbackValue) []models.FeedbackValue {
+	var feedbacks []models.FeedbackValue
+	for _, value := range values {
+		feedbacks = append(feedbacks, models.FeedbackValue{
+			Name: value.Name,
+			Value: models.FieldValue{
+				Type:    string(value.Value.Type),
+				Integer: value.Value.Integer,
+				String:  value.Value.String,
+				Boolean: value.Value.Boolean,
+				JsonRaw: value.Value.JsonRaw,
+			},
+		})
+	}
+	return feedbacks
+}
+
+// Helper function to convert a ManifestWork spec from the model, validating every manifest
+func convertModelToManifestWorkSpec(spec models.ManifestWorkSpec) (workv1.ManifestWorkSpec, error) {
+	result := workv1.ManifestWorkSpec{}
+
+	for i, manifest := range spec.Workload {
+		if manifest.RawExtension["apiVersion"] == nil || manifest.RawExtension["kind"] == nil {
+			return result, fmt.Errorf("manifest %d must set apiVersion and kind", i)
+		}
+		raw, err := json.Marshal(manifest.RawExtension)
+		if err != nil {
+			return result, fmt.Errorf("manifest %d: %v", i, err)
+		}
+		result.Workload.Manifests = append(result.Workload.Manifests, workv1.Manifest{
+			RawExtension: runtime.RawExtension{Raw: raw},
+		})
+	}
+
+	if option := spec.DeleteOption; option != nil {
+		result.DeleteOption = &workv1.DeleteOption{
+			PropagationPolicy: workv1.DeletePropagationPolicyType(option.PropagationPolicy),
+		}
+		if option.SelectivelyOrphan != nil {
+			result.DeleteOption.SelectivelyOrphan = &workv1.SelectivelyOrphan{}
+			for _, rule := range option.SelectivelyOrphan.OrphaningRules {
+				result.DeleteOption.SelectivelyOrphan.OrphaningRules = append(result.DeleteOption.SelectivelyOrphan.OrphaningRules, workv1.OrphaningRule{
+					Group:     rule.Group,
+					Resource:  rule.Resource,
+					Name:      rule.Name,
+					Namespace: rule.Namespace,
+				})
+			}
+		}
+	}
+
+	for _, config := range spec.ManifestConfigs {
+		option := workv1.ManifestConfigOption{
+			ResourceIdentifier: workv1.ResourceIdentifier{
+				Group:     config.ResourceIdentifier.Group,
+				Resource:  config.ResourceIdentifier.Resource,
+				Name:      config.ResourceIdentifier.Name,
+				Namespace: config.ResourceIdentifier.Namespace,
+			},
+		}
+
+		for _, rule := range config.FeedbackRules {
+			feedbackRule := workv1.FeedbackRule{Type: workv1.FeedBackType(rule.Type)}
+			for _, path := range rule.JsonPaths {
+				feedbackRule.JsonPaths = append(feedbackRule.JsonPaths, workv1.JsonPath{
+					Name:    path.Name,
+					Version: path.Version,
+					Path:    path.Path,
+				})
+			}
+			option.FeedbackRules = append(option.FeedbackRules, feedbackRule)
+		}
+
+		if strategy := config.UpdateStrategy; strategy != nil {
+			option.UpdateStrategy = &workv1.UpdateStrategy{Type: workv1.UpdateStrategyType(strategy.Type)}
+			if ssa := strategy.ServerSideApply; ssa != nil {
+				option.UpdateStrategy.ServerSideApply = &workv1.ServerSideApplyConfig{
+					Force:        ssa.Force,
+					FieldManager: ssa.FieldManager,
+				}
+				for _, field := range ssa.IgnoreFields {
+					option.UpdateStrategy.ServerSideApply.IgnoreFields = append(option.UpdateStrategy.ServerSideApply.IgnoreFields, workv1.IgnoreField{
+						Condition: workv1.IgnoreFieldsCondition(field.Condition),
+						JSONPaths: field.JSONPaths,
+					})
+				}
+			}
+		}
+
+		result.ManifestConfigs = append(result.ManifestConfigs, option)
+	}
+
+	return result, nil
+}
+
+// Helper function to summarize the overall and per-resource status of a ManifestWork
+func convertManifestWorkToSummary(item workv1.ManifestWork) models.ManifestWorkSummary {
+	manifestWork := convertManifestWorkToModel(item)
+
+	summary := models.ManifestWorkSummary{
+		Name:       manifestWork.Name,
+		Namespace:  manifestWork.Namespace,
+		Status:     manifestWorkStatus(item.Status.Conditions),
+		Conditions: manifestWork.Conditions,
+	}
+
+	for _, manifest := range item.Status.ResourceStatus.Manifests {
+		summary.Resources = append(summary.Resources, models.ManifestWorkResourceStatus{
+			ResourceMeta: models.ManifestResourceMeta{
+				Ordinal:   manifest.ResourceMeta.Ordinal,
+				Group:     manifest.ResourceMeta.Group,
+				Version:   manifest.ResourceMeta.Version,
+				Kind:      manifest.ResourceMeta.Kind,
+				Resource:  manifest.ResourceMeta.Resource,
+				Name:      manifest.ResourceMeta.Name,
+				Namespace: manifest.ResourceMeta.Namespace,
+			},
+			Applied:   conditionStatus(manifest.Conditions, workv1.ManifestApplied),
+			Available: conditionStatus(manifest.Conditions, workv1.ManifestAvailable),
+		})
+	}
+
+	return summary
+}
+
+// manifestWorkHasKind checks whether the workload of a ManifestWork embeds a manifest of the given kind
+func manifestWorkHasKind(item workv1.ManifestWork, kind string) bool {
+	for _, manifest := range item.Spec.Workload.Manifests {
+		var typeMeta metav1.TypeMeta
+		if err := json.Unmarshal(manifest.Raw, &typeMeta); err == nil && strings.EqualFold(typeMeta.Kind, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// manifestWorkStatus derives a single status from the ManifestWork conditions
+func manifestWorkStatus(conditions []metav1.Condition) string {
+	switch {
+	case meta.IsStatusConditionTrue(conditions, workv1.WorkDegraded):
+		return "Degraded"
+	case meta.IsStatusConditionTrue(conditions, workv1.WorkProgressing):
+		return "Progressing"
+	case meta.IsStatusConditionTrue(conditions, workv1.WorkAvailable):
+		return "Available"
+	case meta.IsStatusConditionTrue(conditions, workv1.WorkApplied):
+		return "Applied"
+	case meta.IsStatusConditionFalse(conditions, workv1.WorkApplied):
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// conditionStatus returns the status of a condition type, or Unknown if it is not set
+func conditionStatus(conditions []metav1.Condition, conditionType string) string {
+	if condition := meta.FindStatusCondition(conditions, conditionType); condition != nil {
+		return string(condition.Status)
+	}
+	return string(metav1.ConditionUnknown)
 }
@@ -2,14 +2,22 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	workv1 "open-cluster-management.io/api/work/v1"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
 )
 
 func TestGetManifestWorks(t *testing.T) {
@@ -80,3 +88,411 @@ func TestGetManifestWork(t *testing.T) {
 		})
 	}
 }
+
+func newTestManifestWork() *workv1.ManifestWork {
+	replicas := int64(3)
+	ready := true
+	return &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "cluster1"},
+		Spec: workv1.ManifestWorkSpec{
+			Workload: workv1.ManifestsTemplate{
+				Manifests: []workv1.Manifest{
+					{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"app","namespace":"default"}}`)}},
+					{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app-config","namespace":"default"}}`)}},
+				},
+			},
+			DeleteOption: &workv1.DeleteOption{
+				PropagationPolicy: workv1.DeletePropagationPolicyTypeSelectivelyOrphan,
+				SelectivelyOrphan: &workv1.SelectivelyOrphan{
+					OrphaningRules: []workv1.OrphaningRule{{Resource: "configmaps", Name: "app-config", Namespace: "default"}},
+				},
+			},
+			ManifestConfigs: []workv1.ManifestConfigOption{
+				{
+					ResourceIdentifier: workv1.ResourceIdentifier{Group: "apps", Resource: "deployments", Name: "app", Namespace: "default"},
+					FeedbackRules: []workv1.FeedbackRule{
+						{Type: workv1.JSONPathsType, JsonPaths: []workv1.JsonPath{{Name: "replicas", Path: ".spec.replicas"}}},
+					},
+					UpdateStrategy: &workv1.UpdateStrategy{
+						Type:            workv1.UpdateStrategyTypeServerSideApply,
+						ServerSideApply: &workv1.ServerSideApplyConfig{FieldManager: "dashboard"},
+					},
+				},
+			},
+		},
+		Status: workv1.ManifestWorkStatus{
+			ResourceStatus: workv1.ManifestResourceStatus{
+				Manifests: []workv1.ManifestCondition{
+					{
+						ResourceMeta: workv1.ManifestResourceMeta{
+							Ordinal: 0, Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Name: "app", Namespace: "default",
+						},
+						StatusFeedbacks: workv1.StatusFeedbackResult{
+							Values: []workv1.FeedbackValue{
+								{Name: "replicas", Value: workv1.FieldValue{Type: workv1.Integer, Integer: &replicas}},
+								{Name: "ready", Value: workv1.FieldValue{Type: workv1.Boolean, Boolean: &ready}},
+							},
+						},
+						Conditions: []metav1.Condition{{Type: workv1.ManifestApplied, Status: metav1.ConditionTrue}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertManifestWorkToModel(t *testing.T) {
+	manifestWork := convertManifestWorkToModel(*newTestManifestWork())
+
+	require.NotNil(t, manifestWork.DeleteOption)
+	assert.Equal(t, "SelectivelyOrphan", manifestWork.DeleteOption.PropagationPolicy)
+	require.NotNil(t, manifestWork.DeleteOption.SelectivelyOrphan)
+	assert.Equal(t, "app-config", manifestWork.DeleteOption.SelectivelyOrphan.OrphaningRules[0].Name)
+
+	require.Len(t, manifestWork.ManifestConfigs, 1)
+	assert.Equal(t, "deployments", manifestWork.ManifestConfigs[0].ResourceIdentifier.Resource)
+	assert.Equal(t, ".spec.replicas", manifestWork.ManifestConfigs[0].FeedbackRules[0].JsonPaths[0].Path)
+	assert.Equal(t, "dashboard", manifestWork.ManifestConfigs[0].UpdateStrategy.ServerSideApply.FieldManager)
+
+	feedbacks := manifestWork.ResourceStatus.Manifests[0].StatusFeedbacks
+	require.Len(t, feedbacks, 2)
+	assert.Equal(t, "Integer", feedbacks[0].Value.Type)
+	assert.Equal(t, int64(3), *feedbacks[0].Value.Integer)
+	assert.True(t, *feedbacks[1].Value.Boolean)
+}
+
+func TestGetManifestWorkResource(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		manifestName   string
+		ordinal        string
+		expectedStatus int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			manifestName:   "app",
+			ordinal:        "0",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid ordinal",
+			client:         &client.OCMClient{WorkClient: workfake.NewSimpleClientset(newTestManifestWork())},
+			manifestName:   "app",
+			ordinal:        "first",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "manifestwork not found",
+			client:         &client.OCMClient{WorkClient: workfake.NewSimpleClientset()},
+			manifestName:   "app",
+			ordinal:        "0",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ordinal out of range",
+			client:         &client.OCMClient{WorkClient: workfake.NewSimpleClientset(newTestManifestWork())},
+			manifestName:   "app",
+			ordinal:        "2",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "resource with status",
+			client:         &client.OCMClient{WorkClient: workfake.NewSimpleClientset(newTestManifestWork())},
+			manifestName:   "app",
+			ordinal:        "0",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{
+				{Key: "namespace", Value: "cluster1"},
+				{Key: "name", Value: tt.manifestName},
+				{Key: "ordinal", Value: tt.ordinal},
+			}
+
+			GetManifestWorkResource(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resource models.ManifestWorkResource
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resource))
+			assert.Equal(t, "Deployment", resource.Manifest.RawExtension["kind"])
+			require.NotNil(t, resource.ResourceMeta)
+			assert.Equal(t, "deployments", resource.ResourceMeta.Resource)
+			assert.Len(t, resource.Conditions, 1)
+			assert.Len(t, resource.StatusFeedbacks, 2)
+			require.NotNil(t, resource.ManifestConfig)
+			assert.Equal(t, "ServerSideApply", resource.ManifestConfig.UpdateStrategy.Type)
+		})
+	}
+}
+
+func TestGetAllManifestWorks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newWork := func(namespace, name, kind string, labels map[string]string, conditions ...metav1.Condition) *workv1.ManifestWork {
+		return &workv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec: workv1.ManifestWorkSpec{
+				Workload: workv1.ManifestsTemplate{
+					Manifests: []workv1.Manifest{
+						{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"` + kind + `","metadata":{"name":"x"}}`)}},
+					},
+				},
+			},
+			Status: workv1.ManifestWorkStatus{Conditions: conditions},
+		}
+	}
+	applied := metav1.Condition{Type: workv1.WorkApplied, Status: metav1.ConditionTrue}
+	available := metav1.Condition{Type: workv1.WorkAvailable, Status: metav1.ConditionTrue}
+	failed := metav1.Condition{Type: workv1.WorkApplied, Status: metav1.ConditionFalse}
+
+	newClient := func() *client.OCMClient {
+		return &client.OCMClient{WorkClient: workfake.NewSimpleClientset(
+			newWork("cluster1", "app", "Deployment", map[string]string{"team": "a"}, applied, available),
+			newWork("cluster2", "app", "Deployment", map[string]string{"team": "a"}, failed),
+			newWork("cluster3", "app", "Deployment", map[string]string{"team": "a"}),
+			newWork("cluster1", "config", "ConfigMap", map[string]string{"team": "b"}, applied),
+		)}
+	}
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		query          string
+		expectedStatus int
+		expectedItems  []string
+		expectedCounts map[string]int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid status",
+			client:         newClient(),
+			query:          "status=Broken",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "all manifestworks",
+			client:         newClient(),
+			expectedStatus: http.StatusOK,
+			expectedItems:  []string{"cluster1/app", "cluster2/app", "cluster3/app", "cluster1/config"},
+			expectedCounts: map[string]int{"Available": 1, "Failed": 1, "Unknown": 1, "Applied": 1},
+		},
+		{
+			name:           "failed clusters for a work",
+			client:         newClient(),
+			query:          "name=app&status=Failed",
+			expectedStatus: http.StatusOK,
+			expectedItems:  []string{"cluster2/app"},
+			expectedCounts: map[string]int{"Available": 1, "Failed": 1, "Unknown": 1},
+		},
+		{
+			name:           "label selector",
+			client:         newClient(),
+			query:          "labelSelector=team%3Db",
+			expectedStatus: http.StatusOK,
+			expectedItems:  []string{"cluster1/config"},
+			expectedCounts: map[string]int{"Applied": 1},
+		},
+		{
+			name:           "resource kind",
+			client:         newClient(),
+			query:          "kind=configmap",
+			expectedStatus: http.StatusOK,
+			expectedItems:  []string{"cluster1/config"},
+			expectedCounts: map[string]int{"Applied": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/manifestworks?"+tt.query, nil)
+
+			GetAllManifestWorks(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var fleet models.ManifestWorkFleet
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fleet))
+			items := make([]string, 0, len(fleet.Items))
+			for _, item := range fleet.Items {
+				items = append(items, item.Namespace+"/"+item.Name)
+			}
+			assert.Equal(t, tt.expectedItems, items)
+			assert.Equal(t, len(tt.expectedItems), fleet.Total)
+			assert.Equal(t, tt.expectedCounts, fleet.StatusCounts)
+		})
+	}
+}
+
+func TestGetManifestWorkDrift(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	driftedWork := func() *workv1.ManifestWork {
+		work := newTestManifestWork()
+		work.Spec.Workload.Manifests[0].Raw = []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"app","namespace":"default"},"spec":{"replicas":2}}`)
+		return work
+	}
+
+	tests := []struct {
+		name            string
+		client          *client.OCMClient
+		expectedStatus  int
+		expectedDrifted bool
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "manifestwork not found",
+			client:         &client.OCMClient{WorkClient: workfake.NewSimpleClientset()},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:            "replicas changed on the cluster",
+			client:          &client.OCMClient{WorkClient: workfake.NewSimpleClientset(driftedWork())},
+			expectedStatus:  http.StatusOK,
+			expectedDrifted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{
+				{Key: "namespace", Value: "cluster1"},
+				{Key: "name", Value: "app"},
+			}
+
+			GetManifestWorkDrift(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var report models.DriftReport
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Equal(t, tt.expectedDrifted, report.Drifted)
+			require.Len(t, report.Resources, 1)
+
+			resource := report.Resources[0]
+			assert.Equal(t, "Deployment", resource.ResourceMeta.Kind)
+			assert.Equal(t, []string{"replicas"}, resource.CheckedFields)
+			assert.Equal(t, []string{"ready"}, resource.SkippedFields)
+			require.Len(t, resource.Differences, 1)
+			assert.Equal(t, ".spec.replicas", resource.Differences[0].Path)
+			assert.Equal(t, float64(2), resource.Differences[0].Desired)
+			assert.Equal(t, float64(3), resource.Differences[0].Live)
+		})
+	}
+}
+
+func TestDiffManifestWork(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const deployment = `{"rawExtension":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"app","namespace":"default"},"spec":{"replicas":2}}}`
+	const orphanRule = `"deleteOption":{"propagationPolicy":"SelectivelyOrphan","selectivelyOrphans":{"orphaningRules":[{"resource":"configmaps","name":"app-config","namespace":"default"}]}}`
+
+	tests := []struct {
+		name             string
+		client           *client.OCMClient
+		body             string
+		expectedStatus   int
+		expectedDeleted  int
+		expectedOrphaned int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			body:           `{}`,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "manifestwork not found",
+			client:         &client.OCMClient{WorkClient: workfake.NewSimpleClientset()},
+			body:           `{"workload":[` + deployment + `]}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "manifest without kind",
+			client:         &client.OCMClient{WorkClient: workfake.NewSimpleClientset(newTestManifestWork())},
+			body:           `{"workload":[{"rawExtension":{"apiVersion":"v1"}}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "removed configmap is orphaned",
+			client:           &client.OCMClient{WorkClient: workfake.NewSimpleClientset(newTestManifestWork())},
+			body:             `{"workload":[` + deployment + `],` + orphanRule + `}`,
+			expectedStatus:   http.StatusOK,
+			expectedOrphaned: 1,
+		},
+		{
+			name:            "removed configmap is deleted",
+			client:          &client.OCMClient{WorkClient: workfake.NewSimpleClientset(newTestManifestWork())},
+			body:            `{"workload":[` + deployment + `]}`,
+			expectedStatus:  http.StatusOK,
+			expectedDeleted: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			c.Params = gin.Params{
+				{Key: "namespace", Value: "cluster1"},
+				{Key: "name", Value: "app"},
+			}
+
+			DiffManifestWork(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var diff models.ManifestWorkDiff
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &diff))
+			assert.True(t, diff.Changed)
+			assert.Equal(t, 1, diff.Modified)
+			assert.Equal(t, 1, diff.Removed)
+			assert.Len(t, diff.DeletedResources, tt.expectedDeleted)
+			assert.Len(t, diff.OrphanedResources, tt.expectedOrphaned)
+
+			require.Len(t, diff.Manifests, 2)
+			assert.Equal(t, "Modified", diff.Manifests[0].Change)
+			assert.Equal(t, "deployments", diff.Manifests[0].Resource)
+			require.Len(t, diff.Manifests[0].Differences, 1)
+			assert.Equal(t, "spec", diff.Manifests[0].Differences[0].Path)
+			assert.Equal(t, "add", diff.Manifests[0].Differences[0].Operation)
+			assert.Contains(t, diff.Manifests[0].UnifiedDiff, "+  replicas: 2\n")
+			assert.Equal(t, "Removed", diff.Manifests[1].Change)
+			assert.Equal(t, "app-config", diff.Manifests[1].Name)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	workv1 "open-cluster-management.io/api/work/v1"
+	workv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+)
+
+// Label set by the ManifestWorkReplicaSet controller on the generated ManifestWorks,
+// the value is <namespace>.<name> of the ManifestWorkReplicaSet
+const manifestWorkReplicaSetLabel = "work.open-cluster-management.io/manifestworkreplicaset"
+
+// GetManifestWorkReplicaSets handles retrieving all ManifestWorkReplicaSets across namespaces
+func GetManifestWorkReplicaSets(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	list, err := ocmClient.WorkClient.WorkV1alpha1().ManifestWorkReplicaSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Convert to our simplified ManifestWorkReplicaSet format
+	replicaSets := make([]models.ManifestWorkReplicaSet, 0, len(list.Items))
+	for _, item := range list.Items {
+		replicaSets = append(replicaSets, convertManifestWorkReplicaSetToModel(item))
+	}
+
+	c.JSON(http.StatusOK, replicaSets)
+}
+
+// GetManifestWorkReplicaSetsByNamespace handles retrieving ManifestWorkReplicaSets in a namespace
+func GetManifestWorkReplicaSetsByNamespace(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	list, err := ocmClient.WorkClient.WorkV1alpha1().ManifestWorkReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Convert to our simplified ManifestWorkReplicaSet format
+	replicaSets := make([]models.ManifestWorkReplicaSet, 0, len(list.Items))
+	for _, item := range list.Items {
+		replicaSets = append(replicaSets, convertManifestWorkReplicaSetToModel(item))
+	}
+
+	c.JSON(http.StatusOK, replicaSets)
+}
+
+// GetManifestWorkReplicaSet handles retrieving a ManifestWorkReplicaSet with the ManifestWorks
+// generated for each selected cluster
+func GetManifestWorkReplicaSet(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.ManifestWorkReplicaSetResource, namespace, name)
+		return
+	}
+
+	item, err := ocmClient.WorkClient.WorkV1alpha1().ManifestWorkReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	replicaSet := convertManifestWorkReplicaSetToModel(*item)
+
+	// The child ManifestWorks live in the namespace of each selected cluster
+	works, err := listManifestWorkReplicaSetWorks(ctx, ocmClient, namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	replicaSet.ManifestWorks = works
+
+	c.JSON(http.StatusOK, replicaSet)
+}
+
+// listManifestWorkReplicaSetWorks lists the ManifestWorks generated for a ManifestWorkReplicaSet, sorted by cluster
+func listManifestWorkReplicaSetWorks(ctx context.Context, ocmClient *client.OCMClient, namespace, name string) ([]models.ManifestWorkSummary, error) {
+	items, err := listManifestWorkReplicaSetChildren(ctx, ocmClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	works := make([]models.ManifestWorkSummary, 0, len(items))
+	for _, item := range items {
+		works = append(works, convertManifestWorkToSummary(item))
+	}
+	sort.Slice(works, func(i, j int) bool { return works[i].Namespace < works[j].Namespace })
+
+	return works, nil
+}
+
+// listManifestWorkReplicaSetChildren lists the ManifestWorks labelled as generated for a ManifestWorkReplicaSet
+func listManifestWorkReplicaSetChildren(ctx context.Context, ocmClient *client.OCMClient, namespace, name string) ([]workv1.ManifestWork, error) {
+	selector := fmt.Sprintf("%s=%s.%s", manifestWorkReplicaSetLabel, namespace, name)
+	list, err := ocmClient.WorkClient.WorkV1().ManifestWorks("").List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// Helper function to convert a ManifestWorkReplicaSet to our simplified model
+func convertManifestWorkReplicaSetToModel(item workv1alpha1.ManifestWorkReplicaSet) models.ManifestWorkReplicaSet {
+	replicaSet := models.ManifestWorkReplicaSet{
+		ID:                   string(item.GetUID()),
+		Name:                 item.GetName(),
+		Namespace:            item.GetNamespace(),
+		Labels:               item.GetLabels(),
+		ManifestWorkTemplate: convertManifestWorkSpecToModel(item.Spec.ManifestWorkTemplate),
+		Summary:              convertManifestWorkReplicaSetSummaryToModel(item.Status.Summary),
+		CreationTimestamp:    item.GetCreationTimestamp().Format(time.RFC3339),
+	}
+
+	for _, ref := range item.Spec.PlacementRefs {
+		replicaSet.PlacementRefs = append(replicaSet.PlacementRefs, models.LocalPlacementReference{
+			Name:            ref.Name,
+			RolloutStrategy: convertRolloutStrategyToModel(ref.RolloutStrategy),
+		})
+	}
+
+	// Extract conditions
+	for _, condition := range item.Status.Conditions {
+		replicaSet.Conditions = append(replicaSet.Conditions, models.Condition{
+			Type:               condition.Type,
+			Status:             string(condition.Status),
+			LastTransitionTime: condition.LastTransitionTime.Format(time.RFC3339),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		})
+	}
+
+	for _, summary := range item.Status.PlacementsSummary {
+		replicaSet.PlacementSummaries = append(replicaSet.PlacementSummaries, models.PlacementSummary{
+			Name:                    summary.Name,
+			AvailableDecisionGroups: summary.AvailableDecisionGroups,
+			Summary:                 convertManifestWorkReplicaSetSummaryToModel(summary.Summary),
+		})
+	}
+
+	return replicaSet
+}
+
+// Helper function to convert the ManifestWork counts of a ManifestWorkReplicaSet
+func convertManifestWorkReplicaSetSummaryToModel(summary workv1alpha1.ManifestWorkReplicaSetSummary) models.ManifestWorkReplicaSetSummary {
+	return models.ManifestWorkReplicaSetSummary{
+		Total:       summary.Total,
+		Applied:     summary.Applied,
+		Available:   summary.Available,
+		Progressing: summary.Progressing,
+		Degraded:    summary.Degraded,
+	}
+}
+
+// Helper function to convert a rollout strategy, only the settings of the selected type are kept
+func convertRolloutStrategyToModel(strategy clusterv1alpha1.RolloutStrategy) models.RolloutStrategy {
+	rollout := models.RolloutStrategy{Type: string(strategy.Type)}
+
+	var (
+		config    *clusterv1alpha1.RolloutConfig
+		mandatory []clusterv1alpha1.MandatoryDecisionGroup
+	)
+
+	switch strategy.Type {
+	case clusterv1alpha1.Progressive:
+		if strategy.Progressive != nil {
+			config = &strategy.Progressive.RolloutConfig
+			mandatory = strategy.Progressive.MandatoryDecisionGroups.MandatoryDecisionGroups
+			if maxConcurrency := strategy.Progressive.MaxConcurrency.String(); maxConcurrency != "0" {
+				rollout.MaxConcurrency = maxConcurrency
+			}
+		}
+	case clusterv1alpha1.ProgressivePerGroup:
+		if strategy.ProgressivePerGroup != nil {
+			config = &strategy.ProgressivePerGroup.RolloutConfig
+			mandatory = strategy.ProgressivePerGroup.MandatoryDecisionGroups.MandatoryDecisionGroups
+		}
+	default:
+		// The type defaults to All when it is not set
+		rollout.Type = string(clusterv1alpha1.All)
+		if strategy.All != nil {
+			config = &strategy.All.RolloutConfig
+		}
+	}
+
+	if config != nil {
+		if config.MinSuccessTime.Duration > 0 {
+			rollout.MinSuccessTime = config.MinSuccessTime.Duration.String()
+		}
+		rollout.ProgressDeadline = config.ProgressDeadline
+		if config.MaxFailures.String() != "0" {
+			rollout.MaxFailures = config.MaxFailures.String()
+		}
+	}
+
+	for _, group := range mandatory {
+		rollout.MandatoryDecisionGroups = append(rollout.MandatoryDecisionGroups, models.MandatoryDecisionGroup{
+			GroupName:  group.GroupName,
+			GroupIndex: group.GroupIndex,
+		})
+	}
+
+	return rollout
+}
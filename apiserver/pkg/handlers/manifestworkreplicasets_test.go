@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	workv1 "open-cluster-management.io/api/work/v1"
+	workv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func newTestManifestWorkReplicaSet(namespace, name string, strategy clusterv1alpha1.RolloutStrategy) *workv1alpha1.ManifestWorkReplicaSet {
+	return &workv1alpha1.ManifestWorkReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: workv1alpha1.ManifestWorkReplicaSetSpec{
+			ManifestWorkTemplate: workv1.ManifestWorkSpec{
+				Workload: workv1.ManifestsTemplate{
+					Manifests: []workv1.Manifest{
+						{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app","namespace":"default"}}`)}},
+					},
+				},
+			},
+			PlacementRefs: []workv1alpha1.LocalPlacementReference{
+				{Name: "placement", RolloutStrategy: strategy},
+			},
+		},
+		Status: workv1alpha1.ManifestWorkReplicaSetStatus{
+			Summary: workv1alpha1.ManifestWorkReplicaSetSummary{Total: 2, Applied: 2, Available: 1, Progressing: 1},
+			PlacementsSummary: []workv1alpha1.PlacementSummary{
+				{Name: "placement", AvailableDecisionGroups: "1 (2 / 2 clusters applied)"},
+			},
+		},
+	}
+}
+
+func newTestChildManifestWork(cluster, namespace, name string, conditions ...metav1.Condition) *workv1.ManifestWork {
+	return &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster,
+			Labels:    map[string]string{manifestWorkReplicaSetLabel: namespace + "." + name},
+		},
+		Status: workv1.ManifestWorkStatus{Conditions: conditions},
+	}
+}
+
+func TestGetManifestWorkReplicaSets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	workClient := workfake.NewSimpleClientset(
+		newTestManifestWorkReplicaSet("default", "app", clusterv1alpha1.RolloutStrategy{}),
+		newTestManifestWorkReplicaSet("other", "app", clusterv1alpha1.RolloutStrategy{}),
+	)
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		namespace      string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "all namespaces",
+			client:         &client.OCMClient{WorkClient: workClient},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "single namespace",
+			client:         &client.OCMClient{WorkClient: workClient},
+			namespace:      "default",
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			if tt.namespace != "" {
+				c.Params = gin.Params{{Key: "namespace", Value: tt.namespace}}
+				GetManifestWorkReplicaSetsByNamespace(c, tt.client, context.Background())
+			} else {
+				GetManifestWorkReplicaSets(c, tt.client, context.Background())
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var replicaSets []models.ManifestWorkReplicaSet
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &replicaSets))
+			assert.Len(t, replicaSets, tt.expectedCount)
+		})
+	}
+}
+
+func TestGetManifestWorkReplicaSet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	strategy := clusterv1alpha1.RolloutStrategy{
+		Type: clusterv1alpha1.Progressive,
+		Progressive: &clusterv1alpha1.RolloutProgressive{
+			RolloutConfig: clusterv1alpha1.RolloutConfig{
+				ProgressDeadline: "10m",
+				MaxFailures:      intstr.FromInt32(1),
+			},
+			MandatoryDecisionGroups: clusterv1alpha1.MandatoryDecisionGroups{
+				MandatoryDecisionGroups: []clusterv1alpha1.MandatoryDecisionGroup{{GroupName: "canary"}},
+			},
+			MaxConcurrency: intstr.FromString("50%"),
+		},
+	}
+	available := metav1.Condition{Type: workv1.WorkAvailable, Status: metav1.ConditionTrue}
+
+	workClient := workfake.NewSimpleClientset(
+		newTestManifestWorkReplicaSet("default", "app", strategy),
+		newTestChildManifestWork("cluster2", "default", "app"),
+		newTestChildManifestWork("cluster1", "default", "app", available),
+		&workv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "cluster1"}},
+	)
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		replicaSetName string
+		expectedStatus int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			replicaSetName: "app",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "not found",
+			client:         &client.OCMClient{WorkClient: workClient},
+			replicaSetName: "missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "with child manifestworks",
+			client:         &client.OCMClient{WorkClient: workClient},
+			replicaSetName: "app",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{
+				{Key: "namespace", Value: "default"},
+				{Key: "name", Value: tt.replicaSetName},
+			}
+
+			GetManifestWorkReplicaSet(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var replicaSet models.ManifestWorkReplicaSet
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &replicaSet))
+			assert.Equal(t, "ConfigMap", replicaSet.ManifestWorkTemplate.Workload[0].RawExtension["kind"])
+			assert.Equal(t, 2, replicaSet.Summary.Total)
+			require.Len(t, replicaSet.PlacementSummaries, 1)
+
+			require.Len(t, replicaSet.PlacementRefs, 1)
+			rollout := replicaSet.PlacementRefs[0].RolloutStrategy
+			assert.Equal(t, "Progressive", rollout.Type)
+			assert.Equal(t, "10m", rollout.ProgressDeadline)
+			assert.Equal(t, "1", rollout.MaxFailures)
+			assert.Equal(t, "50%", rollout.MaxConcurrency)
+			assert.Equal(t, "canary", rollout.MandatoryDecisionGroups[0].GroupName)
+
+			require.Len(t, replicaSet.ManifestWorks, 2)
+			assert.Equal(t, "cluster1", replicaSet.ManifestWorks[0].Namespace)
+			assert.Equal(t, "Available", replicaSet.ManifestWorks[0].Status)
+			assert.Equal(t, "cluster2", replicaSet.ManifestWorks[1].Namespace)
+		})
+	}
+}
+
+func TestConvertRolloutStrategyToModel(t *testing.T) {
+	rollout := convertRolloutStrategyToModel(clusterv1alpha1.RolloutStrategy{})
+	assert.Equal(t, "All", rollout.Type)
+	assert.Empty(t, rollout.MaxFailures)
+	assert.Empty(t, rollout.MaxConcurrency)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,6 +80,12 @@ func GetPlacementDecision(c *gin.Context, ocmClient *client.OCMClient, ctx conte
 		return
 	}
 
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.PlacementDecisionResource, namespace, name)
+		return
+	}
+
 	// Get the specific placement decision using the OCM cluster client
 	pd, err := ocmClient.ClusterClient.ClusterV1beta1().PlacementDecisions(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
@@ -113,22 +122,182 @@ func GetPlacementDecisionsByPlacement(c *gin.Context, ocmClient *client.OCMClien
 		return
 	}
 
-	// Convert to our simplified PlacementDecision format
+	// Convert to our simplified PlacementDecision format, ordered by decision group
 	placementDecisions := make([]models.PlacementDecision, 0, len(pdList.Items))
 	for _, pd := range pdList.Items {
 		placementDecision := convertPlacementDecisionToModel(&pd)
 		placementDecisions = append(placementDecisions, placementDecision)
 	}
+	sortPlacementDecisions(placementDecisions)
 
 	c.JSON(http.StatusOK, placementDecisions)
 }
 
+// GetMergedPlacementDecisions handles retrieving all decisions of a placement merged into one view
+func GetMergedPlacementDecisions(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	// A placement may spread its decisions over several PlacementDecision objects
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", clusterv1beta1.PlacementLabel, name),
+	}
+
+	pdList, err := ocmClient.ClusterClient.ClusterV1beta1().PlacementDecisions(namespace).List(ctx, listOptions)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	placementDecisions := make([]models.PlacementDecision, 0, len(pdList.Items))
+	for _, pd := range pdList.Items {
+		placementDecisions = append(placementDecisions, convertPlacementDecisionToModel(&pd))
+	}
+
+	c.JSON(http.StatusOK, mergePlacementDecisions(namespace, name, placementDecisions))
+}
+
+// GetClusterPlacements handles retrieving every placement that currently selects a cluster
+func GetClusterPlacements(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	clusterName := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	placements, err := listClusterPlacements(ctx, ocmClient, clusterName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, placements)
+}
+
+// listClusterPlacements finds the placements across all namespaces whose decisions include a cluster
+func listClusterPlacements(ctx context.Context, ocmClient *client.OCMClient, clusterName string) ([]models.ClusterPlacement, error) {
+	pdList, err := ocmClient.ClusterClient.ClusterV1beta1().PlacementDecisions("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	placements := make([]models.ClusterPlacement, 0)
+	for _, pd := range pdList.Items {
+		placementDecision := convertPlacementDecisionToModel(&pd)
+		for _, decision := range placementDecision.Decisions {
+			if decision.ClusterName != clusterName {
+				continue
+			}
+			placements = append(placements, models.ClusterPlacement{
+				PlacementName:      placementDecision.PlacementName,
+				Namespace:          placementDecision.Namespace,
+				DecisionName:       placementDecision.Name,
+				DecisionGroupIndex: placementDecision.DecisionGroupIndex,
+				DecisionGroupName:  placementDecision.DecisionGroupName,
+				Reason:             decision.Reason,
+			})
+		}
+	}
+
+	sort.Slice(placements, func(i, j int) bool {
+		if placements[i].Namespace != placements[j].Namespace {
+			return placements[i].Namespace < placements[j].Namespace
+		}
+		return placements[i].PlacementName < placements[j].PlacementName
+	})
+
+	return placements, nil
+}
+
+// mergePlacementDecisions merges the decisions of one placement into groups ordered by group index
+func mergePlacementDecisions(namespace, placementName string, placementDecisions []models.PlacementDecision) models.MergedPlacementDecision {
+	sortPlacementDecisions(placementDecisions)
+
+	merged := models.MergedPlacementDecision{
+		PlacementName: placementName,
+		Namespace:     namespace,
+		Groups:        []models.PlacementDecisionGroup{},
+		Decisions:     []models.ClusterDecision{},
+	}
+
+	for _, pd := range placementDecisions {
+		last := len(merged.Groups) - 1
+		if last < 0 || merged.Groups[last].DecisionGroupIndex != pd.DecisionGroupIndex {
+			merged.Groups = append(merged.Groups, models.PlacementDecisionGroup{
+				DecisionGroupIndex: pd.DecisionGroupIndex,
+				DecisionGroupName:  pd.DecisionGroupName,
+				Decisions:          []models.ClusterDecision{},
+			})
+			last++
+		}
+
+		group := &merged.Groups[last]
+		group.DecisionNames = append(group.DecisionNames, pd.Name)
+		group.Decisions = append(group.Decisions, pd.Decisions...)
+		merged.Decisions = append(merged.Decisions, pd.Decisions...)
+	}
+
+	merged.ClusterCount = len(merged.Decisions)
+
+	return merged
+}
+
+// sortPlacementDecisions orders placement decisions by decision group index, then by name
+func sortPlacementDecisions(placementDecisions []models.PlacementDecision) {
+	sort.SliceStable(placementDecisions, func(i, j int) bool {
+		if placementDecisions[i].DecisionGroupIndex != placementDecisions[j].DecisionGroupIndex {
+			return placementDecisions[i].DecisionGroupIndex < placementDecisions[j].DecisionGroupIndex
+		}
+		return decisionNameLess(placementDecisions[i].Name, placementDecisions[j].Name)
+	})
+}
+
+// decisionNameLess orders the names the placement controller gives decisions,
+// <placement>-decision-<n>, by their numeric suffix so that -decision-10 comes
+// after -decision-2. Other names are compared as strings.
+func decisionNameLess(a, b string) bool {
+	aPrefix, aIndex, aOK := decisionNameIndex(a)
+	bPrefix, bIndex, bOK := decisionNameIndex(b)
+	if aOK && bOK && aPrefix == bPrefix {
+		return aIndex < bIndex
+	}
+	return a < b
+}
+
+// decisionNameIndex splits a decision name into its prefix and numeric suffix
+func decisionNameIndex(name string) (string, int, bool) {
+	i := strings.LastIndex(name, "-")
+	if i < 0 {
+		return "", 0, false
+	}
+	index, err := strconv.Atoi(name[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return name[:i], index, true
+}
+
 // Helper function to convert a PlacementDecision resource to our model
 func convertPlacementDecisionToModel(pd *clusterv1beta1.PlacementDecision) models.PlacementDecision {
 	placementDecision := models.PlacementDecision{
-		ID:        string(pd.GetUID()),
-		Name:      pd.GetName(),
-		Namespace: pd.GetNamespace(),
+		ID:                string(pd.GetUID()),
+		Name:              pd.GetName(),
+		Namespace:         pd.GetNamespace(),
+		PlacementName:     pd.Labels[clusterv1beta1.PlacementLabel],
+		DecisionGroupName: pd.Labels[clusterv1beta1.DecisionGroupNameLabel],
+	}
+
+	// Extract the decision group index from the labels set by the placement controller
+	if index, err := strconv.Atoi(pd.Labels[clusterv1beta1.DecisionGroupIndexLabel]); err == nil {
+		placementDecision.DecisionGroupIndex = int32(index)
 	}
 
 	// Extract decisions from the PlacementDecision status
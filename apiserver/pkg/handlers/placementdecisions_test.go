@@ -2,16 +2,40 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
 )
 
+func newTestPlacementDecision(namespace, name, placement, groupIndex, groupName string, clusters ...string) *clusterv1beta1.PlacementDecision {
+	pd := &clusterv1beta1.PlacementDecision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				clusterv1beta1.PlacementLabel:          placement,
+				clusterv1beta1.DecisionGroupIndexLabel: groupIndex,
+				clusterv1beta1.DecisionGroupNameLabel:  groupName,
+			},
+		},
+	}
+	for _, cluster := range clusters {
+		pd.Status.Decisions = append(pd.Status.Decisions, clusterv1beta1.ClusterDecision{ClusterName: cluster})
+	}
+	return pd
+}
+
 func TestGetPlacementDecisionsByNamespace(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -80,3 +104,88 @@ func TestGetPlacementDecision(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMergedPlacementDecisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ocmClient := &client.OCMClient{ClusterClient: clusterfake.NewSimpleClientset(
+		newTestPlacementDecision("default", "p-decision-3", "p", "1", "prod", "cluster3"),
+		newTestPlacementDecision("default", "p-decision-10", "p", "0", "canary", "cluster10"),
+		newTestPlacementDecision("default", "p-decision-2", "p", "0", "canary", "cluster2"),
+		newTestPlacementDecision("default", "p-decision-1", "p", "0", "canary", "cluster1"),
+		newTestPlacementDecision("default", "other-decision-1", "other", "0", "", "cluster4"),
+	)}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "namespace", Value: "default"},
+		{Key: "name", Value: "p"},
+	}
+
+	GetMergedPlacementDecisions(c, ocmClient, context.Background())
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var merged models.MergedPlacementDecision
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &merged))
+	assert.Equal(t, "p", merged.PlacementName)
+	assert.Equal(t, 4, merged.ClusterCount)
+	require.Len(t, merged.Groups, 2)
+	assert.Equal(t, "canary", merged.Groups[0].DecisionGroupName)
+	assert.Equal(t, []string{"p-decision-1", "p-decision-2", "p-decision-10"}, merged.Groups[0].DecisionNames)
+	assert.Equal(t, int32(1), merged.Groups[1].DecisionGroupIndex)
+	assert.Equal(t, "cluster3", merged.Decisions[3].ClusterName)
+}
+
+func TestGetClusterPlacements(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ocmClient := &client.OCMClient{ClusterClient: clusterfake.NewSimpleClientset(
+		newTestPlacementDecision("ns2", "b-decision-1", "b", "0", "", "cluster1", "cluster2"),
+		newTestPlacementDecision("ns1", "a-decision-2", "a", "1", "prod", "cluster1"),
+		newTestPlacementDecision("ns1", "c-decision-1", "c", "0", "", "cluster2"),
+	)}
+
+	tests := []struct {
+		name               string
+		client             *client.OCMClient
+		expectedStatus     int
+		expectedPlacements []string
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:               "placements selecting cluster",
+			client:             ocmClient,
+			expectedStatus:     http.StatusOK,
+			expectedPlacements: []string{"ns1/a", "ns2/b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: "cluster1"}}
+
+			GetClusterPlacements(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var placements []models.ClusterPlacement
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &placements))
+			names := make([]string, 0, len(placements))
+			for _, placement := range placements {
+				names = append(names, placement.Namespace+"/"+placement.PlacementName)
+			}
+			assert.Equal(t, tt.expectedPlacements, names)
+			assert.Equal(t, "prod", placements[0].DecisionGroupName)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"open-cluster-management-io/lab/apiserver/pkg/history"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// defaultHistoryWindow is the churn window used when none is requested
+const defaultHistoryWindow = 24 * time.Hour
+
+// GetPlacementHistory handles retrieving the recorded decision changes of a placement
+func GetPlacementHistory(c *gin.Context, store *history.Store) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure history recording is enabled before proceeding
+	if store == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Placement history is not enabled"})
+		return
+	}
+
+	window := defaultHistoryWindow
+	if value := c.Query("window"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid window, expected a positive duration such as 1h or 30m"})
+			return
+		}
+		window = parsed
+	}
+
+	clusters, err := store.Current(namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Return all recorded changes, the churn only considers the requested window
+	changes, err := store.Changes(namespace, name, time.Time{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	since := time.Now().Add(-window)
+	windowChanges := make([]history.Change, 0, len(changes))
+	for _, change := range changes {
+		if !change.Timestamp.Before(since) {
+			windowChanges = append(windowChanges, change)
+		}
+	}
+	churn := history.ComputeChurn(windowChanges, window)
+
+	result := models.PlacementDecisionHistory{
+		Namespace: namespace,
+		Name:      name,
+		Clusters:  clusters,
+		Changes:   make([]models.PlacementDecisionChange, 0, len(changes)),
+		Churn: models.PlacementChurn{
+			Window:          window.String(),
+			Changes:         churn.Changes,
+			ClustersAdded:   churn.ClustersAdded,
+			ClustersRemoved: churn.ClustersRemoved,
+			ChangesPerHour:  churn.ChangesPerHour,
+		},
+	}
+
+	for _, change := range changes {
+		result.Changes = append(result.Changes, models.PlacementDecisionChange{
+			Timestamp: change.Timestamp.Format(time.RFC3339),
+			Added:     change.Added,
+			Removed:   change.Removed,
+			Clusters:  change.Clusters,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"open-cluster-management-io/lab/apiserver/pkg/history"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func TestGetPlacementHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	_, err = store.Record("default", "placement1", []string{"cluster1"}, now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	_, err = store.Record("default", "placement1", []string{"cluster2"}, now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		store          *history.Store
+		query          string
+		expectedStatus int
+		expectedWindow int
+	}{
+		{
+			name:           "nil store",
+			store:          nil,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "invalid window",
+			store:          store,
+			query:          "window=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "default window",
+			store:          store,
+			expectedStatus: http.StatusOK,
+			expectedWindow: 1,
+		},
+		{
+			name:           "wide window",
+			store:          store,
+			query:          "window=72h",
+			expectedStatus: http.StatusOK,
+			expectedWindow: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			c.Params = gin.Params{
+				{Key: "namespace", Value: "default"},
+				{Key: "name", Value: "placement1"},
+			}
+
+			GetPlacementHistory(c, tt.store)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var result models.PlacementDecisionHistory
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			assert.Equal(t, []string{"cluster2"}, result.Clusters)
+			assert.Len(t, result.Changes, 2)
+			assert.Equal(t, tt.expectedWindow, result.Churn.Changes)
+		})
+	}
+}
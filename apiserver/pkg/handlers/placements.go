@@ -7,11 +7,17 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
 	"open-cluster-management-io/lab/apiserver/pkg/models"
 
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 )
 
@@ -78,6 +84,12 @@ func GetPlacement(c *gin.Context, ocmClient *client.OCMClient, ctx context.Conte
 		return
 	}
 
+	// Return the original object when YAML is requested
+	if wantsYAML(c) {
+		respondYAML(c, ocmClient, ctx, client.PlacementResource, namespace, name)
+		return
+	}
+
 	// Get the specific placement using the OCM cluster client
 	placement, err := ocmClient.ClusterClient.ClusterV1beta1().Placements(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
@@ -114,29 +126,12 @@ func GetPlacementDecisions(c *gin.Context, ocmClient *client.OCMClient, ctx cont
 		return
 	}
 
-	// Convert to our simplified PlacementDecision format
+	// Convert to our simplified PlacementDecision format, ordered by decision group
 	placementDecisions := make([]models.PlacementDecision, 0, len(list.Items))
 	for _, item := range list.Items {
-		placementDecsion := models.PlacementDecision{
-			ID:        string(item.GetUID()),
-			Name:      item.GetName(),
-			Namespace: item.GetNamespace(),
-		}
-
-		decisions := make([]models.ClusterDecision, 0, len(item.Status.Decisions))
-
-		for _, decision := range item.Status.Decisions {
-			clusterDecision := models.ClusterDecision{
-				ClusterName: decision.ClusterName,
-				Reason:      decision.Reason,
-			}
-			decisions = append(decisions, clusterDecision)
-		}
-
-		placementDecsion.Decisions = decisions
-
-		placementDecisions = append(placementDecisions, placementDecsion)
+		placementDecisions = append(placementDecisions, convertPlacementDecisionToModel(&item))
 	}
+	sortPlacementDecisions(placementDecisions)
 
 	c.JSON(http.StatusOK, placementDecisions)
 }
@@ -214,6 +209,76 @@ func convertPlacementToModel(placement clusterv1beta1.Placement) models.Placemen
 		}
 	}
 
+	// Extract PrioritizerPolicy
+	if placement.Spec.PrioritizerPolicy.Mode != "" || len(placement.Spec.PrioritizerPolicy.Configurations) > 0 {
+		p.PrioritizerPolicy = &models.PrioritizerPolicy{
+			Mode: string(placement.Spec.PrioritizerPolicy.Mode),
+		}
+
+		for _, config := range placement.Spec.PrioritizerPolicy.Configurations {
+			modelConfig := models.PrioritizerConfig{
+				Weight: config.Weight,
+			}
+
+			if config.ScoreCoordinate != nil {
+				modelConfig.ScoreCoordinate = &models.ScoreCoordinate{
+					Type:    config.ScoreCoordinate.Type,
+					BuiltIn: config.ScoreCoordinate.BuiltIn,
+				}
+				if config.ScoreCoordinate.AddOn != nil {
+					modelConfig.ScoreCoordinate.AddOn = &models.AddOnScore{
+						ResourceName: config.ScoreCoordinate.AddOn.ResourceName,
+						ScoreName:    config.ScoreCoordinate.AddOn.ScoreName,
+					}
+				}
+			}
+
+			p.PrioritizerPolicy.Configurations = append(p.PrioritizerPolicy.Configurations, modelConfig)
+		}
+	}
+
+	// Extract Tolerations
+	for _, toleration := range placement.Spec.Tolerations {
+		p.Tolerations = append(p.Tolerations, models.PlacementToleration{
+			Key:               toleration.Key,
+			Operator:          string(toleration.Operator),
+			Value:             toleration.Value,
+			Effect:            string(toleration.Effect),
+			TolerationSeconds: toleration.TolerationSeconds,
+		})
+	}
+
+	// Extract DecisionStrategy
+	groupStrategy := placement.Spec.DecisionStrategy.GroupStrategy
+	if len(groupStrategy.DecisionGroups) > 0 || groupStrategy.ClustersPerDecisionGroup.String() != "0" {
+		p.DecisionStrategy = &models.DecisionStrategy{}
+		if groupStrategy.ClustersPerDecisionGroup.String() != "0" {
+			p.DecisionStrategy.GroupStrategy.ClustersPerDecisionGroup = groupStrategy.ClustersPerDecisionGroup.String()
+		}
+
+		for _, group := range groupStrategy.DecisionGroups {
+			decisionGroup := models.DecisionGroup{
+				GroupName: group.GroupName,
+			}
+
+			selector := group.ClusterSelector.LabelSelector
+			if selector.MatchLabels != nil || len(selector.MatchExpressions) > 0 {
+				decisionGroup.GroupClusterSelector.LabelSelector = &models.LabelSelectorWithExpressions{
+					MatchLabels: selector.MatchLabels,
+				}
+				for _, expr := range selector.MatchExpressions {
+					decisionGroup.GroupClusterSelector.LabelSelector.MatchExpressions = append(decisionGroup.GroupClusterSelector.LabelSelector.MatchExpressions, models.MatchExpression{
+						Key:      expr.Key,
+						Operator: string(expr.Operator),
+						Values:   expr.Values,
+					})
+				}
+			}
+
+			p.DecisionStrategy.GroupStrategy.DecisionGroups = append(p.DecisionStrategy.GroupStrategy.DecisionGroups, decisionGroup)
+		}
+	}
+
 	// Extract status
 	p.NumberOfSelectedClusters = int32(placement.Status.NumberOfSelectedClusters)
 
@@ -249,3 +314,465 @@ func convertPlacementToModel(placement clusterv1beta1.Placement) models.Placemen
 
 	return p
 }
+
+// CreatePlacement handles creating a placement in a specific namespace
+func CreatePlacement(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil || ocmClient.Interface == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	var placementModel models.Placement
+	if err := c.ShouldBindJSON(&placementModel); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+
+	// Access is checked first, validation reads the bound clustersets
+	if !requireAccess(c, ocmClient, ctx, placementAttributes("create", namespace, placementModel.Name)) {
+		return
+	}
+
+	fieldErrors, err := validatePlacement(ctx, ocmClient, namespace, placementModel)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid placement", "fields": fieldErrors})
+		return
+	}
+
+	obj, err := convertModelToUnstructuredPlacement(namespace, placementModel)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The dynamic client is used so that fields newer than the vendored API types
+	// (such as CEL selectors) are passed through to the hub
+	created, err := ocmClient.Interface.Resource(ocmClient.SelectedResource(client.PlacementResource)).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := convertUnstructuredPlacementToModel(created)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// UpdatePlacement handles replacing the spec of an existing placement
+func UpdatePlacement(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil || ocmClient.Interface == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	var placementModel models.Placement
+	if err := c.ShouldBindJSON(&placementModel); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+
+	if placementModel.Name == "" {
+		placementModel.Name = name
+	}
+	if placementModel.Name != name {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "Invalid placement",
+			"fields": []models.FieldError{{Field: "name", Message: fmt.Sprintf("name must match %q", name)}},
+		})
+		return
+	}
+
+	// Access is checked first, validation reads the bound clustersets
+	if !requireAccess(c, ocmClient, ctx, placementAttributes("update", namespace, name)) {
+		return
+	}
+
+	fieldErrors, err := validatePlacement(ctx, ocmClient, namespace, placementModel)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid placement", "fields": fieldErrors})
+		return
+	}
+
+	placementClient := ocmClient.Interface.Resource(ocmClient.SelectedResource(client.PlacementResource)).Namespace(namespace)
+	existing, err := placementClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	desired, err := convertModelToUnstructuredPlacement(namespace, placementModel)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Only the fields of the model are replaced, metadata, status and the spec
+	// fields the model does not cover are kept from the hub
+	if err := mergePlacementSpec(existing, desired); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := placementClient.Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := convertUnstructuredPlacementToModel(updated)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeletePlacement handles deleting a specific placement
+func DeletePlacement(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.Interface == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	if !requireAccess(c, ocmClient, ctx, placementAttributes("delete", namespace, name)) {
+		return
+	}
+
+	// Placements are written with the dynamic client, like on create and update
+	err := ocmClient.Interface.Resource(ocmClient.SelectedResource(client.PlacementResource)).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// placementAttributes returns the access review attributes of a placement action
+func placementAttributes(verb, namespace, name string) authorizationv1.ResourceAttributes {
+	return authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     clusterv1beta1.GroupName,
+		Resource:  "placements",
+		Name:      name,
+	}
+}
+
+// statusForError maps a Kubernetes API error to the HTTP status returned to the client
+func statusForError(err error) int {
+	switch {
+	case apierrors.IsNotFound(err):
+		return http.StatusNotFound
+	case apierrors.IsAlreadyExists(err), apierrors.IsConflict(err):
+		return http.StatusConflict
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return http.StatusUnprocessableEntity
+	case apierrors.IsForbidden(err):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Helper function to convert our model to a Placement resource
+func convertModelToPlacement(namespace string, p models.Placement) clusterv1beta1.Placement {
+	placement := clusterv1beta1.Placement{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1beta1.GroupVersion.String(),
+			Kind:       "Placement",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: namespace,
+		},
+		Spec: clusterv1beta1.PlacementSpec{
+			ClusterSets:      p.ClusterSets,
+			NumberOfClusters: p.NumberOfClusters,
+		},
+	}
+
+	// Convert predicates
+	for _, predicate := range p.Predicates {
+		clusterPredicate := clusterv1beta1.ClusterPredicate{}
+
+		if predicate.RequiredClusterSelector != nil {
+			if selector := predicate.RequiredClusterSelector.LabelSelector; selector != nil {
+				clusterPredicate.RequiredClusterSelector.LabelSelector = convertModelToLabelSelector(selector)
+			}
+
+			if selector := predicate.RequiredClusterSelector.ClaimSelector; selector != nil {
+				clusterPredicate.RequiredClusterSelector.ClaimSelector.MatchExpressions = convertModelToRequirements(selector.MatchExpressions)
+			}
+		}
+
+		placement.Spec.Predicates = append(placement.Spec.Predicates, clusterPredicate)
+	}
+
+	// Convert prioritizer policy
+	if p.PrioritizerPolicy != nil {
+		placement.Spec.PrioritizerPolicy.Mode = clusterv1beta1.PrioritizerPolicyModeType(p.PrioritizerPolicy.Mode)
+
+		for _, config := range p.PrioritizerPolicy.Configurations {
+			prioritizerConfig := clusterv1beta1.PrioritizerConfig{
+				Weight: config.Weight,
+			}
+
+			if config.ScoreCoordinate != nil {
+				prioritizerConfig.ScoreCoordinate = &clusterv1beta1.ScoreCoordinate{
+					Type:    config.ScoreCoordinate.Type,
+					BuiltIn: config.ScoreCoordinate.BuiltIn,
+				}
+				if prioritizerConfig.ScoreCoordinate.Type == "" {
+					prioritizerConfig.ScoreCoordinate.Type = clusterv1beta1.ScoreCoordinateTypeBuiltIn
+				}
+				if config.ScoreCoordinate.AddOn != nil {
+					prioritizerConfig.ScoreCoordinate.AddOn = &clusterv1beta1.AddOnScore{
+						ResourceName: config.ScoreCoordinate.AddOn.ResourceName,
+						ScoreName:    config.ScoreCoordinate.AddOn.ScoreName,
+					}
+				}
+			}
+
+			placement.Spec.PrioritizerPolicy.Configurations = append(placement.Spec.PrioritizerPolicy.Configurations, prioritizerConfig)
+		}
+	}
+
+	// Convert tolerations
+	for _, toleration := range p.Tolerations {
+		placement.Spec.Tolerations = append(placement.Spec.Tolerations, clusterv1beta1.Toleration{
+			Key:               toleration.Key,
+			Operator:          clusterv1beta1.TolerationOperator(toleration.Operator),
+			Value:             toleration.Value,
+			Effect:            clusterv1.TaintEffect(toleration.Effect),
+			TolerationSeconds: toleration.TolerationSeconds,
+		})
+	}
+
+	// Convert decision strategy
+	if p.DecisionStrategy != nil {
+		groupStrategy := p.DecisionStrategy.GroupStrategy
+
+		if groupStrategy.ClustersPerDecisionGroup != "" {
+			placement.Spec.DecisionStrategy.GroupStrategy.ClustersPerDecisionGroup = intstr.Parse(groupStrategy.ClustersPerDecisionGroup)
+		}
+
+		for _, group := range groupStrategy.DecisionGroups {
+			decisionGroup := clusterv1beta1.DecisionGroup{
+				GroupName: group.GroupName,
+			}
+			if selector := group.GroupClusterSelector.LabelSelector; selector != nil {
+				decisionGroup.ClusterSelector.LabelSelector = convertModelToLabelSelector(selector)
+			}
+			placement.Spec.DecisionStrategy.GroupStrategy.DecisionGroups = append(placement.Spec.DecisionStrategy.GroupStrategy.DecisionGroups, decisionGroup)
+		}
+	}
+
+	return placement
+}
+
+// Helper function to convert our model to a Placement as an unstructured object,
+// including the CEL selectors which are not part of the vendored API types
+func convertModelToUnstructuredPlacement(namespace string, p models.Placement) (*unstructured.Unstructured, error) {
+	placement := convertModelToPlacement(namespace, p)
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&placement)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+
+	// Status is owned by the placement controller
+	unstructured.RemoveNestedField(obj.Object, "status")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+
+	predicates, _, _ := unstructured.NestedSlice(obj.Object, "spec", "predicates")
+	for i, predicate := range p.Predicates {
+		if predicate.RequiredClusterSelector == nil || predicate.RequiredClusterSelector.CelSelector == nil || i >= len(predicates) {
+			continue
+		}
+
+		expressions := make([]interface{}, 0, len(predicate.RequiredClusterSelector.CelSelector.CelExpressions))
+		for _, expression := range predicate.RequiredClusterSelector.CelSelector.CelExpressions {
+			expressions = append(expressions, expression)
+		}
+
+		predicateMap, ok := predicates[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := unstructured.SetNestedSlice(predicateMap, expressions, "requiredClusterSelector", "celSelector", "celExpressions"); err != nil {
+			return nil, err
+		}
+	}
+	if len(predicates) > 0 {
+		if err := unstructured.SetNestedSlice(obj.Object, predicates, "spec", "predicates"); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj, nil
+}
+
+// mergePlacementSpec replaces the fields of the existing placement spec that the
+// model covers with those of desired. The model covers a field when converting
+// the existing placement to the model and back keeps it, other fields such as
+// the spread policy or fields newer than the vendored API types are kept.
+func mergePlacementSpec(existing, desired *unstructured.Unstructured) error {
+	model, err := convertUnstructuredPlacementToModel(existing)
+	if err != nil {
+		return err
+	}
+	modeled, err := convertModelToUnstructuredPlacement(existing.GetNamespace(), model)
+	if err != nil {
+		return err
+	}
+
+	spec := mergeModeledFields(existing.Object["spec"], desired.Object["spec"], modeled.Object["spec"])
+	if spec == nil {
+		delete(existing.Object, "spec")
+		return nil
+	}
+	existing.Object["spec"] = spec
+	return nil
+}
+
+// mergeModeledFields merges desired into existing, keeping the fields of existing
+// missing from modeled. Lists of the same length are merged item by item; when
+// items were added or removed they cannot be matched, so the desired list
+// replaces the existing one and the unmodeled fields of its items are dropped.
+func mergeModeledFields(existing, desired, modeled interface{}) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		existingMap, ok := existing.(map[string]interface{})
+		if !ok {
+			return desired
+		}
+		modeledMap, _ := modeled.(map[string]interface{})
+
+		merged := make(map[string]interface{}, len(existingMap)+len(desiredValue))
+		for key, value := range existingMap {
+			if _, covered := modeledMap[key]; !covered {
+				merged[key] = value
+			} else if _, set := desiredValue[key]; !set {
+				// The model no longer sets the field, its unmodeled fields are kept
+				if kept := mergeModeledFields(value, nil, modeledMap[key]); kept != nil {
+					merged[key] = kept
+				}
+			}
+		}
+		for key, value := range desiredValue {
+			merged[key] = mergeModeledFields(existingMap[key], value, modeledMap[key])
+		}
+		return merged
+	case []interface{}:
+		existingList, ok := existing.([]interface{})
+		if !ok || len(existingList) != len(desiredValue) {
+			return desired
+		}
+		modeledList, _ := modeled.([]interface{})
+
+		merged := make([]interface{}, len(desiredValue))
+		for i, value := range desiredValue {
+			var modeledItem interface{}
+			if i < len(modeledList) {
+				modeledItem = modeledList[i]
+			}
+			merged[i] = mergeModeledFields(existingList[i], value, modeledItem)
+		}
+		return merged
+	case nil:
+		existingMap, ok := existing.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		kept, _ := mergeModeledFields(existingMap, map[string]interface{}{}, modeled).(map[string]interface{})
+		if len(kept) == 0 {
+			return nil
+		}
+		return kept
+	default:
+		return desired
+	}
+}
+
+// Helper function to convert an unstructured Placement to our model, including CEL selectors
+func convertUnstructuredPlacementToModel(obj *unstructured.Unstructured) (models.Placement, error) {
+	var placement clusterv1beta1.Placement
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &placement); err != nil {
+		return models.Placement{}, err
+	}
+
+	p := convertPlacementToModel(placement)
+
+	predicates, _, _ := unstructured.NestedSlice(obj.Object, "spec", "predicates")
+	for i, predicate := range predicates {
+		predicateMap, ok := predicate.(map[string]interface{})
+		if !ok || i >= len(p.Predicates) {
+			continue
+		}
+
+		expressions, found, _ := unstructured.NestedStringSlice(predicateMap, "requiredClusterSelector", "celSelector", "celExpressions")
+		if !found || len(expressions) == 0 {
+			continue
+		}
+
+		if p.Predicates[i].RequiredClusterSelector == nil {
+			p.Predicates[i].RequiredClusterSelector = &models.RequiredClusterSelector{}
+		}
+		p.Predicates[i].RequiredClusterSelector.CelSelector = &models.CelSelectorWithExpressions{
+			CelExpressions: expressions,
+		}
+	}
+
+	return p, nil
+}
+
+// Helper function to convert our label selector model to a Kubernetes label selector
+func convertModelToLabelSelector(selector *models.LabelSelectorWithExpressions) metav1.LabelSelector {
+	return metav1.LabelSelector{
+		MatchLabels:      selector.MatchLabels,
+		MatchExpressions: convertModelToRequirements(selector.MatchExpressions),
+	}
+}
+
+// Helper function to convert our match expressions to Kubernetes selector requirements
+func convertModelToRequirements(expressions []models.MatchExpression) []metav1.LabelSelectorRequirement {
+	if len(expressions) == 0 {
+		return nil
+	}
+
+	requirements := make([]metav1.LabelSelectorRequirement, 0, len(expressions))
+	for _, expr := range expressions {
+		requirements = append(requirements, metav1.LabelSelectorRequirement{
+			Key:      expr.Key,
+			Operator: metav1.LabelSelectorOperator(expr.Operator),
+			Values:   expr.Values,
+		})
+	}
+	return requirements
+}
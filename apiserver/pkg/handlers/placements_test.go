@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,9 +11,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
 	"open-cluster-management-io/lab/apiserver/pkg/models"
@@ -285,3 +298,239 @@ func TestConvertPlacementToModel(t *testing.T) {
 		})
 	}
 }
+
+func newPlacementTestClient(objects ...runtime.Object) *client.OCMClient {
+	binding := &clusterv1beta2.ManagedClusterSetBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "default"},
+		Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: "global"},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1beta1.Install(scheme)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+		map[schema.GroupVersionResource]string{client.PlacementResource: "PlacementList"}, objects...)
+
+	return &client.OCMClient{
+		Interface:     dynamicClient,
+		ClusterClient: clusterfake.NewSimpleClientset(append(objects, binding)...),
+	}
+}
+
+func TestCreatePlacement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			body:           `{}`,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid body",
+			client:         newPlacementTestClient(),
+			body:           `{`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "validation errors",
+			client:         newPlacementTestClient(),
+			body:           `{"name":"p","clusterSets":["missing"]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "valid placement",
+			client: newPlacementTestClient(),
+			body: `{"name":"p","clusterSets":["global"],"numberOfClusters":2,
+				"predicates":[{"requiredClusterSelector":{"celSelector":{"celExpressions":["managedCluster.metadata.name != 'hub'"]}}}],
+				"tolerations":[{"key":"gpu","operator":"Exists"}]}`,
+			expectedStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			c.Params = gin.Params{{Key: "namespace", Value: "default"}}
+
+			CreatePlacement(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				return
+			}
+
+			var result models.Placement
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			assert.Equal(t, "p", result.Name)
+			assert.Equal(t, []string{"global"}, result.ClusterSets)
+			require.Len(t, result.Predicates, 1)
+			require.NotNil(t, result.Predicates[0].RequiredClusterSelector.CelSelector)
+			assert.Len(t, result.Predicates[0].RequiredClusterSelector.CelSelector.CelExpressions, 1)
+			require.Len(t, result.Tolerations, 1)
+			assert.Equal(t, "Exists", result.Tolerations[0].Operator)
+		})
+	}
+}
+
+func TestUpdateAndDeletePlacement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	existing := &clusterv1beta1.Placement{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1beta1.GroupVersion.String(), Kind: "Placement"},
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "default"},
+		Spec: clusterv1beta1.PlacementSpec{
+			Tolerations: []clusterv1beta1.Toleration{{Key: "gpu", Operator: clusterv1beta1.TolerationOpExists}},
+			SpreadPolicy: clusterv1beta1.SpreadPolicy{
+				SpreadConstraints: []clusterv1beta1.SpreadConstraintsTerm{{TopologyKey: "zone", TopologyKeyType: clusterv1beta1.TopologyKeyTypeLabel}},
+			},
+		},
+	}
+	ocmClient := newPlacementTestClient(existing)
+
+	// Mismatched name
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{"name":"other"}`))
+	c.Params = gin.Params{{Key: "namespace", Value: "default"}, {Key: "name", Value: "p"}}
+	UpdatePlacement(c, ocmClient, context.Background())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// Valid update
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(
+		`{"prioritizerPolicy":{"mode":"Exact","configurations":[{"scoreCoordinate":{"builtIn":"Balance"},"weight":2}]}}`))
+	c.Params = gin.Params{{Key: "namespace", Value: "default"}, {Key: "name", Value: "p"}}
+	UpdatePlacement(c, ocmClient, context.Background())
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result models.Placement
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.NotNil(t, result.PrioritizerPolicy)
+	assert.Equal(t, "Exact", result.PrioritizerPolicy.Mode)
+	assert.Equal(t, "BuiltIn", result.PrioritizerPolicy.Configurations[0].ScoreCoordinate.Type)
+	assert.Empty(t, result.Tolerations)
+
+	// The spread policy is not part of the model and is kept
+	updated, err := ocmClient.Interface.Resource(client.PlacementResource).Namespace("default").Get(context.Background(), "p", metav1.GetOptions{})
+	require.NoError(t, err)
+	constraints, _, _ := unstructured.NestedSlice(updated.Object, "spec", "spreadPolicy", "spreadConstraints")
+	assert.Len(t, constraints, 1)
+
+	// Delete
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "namespace", Value: "default"}, {Key: "name", Value: "p"}}
+	DeletePlacement(c, ocmClient, context.Background())
+	assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+
+	// Delete again
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "namespace", Value: "default"}, {Key: "name", Value: "p"}}
+	DeletePlacement(c, ocmClient, context.Background())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPlacementAccessDenied(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	existing := &clusterv1beta1.Placement{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1beta1.GroupVersion.String(), Kind: "Placement"},
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "default"},
+	}
+	ocmClient := newPlacementTestClient(existing)
+
+	// The user may only read placements
+	var reviews []authorizationv1.ResourceAttributes
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviews = append(reviews, *review.Spec.ResourceAttributes)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Verb == "get"
+		return true, review, nil
+	})
+	ocmClient.KubernetesClient = kubeClient
+
+	tests := []struct {
+		name    string
+		handler func(*gin.Context, *client.OCMClient, context.Context)
+		verb    string
+		body    string
+	}{
+		{name: "create", handler: CreatePlacement, verb: "create", body: `{"name":"p2"}`},
+		{name: "update", handler: UpdatePlacement, verb: "update", body: `{"name":"p"}`},
+		{name: "delete", handler: DeletePlacement, verb: "delete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews = nil
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			c.Params = gin.Params{{Key: "namespace", Value: "default"}, {Key: "name", Value: "p"}}
+			c.Set(UserInfoKey, authv1.UserInfo{Username: "alice"})
+
+			tt.handler(c, ocmClient, context.Background())
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			require.Len(t, reviews, 1)
+			assert.Equal(t, tt.verb, reviews[0].Verb)
+			assert.Equal(t, "placements", reviews[0].Resource)
+			assert.Equal(t, "default", reviews[0].Namespace)
+		})
+	}
+
+	// Nothing was changed on the hub, nor were the bound clustersets read
+	_, err := ocmClient.Interface.Resource(client.PlacementResource).Namespace("default").Get(context.Background(), "p", metav1.GetOptions{})
+	assert.NoError(t, err)
+	for _, action := range ocmClient.ClusterClient.(*clusterfake.Clientset).Actions() {
+		assert.NotEqual(t, "managedclustersetbindings", action.GetResource().Resource)
+	}
+}
+
+func TestMergePlacementSpecListItems(t *testing.T) {
+	predicate := func(label string, unmodeled bool) interface{} {
+		selector := map[string]interface{}{
+			"labelSelector": map[string]interface{}{"matchLabels": map[string]interface{}{label: "true"}},
+		}
+		if unmodeled {
+			selector["futureSelector"] = map[string]interface{}{"key": label}
+		}
+		return map[string]interface{}{"requiredClusterSelector": selector}
+	}
+	newPlacement := func(predicates ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": clusterv1beta1.GroupVersion.String(),
+			"kind":       "Placement",
+			"metadata":   map[string]interface{}{"name": "p", "namespace": "default"},
+			"spec":       map[string]interface{}{"predicates": predicates},
+		}}
+	}
+	futureSelector := func(obj *unstructured.Unstructured, i int) interface{} {
+		predicates, _, _ := unstructured.NestedSlice(obj.Object, "spec", "predicates")
+		selector := predicates[i].(map[string]interface{})["requiredClusterSelector"].(map[string]interface{})
+		return selector["futureSelector"]
+	}
+
+	// Unchanged items keep their unmodeled fields
+	existing := newPlacement(predicate("a", true), predicate("b", false))
+	require.NoError(t, mergePlacementSpec(existing, newPlacement(predicate("a", false), predicate("b", false))))
+	assert.NotNil(t, futureSelector(existing, 0))
+
+	// Removing predicates[0] does not move its unmodeled fields onto predicates[1]
+	existing = newPlacement(predicate("a", true), predicate("b", false))
+	require.NoError(t, mergePlacementSpec(existing, newPlacement(predicate("b", false))))
+	predicates, _, _ := unstructured.NestedSlice(existing.Object, "spec", "predicates")
+	require.Len(t, predicates, 1)
+	assert.Nil(t, futureSelector(existing, 0))
+}
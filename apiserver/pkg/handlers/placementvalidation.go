@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+// Built-in prioritizers supported by the OCM placement scheduler
+var builtInPrioritizers = map[string]bool{
+	"Balance":                   true,
+	"Steady":                    true,
+	"ResourceAllocatableCPU":    true,
+	"ResourceAllocatableMemory": true,
+}
+
+// Taint effects a placement toleration may reference
+var tolerationEffects = map[string]bool{
+	string(clusterv1.TaintEffectNoSelect):       true,
+	string(clusterv1.TaintEffectPreferNoSelect): true,
+	string(clusterv1.TaintEffectNoSelectIfNew):  true,
+}
+
+// placementCELEnv parses placement CEL selectors. The hub compiles them with
+// the OCM cluster library and the Kubernetes CEL extensions, so expressions are
+// only checked for syntax errors here and type checking is left to the hub.
+var placementCELEnv = mustNewPlacementCELEnv()
+
+func mustNewPlacementCELEnv() *cel.Env {
+	env, err := cel.NewEnv()
+	if err != nil {
+		panic(fmt.Sprintf("failed to create placement CEL environment: %v", err))
+	}
+	return env
+}
+
+// validatePlacement checks a placement model before it is translated and sent
+// to the hub, returning one error per invalid field
+func validatePlacement(ctx context.Context, ocmClient *client.OCMClient, namespace string, placement models.Placement) ([]models.FieldError, error) {
+	var errs []models.FieldError
+
+	if placement.Name == "" {
+		errs = append(errs, models.FieldError{Field: "name", Message: "name is required"})
+	} else if msgs := validation.IsDNS1123Subdomain(placement.Name); len(msgs) > 0 {
+		errs = append(errs, models.FieldError{Field: "name", Message: strings.Join(msgs, "; ")})
+	}
+
+	if placement.NumberOfClusters != nil && *placement.NumberOfClusters < 0 {
+		errs = append(errs, models.FieldError{Field: "numberOfClusters", Message: "must be greater than or equal to 0"})
+	}
+
+	// Every referenced clusterset must be bound to the placement namespace
+	if len(placement.ClusterSets) > 0 {
+		bindings, err := ocmClient.ListManagedClusterSetBindings(ctx, namespace, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		bound := make(map[string]bool, len(bindings.Items))
+		for _, binding := range bindings.Items {
+			bound[binding.Spec.ClusterSet] = true
+		}
+
+		for i, clusterSet := range placement.ClusterSets {
+			if !bound[clusterSet] {
+				errs = append(errs, models.FieldError{
+					Field:   fmt.Sprintf("clusterSets[%d]", i),
+					Message: fmt.Sprintf("clusterset %q has no ManagedClusterSetBinding in namespace %q", clusterSet, namespace),
+				})
+			}
+		}
+	}
+
+	for i, predicate := range placement.Predicates {
+		field := fmt.Sprintf("predicates[%d].requiredClusterSelector", i)
+		if predicate.RequiredClusterSelector == nil {
+			continue
+		}
+
+		if selector := predicate.RequiredClusterSelector.LabelSelector; selector != nil {
+			errs = append(errs, validateMatchExpressions(field+".labelSelector", selector.MatchExpressions)...)
+		}
+
+		if selector := predicate.RequiredClusterSelector.ClaimSelector; selector != nil {
+			errs = append(errs, validateMatchExpressions(field+".claimSelector", selector.MatchExpressions)...)
+		}
+
+		if selector := predicate.RequiredClusterSelector.CelSelector; selector != nil {
+			for j, expression := range selector.CelExpressions {
+				if err := validateCELExpression(expression); err != nil {
+					errs = append(errs, models.FieldError{
+						Field:   fmt.Sprintf("%s.celSelector.celExpressions[%d]", field, j),
+						Message: err.Error(),
+					})
+				}
+			}
+		}
+	}
+
+	for i, toleration := range placement.Tolerations {
+		field := fmt.Sprintf("tolerations[%d]", i)
+
+		switch clusterv1beta1.TolerationOperator(toleration.Operator) {
+		case "", clusterv1beta1.TolerationOpEqual:
+			if toleration.Key == "" {
+				errs = append(errs, models.FieldError{Field: field + ".key", Message: "key is required when operator is Equal"})
+			}
+		case clusterv1beta1.TolerationOpExists:
+			if toleration.Value != "" {
+				errs = append(errs, models.FieldError{Field: field + ".value", Message: "value must be empty when operator is Exists"})
+			}
+		default:
+			errs = append(errs, models.FieldError{
+				Field:   field + ".operator",
+				Message: fmt.Sprintf("unknown operator %q, expected Equal or Exists", toleration.Operator),
+			})
+		}
+
+		if toleration.Effect != "" && !tolerationEffects[toleration.Effect] {
+			errs = append(errs, models.FieldError{
+				Field:   field + ".effect",
+				Message: fmt.Sprintf("unknown effect %q, expected NoSelect, PreferNoSelect or NoSelectIfNew", toleration.Effect),
+			})
+		}
+
+		if toleration.TolerationSeconds != nil && *toleration.TolerationSeconds < 0 {
+			errs = append(errs, models.FieldError{Field: field + ".tolerationSeconds", Message: "must be greater than or equal to 0"})
+		}
+	}
+
+	if policy := placement.PrioritizerPolicy; policy != nil {
+		switch clusterv1beta1.PrioritizerPolicyModeType(policy.Mode) {
+		case "", clusterv1beta1.PrioritizerPolicyModeAdditive, clusterv1beta1.PrioritizerPolicyModeExact:
+		default:
+			errs = append(errs, models.FieldError{
+				Field:   "prioritizerPolicy.mode",
+				Message: fmt.Sprintf("unknown mode %q, expected Additive or Exact", policy.Mode),
+			})
+		}
+
+		for i, config := range policy.Configurations {
+			errs = append(errs, validatePrioritizerConfig(fmt.Sprintf("prioritizerPolicy.configurations[%d]", i), config)...)
+		}
+	}
+
+	if strategy := placement.DecisionStrategy; strategy != nil {
+		groupStrategy := strategy.GroupStrategy
+
+		if value := groupStrategy.ClustersPerDecisionGroup; value != "" {
+			if err := validateClustersPerDecisionGroup(value); err != nil {
+				errs = append(errs, models.FieldError{
+					Field:   "decisionStrategy.groupStrategy.clustersPerDecisionGroup",
+					Message: err.Error(),
+				})
+			}
+		}
+
+		groupNames := make(map[string]bool, len(groupStrategy.DecisionGroups))
+		for i, group := range groupStrategy.DecisionGroups {
+			field := fmt.Sprintf("decisionStrategy.groupStrategy.decisionGroups[%d]", i)
+
+			if group.GroupName != "" {
+				if groupNames[group.GroupName] {
+					errs = append(errs, models.FieldError{Field: field + ".groupName", Message: fmt.Sprintf("duplicate group name %q", group.GroupName)})
+				}
+				groupNames[group.GroupName] = true
+			}
+
+			if selector := group.GroupClusterSelector.LabelSelector; selector != nil {
+				errs = append(errs, validateMatchExpressions(field+".groupClusterSelector.labelSelector", selector.MatchExpressions)...)
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// validateMatchExpressions checks the operator and values of selector requirements
+func validateMatchExpressions(field string, expressions []models.MatchExpression) []models.FieldError {
+	var errs []models.FieldError
+
+	for i, expr := range expressions {
+		exprField := fmt.Sprintf("%s.matchExpressions[%d]", field, i)
+
+		if expr.Key == "" {
+			errs = append(errs, models.FieldError{Field: exprField + ".key", Message: "key is required"})
+		}
+
+		switch metav1.LabelSelectorOperator(expr.Operator) {
+		case metav1.LabelSelectorOpIn, metav1.LabelSelectorOpNotIn:
+			if len(expr.Values) == 0 {
+				errs = append(errs, models.FieldError{
+					Field:   exprField + ".values",
+					Message: fmt.Sprintf("values must be non-empty when operator is %s", expr.Operator),
+				})
+			}
+		case metav1.LabelSelectorOpExists, metav1.LabelSelectorOpDoesNotExist:
+			if len(expr.Values) > 0 {
+				errs = append(errs, models.FieldError{
+					Field:   exprField + ".values",
+					Message: fmt.Sprintf("values must be empty when operator is %s", expr.Operator),
+				})
+			}
+		default:
+			errs = append(errs, models.FieldError{
+				Field:   exprField + ".operator",
+				Message: fmt.Sprintf("unknown operator %q, expected In, NotIn, Exists or DoesNotExist", expr.Operator),
+			})
+		}
+	}
+
+	return errs
+}
+
+// validatePrioritizerConfig checks the score coordinate and weight of a prioritizer
+func validatePrioritizerConfig(field string, config models.PrioritizerConfig) []models.FieldError {
+	var errs []models.FieldError
+
+	if config.Weight < -10 || config.Weight > 10 {
+		errs = append(errs, models.FieldError{Field: field + ".weight", Message: "must be between -10 and 10"})
+	}
+
+	coordinate := config.ScoreCoordinate
+	if coordinate == nil {
+		errs = append(errs, models.FieldError{Field: field + ".scoreCoordinate", Message: "scoreCoordinate is required"})
+		return errs
+	}
+
+	switch coordinate.Type {
+	case "", clusterv1beta1.ScoreCoordinateTypeBuiltIn:
+		if !builtInPrioritizers[coordinate.BuiltIn] {
+			errs = append(errs, models.FieldError{
+				Field:   field + ".scoreCoordinate.builtIn",
+				Message: fmt.Sprintf("unknown built-in prioritizer %q", coordinate.BuiltIn),
+			})
+		}
+	case clusterv1beta1.ScoreCoordinateTypeAddOn:
+		if coordinate.AddOn == nil || coordinate.AddOn.ResourceName == "" || coordinate.AddOn.ScoreName == "" {
+			errs = append(errs, models.FieldError{
+				Field:   field + ".scoreCoordinate.addOn",
+				Message: "resourceName and scoreName are required when type is AddOn",
+			})
+		}
+	default:
+		errs = append(errs, models.FieldError{
+			Field:   field + ".scoreCoordinate.type",
+			Message: fmt.Sprintf("unknown type %q, expected BuiltIn or AddOn", coordinate.Type),
+		})
+	}
+
+	return errs
+}
+
+// validateClustersPerDecisionGroup checks the value is a positive integer or percentage
+func validateClustersPerDecisionGroup(value string) error {
+	number := strings.TrimSuffix(value, "%")
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 || (number != value && n > 100) {
+		return fmt.Errorf("must be a positive integer or a percentage between 1%% and 100%%, got %q", value)
+	}
+	return nil
+}
+
+// validateCELExpression parses a placement CEL expression
+func validateCELExpression(expression string) error {
+	if _, issues := placementCELEnv.Parse(expression); issues != nil && issues.Err() != nil {
+		return issues.Err()
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func TestValidatePlacement(t *testing.T) {
+	binding := &clusterv1beta2.ManagedClusterSetBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "default"},
+		Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: "global"},
+	}
+	ocmClient := &client.OCMClient{ClusterClient: clusterfake.NewSimpleClientset(binding)}
+
+	tests := []struct {
+		name           string
+		placement      models.Placement
+		expectedFields []string
+	}{
+		{
+			name: "valid placement",
+			placement: models.Placement{
+				Name:        "valid",
+				ClusterSets: []string{"global"},
+				Predicates: []models.Predicate{
+					{
+						RequiredClusterSelector: &models.RequiredClusterSelector{
+							LabelSelector: &models.LabelSelectorWithExpressions{
+								MatchExpressions: []models.MatchExpression{{Key: "env", Operator: "In", Values: []string{"prod"}}},
+							},
+							CelSelector: &models.CelSelectorWithExpressions{
+								CelExpressions: []string{
+									`managedCluster.metadata.labels["env"] == "prod"`,
+									// Functions of the OCM cluster library and the Kubernetes extensions compiled by the hub
+									`managedCluster.metadata.labels["version"].versionIsGreaterThan("1.30.0")`,
+									`managedCluster.status.version.kubernetes.split(".").size() == 3`,
+								},
+							},
+						},
+					},
+				},
+				Tolerations: []models.PlacementToleration{{Key: "gpu", Operator: "Exists", Effect: "NoSelect"}},
+				PrioritizerPolicy: &models.PrioritizerPolicy{
+					Mode: "Additive",
+					Configurations: []models.PrioritizerConfig{
+						{ScoreCoordinate: &models.ScoreCoordinate{Type: "BuiltIn", BuiltIn: "Steady"}, Weight: 3},
+					},
+				},
+				DecisionStrategy: &models.DecisionStrategy{
+					GroupStrategy: models.GroupStrategy{ClustersPerDecisionGroup: "25%"},
+				},
+			},
+		},
+		{
+			name:           "missing name",
+			placement:      models.Placement{},
+			expectedFields: []string{"name"},
+		},
+		{
+			name:           "unbound clusterset",
+			placement:      models.Placement{Name: "p", ClusterSets: []string{"global", "other"}},
+			expectedFields: []string{"clusterSets[1]"},
+		},
+		{
+			name: "unknown operators",
+			placement: models.Placement{
+				Name: "p",
+				Predicates: []models.Predicate{
+					{
+						RequiredClusterSelector: &models.RequiredClusterSelector{
+							ClaimSelector: &models.ClaimSelectorWithExpressions{
+								MatchExpressions: []models.MatchExpression{{Key: "region", Operator: "Equals", Values: []string{"us"}}},
+							},
+						},
+					},
+				},
+				Tolerations: []models.PlacementToleration{{Key: "gpu", Operator: "Present"}},
+			},
+			expectedFields: []string{
+				"predicates[0].requiredClusterSelector.claimSelector.matchExpressions[0].operator",
+				"tolerations[0].operator",
+			},
+		},
+		{
+			name: "invalid CEL expression",
+			placement: models.Placement{
+				Name: "p",
+				Predicates: []models.Predicate{
+					{
+						RequiredClusterSelector: &models.RequiredClusterSelector{
+							CelSelector: &models.CelSelectorWithExpressions{
+								CelExpressions: []string{"managedCluster.metadata.name ==", `managedCluster.metadata.labels["env"`},
+							},
+						},
+					},
+				},
+			},
+			expectedFields: []string{
+				"predicates[0].requiredClusterSelector.celSelector.celExpressions[0]",
+				"predicates[0].requiredClusterSelector.celSelector.celExpressions[1]",
+			},
+		},
+		{
+			name: "invalid prioritizer and decision strategy",
+			placement: models.Placement{
+				Name: "p",
+				PrioritizerPolicy: &models.PrioritizerPolicy{
+					Mode: "Sum",
+					Configurations: []models.PrioritizerConfig{
+						{ScoreCoordinate: &models.ScoreCoordinate{Type: "BuiltIn", BuiltIn: "Random"}, Weight: 20},
+					},
+				},
+				DecisionStrategy: &models.DecisionStrategy{
+					GroupStrategy: models.GroupStrategy{
+						ClustersPerDecisionGroup: "150%",
+						DecisionGroups: []models.DecisionGroup{
+							{GroupName: "canary"},
+							{GroupName: "canary"},
+						},
+					},
+				},
+			},
+			expectedFields: []string{
+				"prioritizerPolicy.mode",
+				"prioritizerPolicy.configurations[0].weight",
+				"prioritizerPolicy.configurations[0].scoreCoordinate.builtIn",
+				"decisionStrategy.groupStrategy.clustersPerDecisionGroup",
+				"decisionStrategy.groupStrategy.decisionGroups[1].groupName",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := validatePlacement(context.Background(), ocmClient, "default", tt.placement)
+			require.NoError(t, err)
+
+			fields := make([]string, 0, len(errs))
+			for _, fieldErr := range errs {
+				fields = append(fields, fieldErr.Field)
+			}
+			assert.ElementsMatch(t, tt.expectedFields, fields)
+		})
+	}
+}
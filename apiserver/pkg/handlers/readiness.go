@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// readinessTimeout bounds the hub calls made by a readiness probe
+const readinessTimeout = 5 * time.Second
+
+// serviceAccountNamespaceFile holds the namespace of the pod when running in-cluster
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// GetReadiness handles the readiness probe. It checks that the hub API server is
+// reachable, the given informers have synced, every resource in client.Resources
+// is served and the dashboard is allowed to list it. Resources of optional
+// features the hub does not provide pass as disabled. Individual checks are
+// listed with ?verbose, or whenever a check fails.
+func GetReadiness(c *gin.Context, ocmClient *client.OCMClient, informers map[string]cache.InformerSynced, ctx context.Context) {
+	_, verbose := c.GetQuery("verbose")
+
+	var checks []models.ReadinessCheck
+	if ocmClient == nil || ocmClient.KubernetesClient == nil {
+		checks = []models.ReadinessCheck{failedCheck("hub", "OCM client not initialized")}
+	} else {
+		ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+		defer cancel()
+		checks = runReadinessChecks(ctx, ocmClient, informers)
+	}
+
+	readiness := models.Readiness{Status: models.ReadinessOK}
+	for _, check := range checks {
+		if check.Status != models.ReadinessOK {
+			readiness.Status = models.ReadinessFailed
+			break
+		}
+	}
+
+	if verbose || readiness.Status != models.ReadinessOK {
+		readiness.Checks = checks
+	}
+
+	status := http.StatusOK
+	if readiness.Status != models.ReadinessOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, readiness)
+}
+
+// runReadinessChecks runs the hub, CRD and RBAC checks concurrently and adds the
+// informer sync state. Checks still running when the context expires fail.
+func runReadinessChecks(ctx context.Context, ocmClient *client.OCMClient, informers map[string]cache.InformerSynced) []models.ReadinessCheck {
+	checkers := []struct {
+		name  string
+		check func() []models.ReadinessCheck
+	}{
+		{name: "hub", check: func() []models.ReadinessCheck {
+			return []models.ReadinessCheck{checkHubVersion(ctx, ocmClient)}
+		}},
+		{name: "crd", check: func() []models.ReadinessCheck {
+			return checkResourcesServed(ctx, ocmClient, client.Resources)
+		}},
+		{name: "rbac", check: func() []models.ReadinessCheck {
+			return checkResourcesListable(ctx, ocmClient, client.Resources)
+		}},
+	}
+
+	type checkResult struct {
+		index  int
+		checks []models.ReadinessCheck
+	}
+	results := make(chan checkResult, len(checkers))
+	for i, checker := range checkers {
+		go func(i int, check func() []models.ReadinessCheck) {
+			results <- checkResult{index: i, checks: check()}
+		}(i, checker.check)
+	}
+
+	collected := make([][]models.ReadinessCheck, len(checkers))
+	for range checkers {
+		select {
+		case result := <-results:
+			collected[result.index] = result.checks
+		case <-ctx.Done():
+		}
+	}
+	for i, checker := range checkers {
+		if collected[i] == nil {
+			collected[i] = []models.ReadinessCheck{failedCheck(checker.name, "timed out")}
+		}
+	}
+
+	checks := collected[0]
+	checks = append(checks, checkInformersSynced(informers)...)
+	checks = append(checks, collected[1]...)
+	return append(checks, collected[2]...)
+}
+
+// checkHubVersion checks the hub API server is reachable by reading its version
+func checkHubVersion(ctx context.Context, ocmClient *client.OCMClient) models.ReadinessCheck {
+	version, err := hubVersion(ctx, ocmClient)
+	if err != nil {
+		return failedCheck("hub", fmt.Sprintf("hub API server unreachable: %v", err))
+	}
+	return okCheck("hub", version)
+}
+
+// checkInformersSynced checks every informer has completed its initial list
+func checkInformersSynced(informers map[string]cache.InformerSynced) []models.ReadinessCheck {
+	names := make([]string, 0, len(informers))
+	for name := range informers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checks := make([]models.ReadinessCheck, 0, len(names))
+	for _, name := range names {
+		checkName := "informer/" + name
+		if informers[name]() {
+			checks = append(checks, okCheck(checkName, "synced"))
+		} else {
+			checks = append(checks, failedCheck(checkName, "cache not synced"))
+		}
+	}
+	return checks
+}
+
+// checkResourcesServed checks through discovery that the CRD of every resource
+// is installed and serves the version selected for the hub
+func checkResourcesServed(ctx context.Context, ocmClient *client.OCMClient, resources []schema.GroupVersionResource) []models.ReadinessCheck {
+	selected := make([]schema.GroupVersionResource, 0, len(resources))
+	for _, gvr := range resources {
+		selected = append(selected, ocmClient.SelectedResource(gvr))
+	}
+	resources = selected
+
+	served := make(map[string]map[string]bool)
+	errs := make(map[string]error)
+	for _, gvr := range resources {
+		groupVersion := gvr.GroupVersion().String()
+		if _, ok := served[groupVersion]; ok || errs[groupVersion] != nil {
+			continue
+		}
+
+		list, err := serverResources(ctx, ocmClient, gvr.GroupVersion())
+		if err != nil {
+			errs[groupVersion] = err
+			continue
+		}
+		served[groupVersion] = make(map[string]bool, len(list.APIResources))
+		for _, resource := range list.APIResources {
+			served[groupVersion][resource.Name] = true
+		}
+	}
+
+	checks := make([]models.ReadinessCheck, 0, len(resources))
+	for _, gvr := range resources {
+		name := "crd/" + gvr.GroupResource().String()
+		groupVersion := gvr.GroupVersion().String()
+		feature, disabled := disabledFeature(ocmClient, gvr)
+		switch {
+		case disabled:
+			checks = append(checks, okCheck(name, fmt.Sprintf("not served, %s disabled", feature)))
+		case errs[groupVersion] != nil:
+			checks = append(checks, failedCheck(name, fmt.Sprintf("%s not served: %v", groupVersion, errs[groupVersion])))
+		case !served[groupVersion][gvr.Resource]:
+			checks = append(checks, failedCheck(name, fmt.Sprintf("%s not served in %s", gvr.Resource, groupVersion)))
+		default:
+			checks = append(checks, okCheck(name, groupVersion))
+		}
+	}
+	return checks
+}
+
+// serverResources lists the resources served in a group version, giving up
+// when the context expires. Like hubVersion, it reads through the REST client of
+// discovery, which fake clientsets do not have.
+func serverResources(ctx context.Context, ocmClient *client.OCMClient, groupVersion schema.GroupVersion) (*metav1.APIResourceList, error) {
+	discovery := ocmClient.KubernetesClient.Discovery()
+	restClient := discovery.RESTClient()
+	if restClient == nil {
+		return discovery.ServerResourcesForGroupVersion(groupVersion.String())
+	}
+
+	path := "/apis/" + groupVersion.String()
+	if groupVersion.Group == "" {
+		path = "/api/" + groupVersion.Version
+	}
+	body, err := restClient.Get().AbsPath(path).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	list := &metav1.APIResourceList{}
+	if err := json.Unmarshal(body, list); err != nil {
+		return nil, fmt.Errorf("unable to parse the resources of %s: %w", groupVersion, err)
+	}
+	return list, nil
+}
+
+// checkResourcesListable checks with a SelfSubjectRulesReview that the dashboard
+// service account may list every resource
+func checkResourcesListable(ctx context.Context, ocmClient *client.OCMClient, resources []schema.GroupVersionResource) []models.ReadinessCheck {
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: DashboardNamespace()},
+	}
+	result, err := ocmClient.KubernetesClient.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+
+	checks := make([]models.ReadinessCheck, 0, len(resources))
+	for _, gvr := range resources {
+		name := "rbac/" + gvr.GroupResource().String()
+		feature, disabled := disabledFeature(ocmClient, gvr)
+		switch {
+		case disabled:
+			checks = append(checks, okCheck(name, fmt.Sprintf("%s disabled", feature)))
+		case err != nil:
+			checks = append(checks, failedCheck(name, fmt.Sprintf("SelfSubjectRulesReview failed: %v", err)))
+		case rulesAllow(result.Status.ResourceRules, gvr.Group, gvr.Resource, "list"):
+			checks = append(checks, okCheck(name, "list allowed"))
+		case result.Status.Incomplete:
+			checks = append(checks, failedCheck(name, fmt.Sprintf("list not allowed, rules incomplete: %s", result.Status.EvaluationError)))
+		default:
+			checks = append(checks, failedCheck(name, "list not allowed"))
+		}
+	}
+	return checks
+}
+
+// disabledFeature returns the feature of the resource if it is optional and
+// unavailable on the hub, in which case its routes answer 501 and the resource
+// does not affect readiness
+func disabledFeature(ocmClient *client.OCMClient, gvr schema.GroupVersionResource) (string, bool) {
+	if ocmClient.Capabilities == nil {
+		return "", false
+	}
+	feature := client.FeatureOf(gvr.GroupResource())
+	if !client.OptionalFeature(feature) {
+		return "", false
+	}
+	available, _ := ocmClient.Capabilities.FeatureAvailable(feature)
+	return feature, !available
+}
+
+// rulesAllow reports whether any rule grants the verb on all objects of the resource
+func rulesAllow(rules []authorizationv1.ResourceRule, group, resource, verb string) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+		if matchesRule(rule.Verbs, verb) && matchesRule(rule.APIGroups, group) && matchesRule(rule.Resources, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRule(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// DashboardNamespace returns the namespace the dashboard runs in, used to scope
+// the SelfSubjectRulesReview and to store follow mode state. Cluster-wide rules
+// apply in any namespace.
+func DashboardNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		if namespace := strings.TrimSpace(string(data)); namespace != "" {
+			return namespace
+		}
+	}
+	return "default"
+}
+
+func okCheck(name, message string) models.ReadinessCheck {
+	return models.ReadinessCheck{Name: name, Status: models.ReadinessOK, Message: message}
+}
+
+func failedCheck(name, message string) models.ReadinessCheck {
+	return models.ReadinessCheck{Name: name, Status: models.ReadinessFailed, Message: message}
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// newTestServedResources lists every resource in client.Resources for fake discovery
+func newTestServedResources() []*metav1.APIResourceList {
+	lists := make(map[string]*metav1.APIResourceList)
+	var result []*metav1.APIResourceList
+	for _, gvr := range client.Resources {
+		groupVersion := gvr.GroupVersion().String()
+		list, ok := lists[groupVersion]
+		if !ok {
+			list = &metav1.APIResourceList{GroupVersion: groupVersion}
+			lists[groupVersion] = list
+			result = append(result, list)
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: gvr.Resource})
+	}
+	return result
+}
+
+func TestGetReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	allowAll := []authorizationv1.ResourceRule{
+		{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"cluster.open-cluster-management.io", "work.open-cluster-management.io"}, Resources: []string{"*"}},
+		{Verbs: []string{"*"}, APIGroups: []string{"addon.open-cluster-management.io"}, Resources: []string{"*"}},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		versionErr     error
+		served         func([]*metav1.APIResourceList) []*metav1.APIResourceList
+		capabilities   bool
+		rules          []authorizationv1.ResourceRule
+		informerSynced bool
+		expectedStatus int
+		expectedChecks bool
+		expectedFailed map[string]string
+	}{
+		{
+			name:           "ready",
+			rules:          allowAll,
+			informerSynced: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "ready verbose",
+			query:          "?verbose",
+			rules:          allowAll,
+			informerSynced: true,
+			expectedStatus: http.StatusOK,
+			expectedChecks: true,
+		},
+		{
+			name:           "hub unreachable",
+			versionErr:     errors.New("connection refused"),
+			rules:          allowAll,
+			informerSynced: true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: true,
+			expectedFailed: map[string]string{"hub": "hub API server unreachable: connection refused"},
+		},
+		{
+			name:           "informer not synced",
+			rules:          allowAll,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: true,
+			expectedFailed: map[string]string{"informer/managedclusters": "cache not synced"},
+		},
+		{
+			name: "addon framework not installed",
+			served: func(lists []*metav1.APIResourceList) []*metav1.APIResourceList {
+				var result []*metav1.APIResourceList
+				for _, list := range lists {
+					if list.GroupVersion != "addon.open-cluster-management.io/v1alpha1" {
+						result = append(result, list)
+					}
+				}
+				return result
+			},
+			rules:          allowAll,
+			informerSynced: true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: true,
+			expectedFailed: map[string]string{
+				"crd/managedclusteraddons.addon.open-cluster-management.io":    "",
+				"crd/clustermanagementaddons.addon.open-cluster-management.io": "",
+				"crd/addondeploymentconfigs.addon.open-cluster-management.io":  "",
+				"crd/addontemplates.addon.open-cluster-management.io":          "",
+			},
+		},
+		{
+			name:  "addon framework not installed with capabilities",
+			query: "?verbose",
+			served: func(lists []*metav1.APIResourceList) []*metav1.APIResourceList {
+				var result []*metav1.APIResourceList
+				for _, list := range lists {
+					if list.GroupVersion != "addon.open-cluster-management.io/v1alpha1" {
+						result = append(result, list)
+					}
+				}
+				return result
+			},
+			capabilities:   true,
+			rules:          allowAll[:1],
+			informerSynced: true,
+			expectedStatus: http.StatusOK,
+			expectedChecks: true,
+		},
+		{
+			name: "missing list permission",
+			rules: []authorizationv1.ResourceRule{
+				allowAll[0],
+				{Verbs: []string{"list"}, APIGroups: []string{"addon.open-cluster-management.io"}, Resources: []string{"managedclusteraddons", "clustermanagementaddons", "addontemplates"}},
+				{Verbs: []string{"list"}, APIGroups: []string{"addon.open-cluster-management.io"}, Resources: []string{"addondeploymentconfigs"}, ResourceNames: []string{"default"}},
+			},
+			informerSynced: true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: true,
+			expectedFailed: map[string]string{
+				"rbac/addondeploymentconfigs.addon.open-cluster-management.io": "list not allowed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+			discovery.FakedServerVersion = &version.Info{GitVersion: "v1.30.0"}
+			discovery.Resources = newTestServedResources()
+			if tt.served != nil {
+				discovery.Resources = tt.served(discovery.Resources)
+			}
+			if tt.versionErr != nil {
+				kubeClient.PrependReactor("get", "version", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.versionErr
+				})
+			}
+			kubeClient.PrependReactor("create", "selfsubjectrulesreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview)
+				review.Status.ResourceRules = tt.rules
+				return true, review, nil
+			})
+
+			informers := map[string]cache.InformerSynced{
+				"managedclusters": func() bool { return tt.informerSynced },
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/readyz"+tt.query, nil)
+
+			ocmClient := &client.OCMClient{KubernetesClient: kubeClient}
+			if tt.capabilities {
+				ocmClient.Capabilities = client.NewCapabilities(discovery)
+				require.NoError(t, ocmClient.Capabilities.Detect())
+			}
+
+			GetReadiness(c, ocmClient, informers, context.Background())
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var readiness models.Readiness
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &readiness))
+			if !tt.expectedChecks {
+				assert.Equal(t, models.ReadinessOK, readiness.Status)
+				assert.Empty(t, readiness.Checks)
+				return
+			}
+
+			// hub, informer, one crd and one rbac check per resource
+			assert.Len(t, readiness.Checks, 2+2*len(client.Resources))
+			failed := make(map[string]string)
+			for _, check := range readiness.Checks {
+				if check.Status != models.ReadinessOK {
+					failed[check.Name] = check.Message
+				}
+			}
+			assert.Len(t, failed, len(tt.expectedFailed))
+			for name, message := range tt.expectedFailed {
+				require.Contains(t, failed, name)
+				if message != "" {
+					assert.Equal(t, message, failed[name])
+				}
+			}
+			if len(tt.expectedFailed) == 0 {
+				assert.Equal(t, models.ReadinessOK, readiness.Status)
+				assert.Equal(t, "v1.30.0", readiness.Checks[0].Message)
+			} else {
+				assert.Equal(t, models.ReadinessFailed, readiness.Status)
+			}
+		})
+	}
+
+	t.Run("client not initialized", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+		GetReadiness(c, nil, nil, context.Background())
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestReadinessChecksHonorContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	ocmClient := &client.OCMClient{KubernetesClient: kubeClient}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The checks return once the context expires instead of waiting on the hub
+	assert.Equal(t, models.ReadinessFailed, checkHubVersion(ctx, ocmClient).Status)
+	for _, check := range checkResourcesServed(ctx, ocmClient, client.Resources) {
+		assert.Equal(t, models.ReadinessFailed, check.Status, check.Name)
+	}
+	_, err = serverResources(ctx, ocmClient, client.Resources[0].GroupVersion())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// Rollout states of a cluster, decision group, placement or the whole ManifestWorkReplicaSet
+const (
+	rolloutSucceeded   = "Succeeded"
+	rolloutProgressing = "Progressing"
+	rolloutFailed      = "Failed"
+	rolloutTimedOut    = "TimedOut"
+	rolloutPending     = "Pending"
+)
+
+// rolloutGroup holds the clusters selected by one placement decision group
+type rolloutGroup struct {
+	index    int32
+	name     string
+	clusters []string
+}
+
+// GetManifestWorkReplicaSetRollout handles retrieving the rollout progress of a ManifestWorkReplicaSet
+// per placement decision group, combined with the conditions of the ManifestWork on each cluster
+func GetManifestWorkReplicaSetRollout(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context) {
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	// Ensure we have a client before proceeding
+	if ocmClient == nil || ocmClient.ClusterClient == nil || ocmClient.WorkClient == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	replicaSet, err := ocmClient.WorkClient.WorkV1alpha1().ManifestWorkReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	children, err := listManifestWorkReplicaSetChildren(ctx, ocmClient, namespace, name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The generated ManifestWorks live in the namespace of their cluster
+	works := make(map[string]*workv1.ManifestWork, len(children))
+	for i := range children {
+		works[children[i].Namespace] = &children[i]
+	}
+
+	progress := models.RolloutProgress{
+		Name:       name,
+		Namespace:  namespace,
+		Placements: []models.PlacementRollout{},
+	}
+
+	now := time.Now()
+	for _, ref := range replicaSet.Spec.PlacementRefs {
+		strategy := convertRolloutStrategyToModel(ref.RolloutStrategy)
+
+		groups, err := listRolloutGroups(ctx, ocmClient, namespace, ref.Name)
+		if err != nil {
+			progress.Placements = append(progress.Placements, models.PlacementRollout{
+				PlacementName:   ref.Name,
+				RolloutStrategy: strategy,
+				Phase:           rolloutPending,
+				Groups:          []models.DecisionGroupRollout{},
+				Error:           err.Error(),
+			})
+			continue
+		}
+
+		placementRollout := buildPlacementRollout(ref.Name, strategy, groups, works, now)
+		addRolloutCounts(&progress.Counts, placementRollout.Counts)
+		progress.Placements = append(progress.Placements, placementRollout)
+	}
+
+	progress.Phase = rolloutPhase(progress.Counts)
+	estimateRolloutCompletion(&progress, children, now)
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// listRolloutGroups resolves the clusters of each decision group of a placement. The placement
+// status lists the PlacementDecisions of each group; when it is missing the group labels are used.
+func listRolloutGroups(ctx context.Context, ocmClient *client.OCMClient, namespace, placementName string) ([]rolloutGroup, error) {
+	placement, err := ocmClient.ClusterClient.ClusterV1beta1().Placements(namespace).Get(ctx, placementName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", clusterv1beta1.PlacementLabel, placementName),
+	}
+	pdList, err := ocmClient.ClusterClient.ClusterV1beta1().PlacementDecisions(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	placementDecisions := make([]models.PlacementDecision, 0, len(pdList.Items))
+	decisionClusters := make(map[string][]string, len(pdList.Items))
+	for _, pd := range pdList.Items {
+		placementDecision := convertPlacementDecisionToModel(&pd)
+		placementDecisions = append(placementDecisions, placementDecision)
+		for _, decision := range placementDecision.Decisions {
+			decisionClusters[pd.Name] = append(decisionClusters[pd.Name], decision.ClusterName)
+		}
+	}
+
+	var groups []rolloutGroup
+	if decisionGroups := convertPlacementToModel(*placement).DecisionGroups; len(decisionGroups) > 0 {
+		for _, group := range decisionGroups {
+			rg := rolloutGroup{index: group.DecisionGroupIndex, name: group.DecisionGroupName}
+			for _, decisionName := range group.Decisions {
+				rg.clusters = append(rg.clusters, decisionClusters[decisionName]...)
+			}
+			groups = append(groups, rg)
+		}
+	} else {
+		for _, group := range mergePlacementDecisions(namespace, placementName, placementDecisions).Groups {
+			rg := rolloutGroup{index: group.DecisionGroupIndex, name: group.DecisionGroupName}
+			for _, decision := range group.Decisions {
+				rg.clusters = append(rg.clusters, decision.ClusterName)
+			}
+			groups = append(groups, rg)
+		}
+	}
+
+	for i := range groups {
+		sort.Strings(groups[i].clusters)
+	}
+
+	return groups, nil
+}
+
+// buildPlacementRollout classifies every cluster of every decision group of a placement
+func buildPlacementRollout(placementName string, strategy models.RolloutStrategy, groups []rolloutGroup, works map[string]*workv1.ManifestWork, now time.Time) models.PlacementRollout {
+	placementRollout := models.PlacementRollout{
+		PlacementName:   placementName,
+		RolloutStrategy: strategy,
+		Groups:          []models.DecisionGroupRollout{},
+	}
+
+	deadline := parseProgressDeadline(strategy.ProgressDeadline)
+	for _, group := range groups {
+		groupRollout := models.DecisionGroupRollout{
+			DecisionGroupIndex: group.index,
+			DecisionGroupName:  group.name,
+			Clusters:           []models.ClusterRolloutStatus{},
+		}
+
+		for _, cluster := range group.clusters {
+			status := clusterRolloutStatus(cluster, works[cluster], deadline, now)
+			countRolloutStatus(&groupRollout.Counts, status.Status)
+			groupRollout.Clusters = append(groupRollout.Clusters, status)
+		}
+
+		groupRollout.Phase = rolloutPhase(groupRollout.Counts)
+		addRolloutCounts(&placementRollout.Counts, groupRollout.Counts)
+		placementRollout.Groups = append(placementRollout.Groups, groupRollout)
+	}
+
+	placementRollout.Phase = rolloutPhase(placementRollout.Counts)
+
+	return placementRollout
+}
+
+// clusterRolloutStatus derives the rollout state of a cluster from the conditions of its ManifestWork.
+// A cluster without a ManifestWork has not been rolled out to yet.
+func clusterRolloutStatus(cluster string, work *workv1.ManifestWork, deadline time.Duration, now time.Time) models.ClusterRolloutStatus {
+	status := models.ClusterRolloutStatus{ClusterName: cluster, Status: rolloutPending}
+	if work == nil {
+		return status
+	}
+
+	conditions := work.Status.Conditions
+	degraded := meta.FindStatusCondition(conditions, workv1.WorkDegraded)
+	applied := meta.FindStatusCondition(conditions, workv1.WorkApplied)
+	progressing := meta.FindStatusCondition(conditions, workv1.WorkProgressing)
+	available := meta.FindStatusCondition(conditions, workv1.WorkAvailable)
+
+	var reported *metav1.Condition
+	switch {
+	case degraded != nil && degraded.Status == metav1.ConditionTrue:
+		status.Status, reported = rolloutFailed, degraded
+	case applied != nil && applied.Status == metav1.ConditionFalse:
+		status.Status, reported = rolloutFailed, applied
+	case available != nil && available.Status == metav1.ConditionTrue && (progressing == nil || progressing.Status != metav1.ConditionTrue):
+		status.Status, reported = rolloutSucceeded, available
+	default:
+		status.Status, reported = rolloutProgressing, progressing
+
+		// The deadline counts from when the work started progressing
+		started := work.CreationTimestamp.Time
+		if progressing != nil {
+			started = progressing.LastTransitionTime.Time
+		}
+		if deadline > 0 && !started.IsZero() && now.Sub(started) > deadline {
+			status.Status = rolloutTimedOut
+		}
+	}
+
+	if reported != nil {
+		status.LastTransitionTime = reported.LastTransitionTime.Format(time.RFC3339)
+		status.Message = reported.Message
+	}
+
+	return status
+}
+
+// parseProgressDeadline returns the progress deadline of a rollout, zero means no deadline
+func parseProgressDeadline(value string) time.Duration {
+	if value == "" || value == "None" {
+		return 0
+	}
+	deadline, err := time.ParseDuration(value)
+	if err != nil {
+		return 0
+	}
+	return deadline
+}
+
+// rolloutPhase derives the phase of a group of clusters from their rollout counts
+func rolloutPhase(counts models.RolloutCounts) string {
+	switch {
+	case counts.Total > 0 && counts.Succeeded == counts.Total:
+		return rolloutSucceeded
+	case counts.Progressing > 0:
+		return rolloutProgressing
+	case counts.Failed+counts.TimedOut > 0:
+		return rolloutFailed
+	case counts.Pending == counts.Total:
+		return rolloutPending
+	default:
+		// Some clusters succeeded and the rest wait for the next step of the rollout
+		return rolloutProgressing
+	}
+}
+
+func countRolloutStatus(counts *models.RolloutCounts, status string) {
+	counts.Total++
+	switch status {
+	case rolloutSucceeded:
+		counts.Succeeded++
+	case rolloutProgressing:
+		counts.Progressing++
+	case rolloutFailed:
+		counts.Failed++
+	case rolloutTimedOut:
+		counts.TimedOut++
+	default:
+		counts.Pending++
+	}
+}
+
+func addRolloutCounts(total *models.RolloutCounts, counts models.RolloutCounts) {
+	total.Total += counts.Total
+	total.Succeeded += counts.Succeeded
+	total.Progressing += counts.Progressing
+	total.Failed += counts.Failed
+	total.TimedOut += counts.TimedOut
+	total.Pending += counts.Pending
+}
+
+// estimateRolloutCompletion sets the start time of the rollout and, while it is progressing,
+// extrapolates the completion time from the rate at which clusters have finished so far
+func estimateRolloutCompletion(progress *models.RolloutProgress, works []workv1.ManifestWork, now time.Time) {
+	var start time.Time
+	for _, work := range works {
+		created := work.CreationTimestamp.Time
+		if !created.IsZero() && (start.IsZero() || created.Before(start)) {
+			start = created
+		}
+	}
+	if start.IsZero() {
+		return
+	}
+	progress.StartTime = start.Format(time.RFC3339)
+
+	counts := progress.Counts
+	finished := counts.Succeeded + counts.Failed + counts.TimedOut
+	if progress.Phase != rolloutProgressing || finished == 0 || !now.After(start) {
+		return
+	}
+
+	perCluster := now.Sub(start) / time.Duration(finished)
+	remaining := time.Duration(counts.Total - finished)
+	progress.EstimatedCompletion = now.Add(perCluster * remaining).Format(time.RFC3339)
+}
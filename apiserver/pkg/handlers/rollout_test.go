@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func TestGetManifestWorkReplicaSetRollout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	strategy := clusterv1alpha1.RolloutStrategy{
+		Type: clusterv1alpha1.ProgressivePerGroup,
+		ProgressivePerGroup: &clusterv1alpha1.RolloutProgressivePerGroup{
+			RolloutConfig: clusterv1alpha1.RolloutConfig{ProgressDeadline: "10m"},
+		},
+	}
+
+	placement := &clusterv1beta1.Placement{
+		ObjectMeta: metav1.ObjectMeta{Name: "placement", Namespace: "default"},
+		Status: clusterv1beta1.PlacementStatus{
+			DecisionGroups: []clusterv1beta1.DecisionGroupStatus{
+				{DecisionGroupIndex: 0, DecisionGroupName: "canary", Decisions: []string{"placement-decision-1"}, ClustersCount: 1},
+				{DecisionGroupIndex: 1, Decisions: []string{"placement-decision-2"}, ClustersCount: 3},
+			},
+		},
+	}
+
+	newClient := func() *client.OCMClient {
+		timedOut := newTestChildManifestWork("cluster2", "default", "app",
+			metav1.Condition{Type: workv1.WorkApplied, Status: metav1.ConditionTrue},
+			metav1.Condition{Type: workv1.WorkProgressing, Status: metav1.ConditionTrue, LastTransitionTime: longAgo},
+		)
+		timedOut.CreationTimestamp = longAgo
+
+		return &client.OCMClient{
+			ClusterClient: clusterfake.NewSimpleClientset(
+				placement,
+				newTestPlacementDecision("default", "placement-decision-1", "placement", "0", "canary", "cluster1"),
+				newTestPlacementDecision("default", "placement-decision-2", "placement", "1", "", "cluster2", "cluster3", "cluster4"),
+			),
+			WorkClient: workfake.NewSimpleClientset(
+				newTestManifestWorkReplicaSet("default", "app", strategy),
+				newTestChildManifestWork("cluster1", "default", "app",
+					metav1.Condition{Type: workv1.WorkApplied, Status: metav1.ConditionTrue},
+					metav1.Condition{Type: workv1.WorkAvailable, Status: metav1.ConditionTrue},
+				),
+				timedOut,
+				newTestChildManifestWork("cluster4", "default", "app",
+					metav1.Condition{Type: workv1.WorkDegraded, Status: metav1.ConditionTrue, Message: "image pull failed"},
+				),
+			),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		client         *client.OCMClient
+		replicaSetName string
+		expectedStatus int
+	}{
+		{
+			name:           "nil client",
+			client:         nil,
+			replicaSetName: "app",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "not found",
+			client:         newClient(),
+			replicaSetName: "missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "progress per decision group",
+			client:         newClient(),
+			replicaSetName: "app",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{
+				{Key: "namespace", Value: "default"},
+				{Key: "name", Value: tt.replicaSetName},
+			}
+
+			GetManifestWorkReplicaSetRollout(c, tt.client, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var progress models.RolloutProgress
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &progress))
+			assert.Equal(t, "Failed", progress.Phase)
+			assert.Equal(t, models.RolloutCounts{Total: 4, Succeeded: 1, Failed: 1, TimedOut: 1, Pending: 1}, progress.Counts)
+			assert.NotEmpty(t, progress.StartTime)
+			assert.Empty(t, progress.EstimatedCompletion)
+
+			require.Len(t, progress.Placements, 1)
+			placementRollout := progress.Placements[0]
+			assert.Equal(t, "ProgressivePerGroup", placementRollout.RolloutStrategy.Type)
+			require.Len(t, placementRollout.Groups, 2)
+
+			canary := placementRollout.Groups[0]
+			assert.Equal(t, "canary", canary.DecisionGroupName)
+			assert.Equal(t, "Succeeded", canary.Phase)
+
+			second := placementRollout.Groups[1]
+			assert.Equal(t, "Failed", second.Phase)
+			require.Len(t, second.Clusters, 3)
+			assert.Equal(t, "TimedOut", second.Clusters[0].Status)
+			assert.Equal(t, "Pending", second.Clusters[1].Status)
+			assert.Equal(t, "Failed", second.Clusters[2].Status)
+			assert.Equal(t, "image pull failed", second.Clusters[2].Message)
+		})
+	}
+}
+
+func TestRolloutPhase(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   models.RolloutCounts
+		expected string
+	}{
+		{name: "all succeeded", counts: models.RolloutCounts{Total: 2, Succeeded: 2}, expected: "Succeeded"},
+		{name: "progressing", counts: models.RolloutCounts{Total: 2, Progressing: 1, Failed: 1}, expected: "Progressing"},
+		{name: "failed", counts: models.RolloutCounts{Total: 2, Succeeded: 1, TimedOut: 1}, expected: "Failed"},
+		{name: "not started", counts: models.RolloutCounts{Total: 2, Pending: 2}, expected: "Pending"},
+		{name: "waiting for next group", counts: models.RolloutCounts{Total: 2, Succeeded: 1, Pending: 1}, expected: "Progressing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rolloutPhase(tt.counts))
+		})
+	}
+}
+
+func TestEstimateRolloutCompletion(t *testing.T) {
+	now := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	works := []workv1.ManifestWork{
+		{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-20 * time.Minute))}},
+		{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Minute))}},
+	}
+
+	progress := models.RolloutProgress{
+		Phase:  "Progressing",
+		Counts: models.RolloutCounts{Total: 4, Succeeded: 2, Progressing: 1, Pending: 1},
+	}
+	estimateRolloutCompletion(&progress, works, now)
+
+	// Two clusters finished in 20 minutes, so the remaining two take another 20 minutes
+	assert.Equal(t, "2024-01-01T00:40:00Z", progress.StartTime)
+	assert.Equal(t, "2024-01-01T01:20:00Z", progress.EstimatedCompletion)
+}
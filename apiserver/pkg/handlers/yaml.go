@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+// wantsYAML checks whether the request asks for YAML, through ?format=yaml or the Accept header
+func wantsYAML(c *gin.Context) bool {
+	if c.Request == nil {
+		return false
+	}
+	if c.Query("format") == "yaml" {
+		return true
+	}
+	accept := c.GetHeader("Accept")
+	return strings.Contains(accept, "application/yaml") || strings.Contains(accept, "application/x-yaml") || strings.Contains(accept, "text/yaml")
+}
+
+// respondYAML writes the original Kubernetes object as YAML, without managedFields. The dynamic
+// client is used so that fields unknown to the typed clients are preserved.
+func respondYAML(c *gin.Context, ocmClient *client.OCMClient, ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) {
+	if ocmClient.Interface == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "OCM client not initialized"})
+		return
+	}
+
+	obj, err := ocmClient.Interface.Resource(ocmClient.SelectedResource(gvr)).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+	obj.SetManagedFields(nil)
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/yaml; charset=utf-8", data)
+}
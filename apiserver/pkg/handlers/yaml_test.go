@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+func TestWantsYAML(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		url      string
+		accept   string
+		expected bool
+	}{
+		{name: "default", url: "/api/clusters/cluster1", expected: false},
+		{name: "json accept", url: "/api/clusters/cluster1", accept: "application/json", expected: false},
+		{name: "format query", url: "/api/clusters/cluster1?format=yaml", expected: true},
+		{name: "yaml accept", url: "/api/clusters/cluster1", accept: "application/yaml", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+
+			assert.Equal(t, tt.expected, wantsYAML(c))
+		})
+	}
+}
+
+func TestGetClusterYAML(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cluster := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cluster.open-cluster-management.io/v1",
+		"kind":       "ManagedCluster",
+		"metadata": map[string]interface{}{
+			"name": "cluster1",
+			"managedFields": []interface{}{
+				map[string]interface{}{"manager": "registration", "operation": "Update"},
+			},
+		},
+		"spec": map[string]interface{}{"hubAcceptsClient": true},
+	}}
+	ocmClient := &client.OCMClient{Interface: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), cluster)}
+
+	tests := []struct {
+		name           string
+		clusterName    string
+		expectedStatus int
+	}{
+		{name: "not found", clusterName: "missing", expectedStatus: http.StatusNotFound},
+		{name: "original object", clusterName: "cluster1", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/clusters/"+tt.clusterName, nil)
+			c.Request.Header.Set("Accept", "application/yaml")
+			c.Params = gin.Params{{Key: "name", Value: tt.clusterName}}
+
+			GetCluster(c, ocmClient, context.Background())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/yaml")
+
+			var obj map[string]interface{}
+			require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &obj))
+			assert.Equal(t, "ManagedCluster", obj["kind"])
+			assert.Equal(t, true, obj["spec"].(map[string]interface{})["hubAcceptsClient"])
+			assert.NotContains(t, obj["metadata"], "managedFields")
+		})
+	}
+}
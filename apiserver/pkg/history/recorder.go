@@ -0,0 +1,122 @@
+package history
+
+import (
+	"log/slog"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	clusterlisterv1beta1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1beta1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+// Recorder watches PlacementDecisions and records every change in the set of
+// decided clusters per placement. The history of a placement is removed with it.
+type Recorder struct {
+	store            *Store
+	lister           clusterlisterv1beta1.PlacementDecisionLister
+	placements       clusterlisterv1beta1.PlacementLister
+	placementsSynced cache.InformerSynced
+}
+
+// NewRecorder registers PlacementDecision and Placement event handlers on the
+// OCM cluster informer factory. Changes are recorded once the caller starts the factory.
+func NewRecorder(ocmClient *client.OCMClient, store *Store) (*Recorder, error) {
+	informer := ocmClient.ClusterInformerFactory.Cluster().V1beta1().PlacementDecisions()
+	placements := ocmClient.ClusterInformerFactory.Cluster().V1beta1().Placements()
+
+	r := &Recorder{
+		store:            store,
+		lister:           informer.Lister(),
+		placements:       placements.Lister(),
+		placementsSynced: placements.Informer().HasSynced,
+	}
+
+	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.onChange,
+		UpdateFunc: func(_, newObj interface{}) { r.onChange(newObj) },
+		DeleteFunc: r.onChange,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = placements.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: r.onPlacementDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Registered placement decision history recorder")
+
+	return r, nil
+}
+
+func (r *Recorder) onPlacementDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	placement, ok := obj.(*clusterv1beta1.Placement)
+	if !ok {
+		return
+	}
+
+	if err := r.store.Delete(placement.Namespace, placement.Name); err != nil {
+		slog.Error("Failed to delete placement history", "namespace", placement.Namespace, "placement", placement.Name, "error", err)
+	}
+}
+
+func (r *Recorder) onChange(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pd, ok := obj.(*clusterv1beta1.PlacementDecision)
+	if !ok {
+		return
+	}
+
+	placementName := pd.Labels[clusterv1beta1.PlacementLabel]
+	if placementName == "" {
+		return
+	}
+
+	if err := r.sync(pd.Namespace, placementName); err != nil {
+		slog.Error("Failed to record placement decisions", "namespace", pd.Namespace, "placement", placementName, "error", err)
+	}
+}
+
+// sync records the union of clusters across all PlacementDecisions of a placement.
+// Decisions outliving their deleted placement are not recorded.
+func (r *Recorder) sync(namespace, placementName string) error {
+	if r.placementsSynced() {
+		if _, err := r.placements.Placements(namespace).Get(placementName); apierrors.IsNotFound(err) {
+			return r.store.Delete(namespace, placementName)
+		}
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{clusterv1beta1.PlacementLabel: placementName})
+	decisions, err := r.lister.PlacementDecisions(namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	var clusters []string
+	for _, pd := range decisions {
+		for _, decision := range pd.Status.Decisions {
+			clusters = append(clusters, decision.ClusterName)
+		}
+	}
+
+	change, err := r.store.Record(namespace, placementName, clusters, time.Now())
+	if err != nil {
+		return err
+	}
+	if change != nil {
+		slog.Info("Placement decisions changed", "namespace", namespace, "placement", placementName, "added", change.Added, "removed", change.Removed)
+	}
+	return nil
+}
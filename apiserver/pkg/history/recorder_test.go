@@ -0,0 +1,53 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1informers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	"open-cluster-management-io/lab/apiserver/pkg/client"
+)
+
+func TestRecorderDeletesRemovedPlacements(t *testing.T) {
+	clusterClient := clusterfake.NewSimpleClientset(
+		&clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"}},
+		&clusterv1beta1.PlacementDecision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "placement1-decision-1",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1beta1.PlacementLabel: "placement1"},
+			},
+			Status: clusterv1beta1.PlacementDecisionStatus{
+				Decisions: []clusterv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+			},
+		},
+	)
+	factory := clusterv1informers.NewSharedInformerFactory(clusterClient, 0)
+	store := openTestStore(t)
+
+	_, err := NewRecorder(&client.OCMClient{ClusterClient: clusterClient, ClusterInformerFactory: factory}, store)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	assert.Eventually(t, func() bool {
+		current, err := store.Current("default", "placement1")
+		return err == nil && len(current) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, clusterClient.ClusterV1beta1().Placements("default").Delete(ctx, "placement1", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		changes, err := store.Changes("default", "placement1", time.Time{})
+		return err == nil && len(changes) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
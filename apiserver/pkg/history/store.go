@@ -0,0 +1,293 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// currentBucket holds the last known set of decided clusters per placement
+	currentBucket = []byte("current")
+	// eventsBucket holds one nested bucket of change events per placement
+	eventsBucket = []byte("events")
+)
+
+// maxEvents is the number of change events kept per placement, older events
+// are dropped as new ones are recorded
+const maxEvents = 1000
+
+// Change represents a change in the set of clusters selected by a placement
+type Change struct {
+	Timestamp time.Time `json:"timestamp"`
+	Added     []string  `json:"added,omitempty"`
+	Removed   []string  `json:"removed,omitempty"`
+	Clusters  []string  `json:"clusters"`
+}
+
+// Store records placement decision changes in an embedded bbolt database
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (or creates) the history database at the given path, creating its
+// directory if needed
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create placement history directory: %w", err)
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open placement history database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(currentBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(eventsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record stores the current set of decided clusters for a placement. A change
+// event is only appended when the set differs from the last recorded one, in
+// which case the event is returned; otherwise nil is returned.
+func (s *Store) Record(namespace, name string, clusters []string, at time.Time) (*Change, error) {
+	key := placementKey(namespace, name)
+	current := normalize(clusters)
+
+	var change *Change
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var previous []string
+		if data := tx.Bucket(currentBucket).Get(key); data != nil {
+			if err := json.Unmarshal(data, &previous); err != nil {
+				return err
+			}
+		} else if len(current) == 0 {
+			// Nothing known and nothing decided, no need to track this placement yet
+			return nil
+		}
+
+		added, removed := diff(previous, current)
+		if len(added) == 0 && len(removed) == 0 {
+			return nil
+		}
+
+		change = &Change{
+			Timestamp: at.UTC(),
+			Added:     added,
+			Removed:   removed,
+			Clusters:  current,
+		}
+
+		events, err := tx.Bucket(eventsBucket).CreateBucketIfNotExists(key)
+		if err != nil {
+			return err
+		}
+		seq, err := events.NextSequence()
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		if err := events.Put(sequenceKey(seq), data); err != nil {
+			return err
+		}
+		// Every record appends one event, so dropping the event maxEvents
+		// sequences back keeps the newest maxEvents
+		if seq > maxEvents {
+			if err := events.Delete(sequenceKey(seq - maxEvents)); err != nil {
+				return err
+			}
+		}
+
+		data, err = json.Marshal(current)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(currentBucket).Put(key, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// Delete removes the recorded decisions and changes of a placement
+func (s *Store) Delete(namespace, name string) error {
+	key := placementKey(namespace, name)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(currentBucket).Delete(key); err != nil {
+			return err
+		}
+		err := tx.Bucket(eventsBucket).DeleteBucket(key)
+		if err == bolt.ErrBucketNotFound {
+			return nil
+		}
+		return err
+	})
+}
+
+// Current returns the last recorded set of decided clusters for a placement
+func (s *Store) Current(namespace, name string) ([]string, error) {
+	clusters := []string{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(currentBucket).Get(placementKey(namespace, name))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &clusters)
+	})
+	return clusters, err
+}
+
+// Changes returns the recorded changes of a placement since the given time,
+// oldest first
+func (s *Store) Changes(namespace, name string, since time.Time) ([]Change, error) {
+	changes := []Change{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		events := tx.Bucket(eventsBucket).Bucket(placementKey(namespace, name))
+		if events == nil {
+			return nil
+		}
+		return events.ForEach(func(_, v []byte) error {
+			var change Change
+			if err := json.Unmarshal(v, &change); err != nil {
+				return err
+			}
+			if !change.Timestamp.Before(since) {
+				changes = append(changes, change)
+			}
+			return nil
+		})
+	})
+	return changes, err
+}
+
+// Churn summarizes the given changes over a time window
+type Churn struct {
+	Changes         int
+	ClustersAdded   int
+	ClustersRemoved int
+	ChangesPerHour  float64
+}
+
+// ComputeChurn computes the churn rate of the given changes over the window
+func ComputeChurn(changes []Change, window time.Duration) Churn {
+	churn := Churn{Changes: len(changes)}
+	for _, change := range changes {
+		churn.ClustersAdded += len(change.Added)
+		churn.ClustersRemoved += len(change.Removed)
+	}
+	if hours := window.Hours(); hours > 0 {
+		churn.ChangesPerHour = float64(churn.Changes) / hours
+	}
+	return churn
+}
+
+// PlacementChurn is the churn of a placement over a time window
+type PlacementChurn struct {
+	Namespace string
+	Name      string
+	Churn
+}
+
+// ChurnRates computes the churn of every placement with recorded changes over
+// the window ending at now
+func (s *Store) ChurnRates(window time.Duration, now time.Time) ([]PlacementChurn, error) {
+	since := now.Add(-window)
+	var result []PlacementChurn
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(eventsBucket).ForEachBucket(func(key []byte) error {
+			namespace, name, ok := strings.Cut(string(key), "/")
+			if !ok {
+				return nil
+			}
+
+			// Events are appended in time order, read them back from the newest
+			var changes []Change
+			cursor := tx.Bucket(eventsBucket).Bucket(key).Cursor()
+			for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+				var change Change
+				if err := json.Unmarshal(v, &change); err != nil {
+					return err
+				}
+				if change.Timestamp.Before(since) {
+					break
+				}
+				changes = append(changes, change)
+			}
+			result = append(result, PlacementChurn{Namespace: namespace, Name: name, Churn: ComputeChurn(changes, window)})
+			return nil
+		})
+	})
+	return result, err
+}
+
+func placementKey(namespace, name string) []byte {
+	return []byte(namespace + "/" + name)
+}
+
+func sequenceKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
+// normalize returns a sorted copy of the cluster names without duplicates
+func normalize(clusters []string) []string {
+	seen := make(map[string]bool, len(clusters))
+	result := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		if cluster == "" || seen[cluster] {
+			continue
+		}
+		seen[cluster] = true
+		result = append(result, cluster)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// diff returns the clusters added to and removed from a sorted cluster set
+func diff(previous, current []string) (added, removed []string) {
+	previousSet := make(map[string]bool, len(previous))
+	for _, cluster := range previous {
+		previousSet[cluster] = true
+	}
+	currentSet := make(map[string]bool, len(current))
+	for _, cluster := range current {
+		currentSet[cluster] = true
+		if !previousSet[cluster] {
+			added = append(added, cluster)
+		}
+	}
+	for _, cluster := range previous {
+		if !currentSet[cluster] {
+			removed = append(removed, cluster)
+		}
+	}
+	return added, removed
+}
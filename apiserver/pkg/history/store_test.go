@@ -0,0 +1,135 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestStore(t *testing.T) *Store {
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestOpenCreatesDirectory(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "data", "history.db"))
+	require.NoError(t, err)
+	store.Close()
+}
+
+func TestStoreRecord(t *testing.T) {
+	store := openTestStore(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	change, err := store.Record("default", "placement1", []string{"cluster2", "cluster1"}, start)
+	require.NoError(t, err)
+	require.NotNil(t, change)
+	assert.Equal(t, []string{"cluster1", "cluster2"}, change.Added)
+	assert.Empty(t, change.Removed)
+
+	// Same set in a different order is not a change
+	change, err = store.Record("default", "placement1", []string{"cluster1", "cluster2", "cluster1"}, start.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, change)
+
+	change, err = store.Record("default", "placement1", []string{"cluster1", "cluster3"}, start.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, change)
+	assert.Equal(t, []string{"cluster3"}, change.Added)
+	assert.Equal(t, []string{"cluster2"}, change.Removed)
+	assert.Equal(t, []string{"cluster1", "cluster3"}, change.Clusters)
+
+	current, err := store.Current("default", "placement1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cluster1", "cluster3"}, current)
+
+	changes, err := store.Changes("default", "placement1", time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+
+	changes, err = store.Changes("default", "placement1", start.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, changes, 1)
+}
+
+func TestStoreRecordEmptyUnknownPlacement(t *testing.T) {
+	store := openTestStore(t)
+
+	change, err := store.Record("default", "placement1", nil, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, change)
+
+	changes, err := store.Changes("default", "placement1", time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestStoreRecordKeepsNewestEvents(t *testing.T) {
+	store := openTestStore(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < maxEvents+5; i++ {
+		_, err := store.Record("default", "placement1", []string{fmt.Sprintf("cluster%d", i)}, start.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+	}
+
+	changes, err := store.Changes("default", "placement1", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, changes, maxEvents)
+	assert.Equal(t, start.Add(5*time.Minute), changes[0].Timestamp)
+}
+
+func TestStoreDelete(t *testing.T) {
+	store := openTestStore(t)
+
+	_, err := store.Record("default", "placement1", []string{"cluster1"}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, store.Delete("default", "placement1"))
+	require.NoError(t, store.Delete("default", "unknown"))
+
+	current, err := store.Current("default", "placement1")
+	require.NoError(t, err)
+	assert.Empty(t, current)
+	changes, err := store.Changes("default", "placement1", time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestStoreChurnRates(t *testing.T) {
+	store := openTestStore(t)
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.Record("default", "placement1", []string{"cluster1"}, now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	_, err = store.Record("default", "placement1", []string{"cluster2"}, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+	_, err = store.Record("default", "placement1", []string{"cluster3"}, now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	rates, err := store.ChurnRates(4*time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "default", rates[0].Namespace)
+	assert.Equal(t, "placement1", rates[0].Name)
+	assert.Equal(t, 2, rates[0].Changes)
+	assert.Equal(t, 0.5, rates[0].ChangesPerHour)
+}
+
+func TestComputeChurn(t *testing.T) {
+	changes := []Change{
+		{Added: []string{"cluster1", "cluster2"}},
+		{Added: []string{"cluster3"}, Removed: []string{"cluster1"}},
+	}
+
+	churn := ComputeChurn(changes, 4*time.Hour)
+	assert.Equal(t, 2, churn.Changes)
+	assert.Equal(t, 3, churn.ClustersAdded)
+	assert.Equal(t, 1, churn.ClustersRemoved)
+	assert.Equal(t, 0.5, churn.ChangesPerHour)
+}
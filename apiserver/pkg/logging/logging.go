@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Environment variables configuring the logger
+const (
+	LevelEnv  = "DASHBOARD_LOG_LEVEL"
+	FormatEnv = "DASHBOARD_LOG_FORMAT"
+
+	// legacyDebugEnv enabled debug logging before DASHBOARD_LOG_LEVEL existed
+	legacyDebugEnv = "DASHBOARD_DEBUG"
+)
+
+// Log formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// level is the level of the default logger, it can be changed while the server
+// runs
+var level = new(slog.LevelVar)
+
+// Configure installs the default slog logger with the given level and format
+func Configure(levelName, format string) error {
+	parsed, err := ParseLevel(levelName)
+	if err != nil {
+		return err
+	}
+	handler, err := NewHandler(os.Stderr, strings.ToLower(format), level)
+	if err != nil {
+		return err
+	}
+	level.Set(parsed)
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// SetLevel changes the level of the default logger installed by Configure or Setup
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Setup installs the default slog logger configured from the environment and
+// returns its level. The standard log package is routed through it as well.
+func Setup() slog.Level {
+	envLevel, levelErr := levelFromEnv()
+	level.Set(envLevel)
+	format := strings.ToLower(os.Getenv(FormatEnv))
+	if format == "" {
+		format = FormatJSON
+	}
+
+	handler, formatErr := NewHandler(os.Stderr, format, level)
+	if formatErr != nil {
+		handler, _ = NewHandler(os.Stderr, FormatJSON, level)
+	}
+	slog.SetDefault(slog.New(handler))
+
+	if levelErr != nil {
+		slog.Warn("Invalid log level, using info", "error", levelErr)
+	}
+	if formatErr != nil {
+		slog.Warn("Invalid log format, using json", "error", formatErr)
+	}
+	if os.Getenv(LevelEnv) == "" && os.Getenv(legacyDebugEnv) != "" {
+		slog.Warn("DASHBOARD_DEBUG is deprecated, set DASHBOARD_LOG_LEVEL instead")
+	}
+	return envLevel
+}
+
+// levelFromEnv reads DASHBOARD_LOG_LEVEL, falling back to DASHBOARD_DEBUG=true
+// for debug logging
+func levelFromEnv() (slog.Level, error) {
+	if value := os.Getenv(LevelEnv); value != "" {
+		level, err := ParseLevel(value)
+		if err != nil {
+			return slog.LevelInfo, err
+		}
+		return level, nil
+	}
+	if os.Getenv(legacyDebugEnv) == "true" {
+		return slog.LevelDebug, nil
+	}
+	return slog.LevelInfo, nil
+}
+
+// ParseLevel parses debug, info, warn or error (case-insensitive)
+func ParseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q, expected debug, info, warn or error", value)
+	}
+	return level, nil
+}
+
+// NewHandler creates a JSON or text handler writing records at or above level
+func NewHandler(w io.Writer, format string, level slog.Leveler) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level}
+	switch format {
+	case FormatJSON:
+		return slog.NewJSONHandler(w, options), nil
+	case FormatText:
+		return slog.NewTextHandler(w, options), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q, expected json or text", format)
+	}
+}
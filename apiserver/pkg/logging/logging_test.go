@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    slog.Level
+		expectError bool
+	}{
+		{value: "debug", expected: slog.LevelDebug},
+		{value: "INFO", expected: slog.LevelInfo},
+		{value: "warn", expected: slog.LevelWarn},
+		{value: "error", expected: slog.LevelError},
+		{value: "verbose", expected: slog.LevelInfo, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			level, err := ParseLevel(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestLevelFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		level       string
+		debug       string
+		expected    slog.Level
+		expectError bool
+	}{
+		{name: "default", expected: slog.LevelInfo},
+		{name: "level", level: "warn", expected: slog.LevelWarn},
+		{name: "legacy debug", debug: "true", expected: slog.LevelDebug},
+		{name: "level wins over legacy debug", level: "error", debug: "true", expected: slog.LevelError},
+		{name: "invalid level", level: "loud", expected: slog.LevelInfo, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(LevelEnv, tt.level)
+			t.Setenv(legacyDebugEnv, tt.debug)
+
+			level, err := levelFromEnv()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	handler, err := NewHandler(&buf, FormatJSON, slog.LevelWarn)
+	require.NoError(t, err)
+	logger := slog.New(handler)
+	logger.Info("dropped")
+	logger.Warn("kept", "key", "value")
+	assert.NotContains(t, buf.String(), "dropped")
+	assert.Contains(t, buf.String(), `"msg":"kept","key":"value"`)
+
+	buf.Reset()
+	handler, err = NewHandler(&buf, FormatText, slog.LevelInfo)
+	require.NoError(t, err)
+	slog.New(handler).Info("hello", "key", "value")
+	assert.Contains(t, buf.String(), "msg=hello key=value")
+
+	_, err = NewHandler(&buf, "xml", slog.LevelInfo)
+	assert.Error(t, err)
+}
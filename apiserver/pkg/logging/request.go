@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the request ID, it is reused from the incoming
+// request when valid and always set on the response
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds request IDs supplied by clients
+const maxRequestIDLength = 128
+
+// quietRoutes are probed frequently and only logged at debug level
+var quietRoutes = map[string]bool{
+	"/health":  true,
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+type contextKey struct{}
+
+// requestInfo holds the per-request logging state
+type requestInfo struct {
+	id            string
+	upstreamCalls atomic.Int64
+
+	mu   sync.Mutex
+	user string
+}
+
+func infoFromContext(ctx context.Context) *requestInfo {
+	if ctx == nil {
+		return nil
+	}
+	info, _ := ctx.Value(contextKey{}).(*requestInfo)
+	return info
+}
+
+// RequestID returns the ID of the request the context belongs to, or an empty string
+func RequestID(ctx context.Context) string {
+	if info := infoFromContext(ctx); info != nil {
+		return info.id
+	}
+	return ""
+}
+
+// FromContext returns the default logger annotated with the request ID
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestID(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
+
+// SetUser records the authenticated user to include in the request log
+func SetUser(ctx context.Context, user string) {
+	if info := infoFromContext(ctx); info != nil {
+		info.mu.Lock()
+		info.user = user
+		info.mu.Unlock()
+	}
+}
+
+// Middleware assigns every request an ID and logs its route, status, latency,
+// authenticated user and number of hub API calls once it completes. Headers and
+// query parameters are never logged, so bearer tokens cannot leak.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		info := &requestInfo{id: id}
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, info))
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := c.Writer.Status()
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case quietRoutes[route]:
+			level = slog.LevelDebug
+		}
+
+		info.mu.Lock()
+		user := info.user
+		info.mu.Unlock()
+
+		attrs := []slog.Attr{
+			slog.String("request_id", id),
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int64("upstream_calls", info.upstreamCalls.Load()),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if user != "" {
+			attrs = append(attrs, slog.String("user", user))
+		}
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.HasTraceID() {
+			attrs = append(attrs, slog.String("trace_id", spanContext.TraceID().String()))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		slog.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// CountUpstreamCalls wraps a round tripper to count the hub API calls made on
+// behalf of each request. It is meant for rest.Config.Wrap.
+func CountUpstreamCalls(rt http.RoundTripper) http.RoundTripper {
+	return &countingTransport{next: rt}
+}
+
+type countingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if info := infoFromContext(req.Context()); info != nil {
+		info.upstreamCalls.Add(1)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// validRequestID accepts short printable ASCII IDs, so client supplied values
+// cannot inject into log lines
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().UTC().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
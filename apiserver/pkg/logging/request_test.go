@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLogs routes the default logger to a buffer for the duration of the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		requestID     string
+		path          string
+		expectedID    string
+		expectedRoute string
+		expectedUser  string
+		expectLogged  bool
+	}{
+		{
+			name:          "request ID is propagated",
+			requestID:     "abc-123",
+			path:          "/api/namespaces/default/placements",
+			expectedID:    "abc-123",
+			expectedRoute: "/api/namespaces/:namespace/placements",
+			expectedUser:  "alice",
+			expectLogged:  true,
+		},
+		{
+			name:          "request ID is generated",
+			path:          "/api/namespaces/default/placements",
+			expectedRoute: "/api/namespaces/:namespace/placements",
+			expectedUser:  "alice",
+			expectLogged:  true,
+		},
+		{
+			name:          "invalid request ID is replaced",
+			requestID:     "bad id\nwith newline",
+			path:          "/unknown",
+			expectedRoute: "unmatched",
+			expectLogged:  true,
+		},
+		{
+			name:         "health checks are logged at debug level",
+			path:         "/health",
+			expectLogged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureLogs(t)
+
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer upstream.Close()
+			upstreamClient := &http.Client{Transport: CountUpstreamCalls(http.DefaultTransport)}
+
+			r := gin.New()
+			r.Use(Middleware())
+			r.GET("/api/namespaces/:namespace/placements", func(c *gin.Context) {
+				SetUser(c.Request.Context(), "alice")
+				for i := 0; i < 2; i++ {
+					req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, upstream.URL, nil)
+					require.NoError(t, err)
+					resp, err := upstreamClient.Do(req)
+					require.NoError(t, err)
+					resp.Body.Close()
+				}
+				c.Status(http.StatusOK)
+			})
+			r.GET("/health", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path+"?token=secret", nil)
+			req.Header.Set("Authorization", "Bearer secret-token")
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			responseID := w.Header().Get(RequestIDHeader)
+			require.NotEmpty(t, responseID)
+			if tt.expectedID != "" {
+				assert.Equal(t, tt.expectedID, responseID)
+			} else {
+				assert.NotEqual(t, tt.requestID, responseID)
+			}
+
+			assert.NotContains(t, buf.String(), "secret")
+			if !tt.expectLogged {
+				assert.Empty(t, buf.String())
+				return
+			}
+
+			var record map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &record))
+			assert.Equal(t, "request", record["msg"])
+			assert.Equal(t, responseID, record["request_id"])
+			assert.Equal(t, tt.expectedRoute, record["route"])
+			assert.Equal(t, tt.path, record["path"])
+			if tt.expectedUser != "" {
+				assert.Equal(t, tt.expectedUser, record["user"])
+				assert.Equal(t, 2.0, record["upstream_calls"])
+			} else {
+				assert.NotContains(t, record, "user")
+				assert.Equal(t, 0.0, record["upstream_calls"])
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	buf := captureLogs(t)
+
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/", func(c *gin.Context) {
+		FromContext(c.Request.Context()).Info("handler")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"msg":"handler","request_id":"req-1"`)
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ChurnWindow is the window the placement churn gauge is computed over
+const ChurnWindow = 24 * time.Hour
+
+// PlacementChurn is the decision churn of a placement over ChurnWindow
+type PlacementChurn struct {
+	Namespace      string
+	Placement      string
+	ChangesPerHour float64
+}
+
+// churnCollector reports the placement churn of a hub at scrape time
+type churnCollector struct {
+	desc  *prometheus.Desc
+	churn func() ([]PlacementChurn, error)
+}
+
+// RegisterPlacementChurnCollector registers the placement churn gauge of a hub,
+// computed at scrape time by churn from the recorded decision history
+func RegisterPlacementChurnCollector(hub string, churn func() ([]PlacementChurn, error)) error {
+	return Registry.Register(newChurnCollector(hub, churn))
+}
+
+func newChurnCollector(hub string, churn func() ([]PlacementChurn, error)) *churnCollector {
+	return &churnCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "placement", "churn_changes_per_hour"),
+			"Placement decision changes per hour over the last 24 hours, by placement.",
+			[]string{"namespace", "placement"}, prometheus.Labels{"hub": hub}),
+		churn: churn,
+	}
+}
+
+func (c *churnCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *churnCollector) Collect(ch chan<- prometheus.Metric) {
+	placements, err := c.churn()
+	if err != nil {
+		slog.Warn("Failed to compute placement churn", "error", err)
+		return
+	}
+	for _, placement := range placements {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, placement.ChangesPerHour,
+			placement.Namespace, placement.Placement)
+	}
+}
@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	addonv1alpha1informers "open-cluster-management.io/api/client/addon/informers/externalversions"
+	addonv1alpha1listers "open-cluster-management.io/api/client/addon/listers/addon/v1alpha1"
+	clusterv1informers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	clusterv1listers "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
+	workv1informers "open-cluster-management.io/api/client/work/informers/externalversions"
+	workv1listers "open-cluster-management.io/api/client/work/listers/work/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+var (
+	informerSyncedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "informer_synced"),
+		"Whether the informer cache has completed its initial sync (1) or not (0).",
+		[]string{"informer"}, nil)
+
+	clustersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "fleet", "clusters"),
+		"Number of ManagedClusters by status (Online, Offline or Unknown).",
+		[]string{"status"}, nil)
+
+	unhealthyAddonsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "fleet", "unhealthy_addons"),
+		"Number of ManagedClusterAddOns that are not Available, by addon.",
+		[]string{"addon"}, nil)
+
+	failedManifestWorksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "fleet", "failed_manifestworks"),
+		"Number of ManifestWorks that failed to apply or are degraded.",
+		nil, nil)
+)
+
+// fleetCollector reports the fleet gauges from the informer caches at scrape time
+type fleetCollector struct {
+	clusters  clusterv1listers.ManagedClusterLister
+	addons    addonv1alpha1listers.ManagedClusterAddOnLister
+	works     workv1listers.ManifestWorkLister
+	informers map[string]cache.SharedIndexInformer
+}
+
+// RegisterFleetCollector registers the informer sync and fleet gauges, backed
+// by the ManagedCluster, ManagedClusterAddOn and ManifestWork informers. The
+// factories must be started by the caller.
+func RegisterFleetCollector(clusterInformers clusterv1informers.SharedInformerFactory,
+	addonInformers addonv1alpha1informers.SharedInformerFactory, workInformers workv1informers.SharedInformerFactory) error {
+	return Registry.Register(newFleetCollector(clusterInformers, addonInformers, workInformers))
+}
+
+func newFleetCollector(clusterInformers clusterv1informers.SharedInformerFactory,
+	addonInformers addonv1alpha1informers.SharedInformerFactory, workInformers workv1informers.SharedInformerFactory) *fleetCollector {
+	clusters := clusterInformers.Cluster().V1().ManagedClusters()
+	addons := addonInformers.Addon().V1alpha1().ManagedClusterAddOns()
+	works := workInformers.Work().V1().ManifestWorks()
+
+	return &fleetCollector{
+		clusters: clusters.Lister(),
+		addons:   addons.Lister(),
+		works:    works.Lister(),
+		informers: map[string]cache.SharedIndexInformer{
+			"managedclusters":      clusters.Informer(),
+			"managedclusteraddons": addons.Informer(),
+			"manifestworks":        works.Informer(),
+		},
+	}
+}
+
+func (f *fleetCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- informerSyncedDesc
+	ch <- clustersDesc
+	ch <- unhealthyAddonsDesc
+	ch <- failedManifestWorksDesc
+}
+
+func (f *fleetCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, informer := range f.informers {
+		synced := 0.0
+		if informer.HasSynced() {
+			synced = 1
+		}
+		ch <- prometheus.MustNewConstMetric(informerSyncedDesc, prometheus.GaugeValue, synced, name)
+	}
+
+	// Skip the fleet gauges until the caches are complete, partial counts would
+	// trigger false alerts
+	if !f.informers["managedclusters"].HasSynced() {
+		return
+	}
+	clusters, err := f.clusters.List(labels.Everything())
+	if err != nil {
+		slog.Error("Failed to list ManagedClusters for metrics", "error", err)
+		return
+	}
+	clusterCounts := map[string]int{"Online": 0, "Offline": 0, "Unknown": 0}
+	for _, cluster := range clusters {
+		clusterCounts[clusterStatus(cluster.Status.Conditions)]++
+	}
+	for status, count := range clusterCounts {
+		ch <- prometheus.MustNewConstMetric(clustersDesc, prometheus.GaugeValue, float64(count), status)
+	}
+
+	if f.informers["managedclusteraddons"].HasSynced() {
+		addons, err := f.addons.List(labels.Everything())
+		if err != nil {
+			slog.Error("Failed to list ManagedClusterAddOns for metrics", "error", err)
+			return
+		}
+		unhealthy := make(map[string]int)
+		for _, addon := range addons {
+			if _, ok := unhealthy[addon.Name]; !ok {
+				unhealthy[addon.Name] = 0
+			}
+			if !meta.IsStatusConditionTrue(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable) ||
+				meta.IsStatusConditionTrue(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionDegraded) {
+				unhealthy[addon.Name]++
+			}
+		}
+		for name, count := range unhealthy {
+			ch <- prometheus.MustNewConstMetric(unhealthyAddonsDesc, prometheus.GaugeValue, float64(count), name)
+		}
+	}
+
+	if f.informers["manifestworks"].HasSynced() {
+		works, err := f.works.List(labels.Everything())
+		if err != nil {
+			slog.Error("Failed to list ManifestWorks for metrics", "error", err)
+			return
+		}
+		failed := 0
+		for _, work := range works {
+			if meta.IsStatusConditionFalse(work.Status.Conditions, workv1.WorkApplied) ||
+				meta.IsStatusConditionTrue(work.Status.Conditions, workv1.WorkDegraded) {
+				failed++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(failedManifestWorksDesc, prometheus.GaugeValue, float64(failed))
+	}
+}
+
+// clusterStatus derives the cluster status the same way the cluster API does
+func clusterStatus(conditions []metav1.Condition) string {
+	condition := meta.FindStatusCondition(conditions, clusterv1.ManagedClusterConditionAvailable)
+	switch {
+	case condition == nil:
+		return "Unknown"
+	case condition.Status == metav1.ConditionTrue:
+		return "Online"
+	default:
+		return "Offline"
+	}
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ocm_dashboard"
+
+// TokenReview results
+const (
+	TokenReviewAuthenticated   = "authenticated"
+	TokenReviewUnauthenticated = "unauthenticated"
+	TokenReviewError           = "error"
+)
+
+// unmatchedRoute labels requests that did not match any registered route, so
+// unknown paths cannot grow the label cardinality
+const unmatchedRoute = "unmatched"
+
+// Registry holds every dashboard metric
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route template, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route template, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+
+	hubRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "hub_requests_total",
+		Help:      "Number of requests to the hub API server by resource, verb and status code.",
+	}, []string{"resource", "verb", "code"})
+
+	hubRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "hub_request_duration_seconds",
+		Help:      "Latency of requests to the hub API server by resource and verb.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"resource", "verb"})
+
+	tokenReviewDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tokenreview_duration_seconds",
+		Help:      "Latency of TokenReview calls by result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	sseConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "sse_connections",
+		Help:      "Number of open server-sent event streams by stream.",
+	}, []string{"stream"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		hubRequestsTotal,
+		hubRequestDuration,
+		tokenReviewDuration,
+		sseConnections,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Middleware records the count and latency of every request, labeled by the
+// route template (e.g. /api/namespaces/:namespace/placements) rather than the path
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		code := strconv.Itoa(c.Writer.Status())
+
+		httpRequestsTotal.WithLabelValues(route, c.Request.Method, code).Inc()
+		httpRequestDuration.WithLabelValues(route, c.Request.Method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveTokenReview records the latency and result of a TokenReview call
+func ObserveTokenReview(result string, duration time.Duration) {
+	tokenReviewDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// TrackSSEConnection counts an open stream until the returned function is called
+func TrackSSEConnection(stream string) func() {
+	gauge := sseConnections.WithLabelValues(stream)
+	gauge.Inc()
+	return gauge.Dec
+}
@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+	addonv1alpha1informers "open-cluster-management.io/api/client/addon/informers/externalversions"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1informers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	workv1informers "open-cluster-management.io/api/client/work/informers/externalversions"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/api/namespaces/:namespace/placements", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/api/namespaces/a/placements", "/api/namespaces/b/placements", "/unknown"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpRequestsTotal.WithLabelValues("/api/namespaces/:namespace/placements", "GET", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequestsTotal.WithLabelValues(unmatchedRoute, "GET", "404")))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestInstrumentTransport(t *testing.T) {
+	transport := InstrumentTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}, nil
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "https://hub/apis/cluster.open-cluster-management.io/v1beta2/managedclustersets/missing", nil)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(hubRequestsTotal.WithLabelValues("managedclustersets.cluster.open-cluster-management.io", "get", "404")))
+}
+
+func TestTrackSSEConnection(t *testing.T) {
+	done := TrackSSEConnection("test")
+	assert.Equal(t, 1.0, testutil.ToFloat64(sseConnections.WithLabelValues("test")))
+	done()
+	assert.Equal(t, 0.0, testutil.ToFloat64(sseConnections.WithLabelValues("test")))
+}
+
+func TestObserveTokenReview(t *testing.T) {
+	ObserveTokenReview(TokenReviewUnauthenticated, 10*time.Millisecond)
+	assert.Equal(t, 1, testutil.CollectAndCount(tokenReviewDuration, namespace+"_tokenreview_duration_seconds"))
+}
+
+func TestFleetCollector(t *testing.T) {
+	condition := func(conditionType string, status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{Type: conditionType, Status: status}
+	}
+
+	clusterClient := clusterfake.NewSimpleClientset(
+		&clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+			Status: clusterv1.ManagedClusterStatus{Conditions: []metav1.Condition{
+				condition(clusterv1.ManagedClusterConditionAvailable, metav1.ConditionTrue),
+			}},
+		},
+		&clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster2"},
+			Status: clusterv1.ManagedClusterStatus{Conditions: []metav1.Condition{
+				condition(clusterv1.ManagedClusterConditionAvailable, metav1.ConditionUnknown),
+			}},
+		},
+		&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster3"}},
+	)
+	addonClient := addonfake.NewSimpleClientset(
+		&addonv1alpha1.ManagedClusterAddOn{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-manager", Namespace: "cluster1"},
+			Status: addonv1alpha1.ManagedClusterAddOnStatus{Conditions: []metav1.Condition{
+				condition(addonv1alpha1.ManagedClusterAddOnConditionAvailable, metav1.ConditionTrue),
+			}},
+		},
+		&addonv1alpha1.ManagedClusterAddOn{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-manager", Namespace: "cluster2"},
+			Status: addonv1alpha1.ManagedClusterAddOnStatus{Conditions: []metav1.Condition{
+				condition(addonv1alpha1.ManagedClusterAddOnConditionAvailable, metav1.ConditionFalse),
+			}},
+		},
+	)
+	workClient := workfake.NewSimpleClientset(
+		&workv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: "applied", Namespace: "cluster1"},
+			Status: workv1.ManifestWorkStatus{Conditions: []metav1.Condition{
+				condition(workv1.WorkApplied, metav1.ConditionTrue),
+			}},
+		},
+		&workv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "cluster1"},
+			Status: workv1.ManifestWorkStatus{Conditions: []metav1.Condition{
+				condition(workv1.WorkApplied, metav1.ConditionFalse),
+			}},
+		},
+		&workv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: "degraded", Namespace: "cluster2"},
+			Status: workv1.ManifestWorkStatus{Conditions: []metav1.Condition{
+				condition(workv1.WorkApplied, metav1.ConditionTrue),
+				condition(workv1.WorkDegraded, metav1.ConditionTrue),
+			}},
+		},
+	)
+
+	clusterInformers := clusterv1informers.NewSharedInformerFactory(clusterClient, 0)
+	addonInformers := addonv1alpha1informers.NewSharedInformerFactory(addonClient, 0)
+	workInformers := workv1informers.NewSharedInformerFactory(workClient, 0)
+	collector := newFleetCollector(clusterInformers, addonInformers, workInformers)
+
+	// Nothing but the sync state is reported before the caches are synced
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP ocm_dashboard_informer_synced Whether the informer cache has completed its initial sync (1) or not (0).
+# TYPE ocm_dashboard_informer_synced gauge
+ocm_dashboard_informer_synced{informer="managedclusteraddons"} 0
+ocm_dashboard_informer_synced{informer="managedclusters"} 0
+ocm_dashboard_informer_synced{informer="manifestworks"} 0
+`)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clusterInformers.Start(ctx.Done())
+	addonInformers.Start(ctx.Done())
+	workInformers.Start(ctx.Done())
+	for _, informer := range collector.informers {
+		require.True(t, cache.WaitForCacheSync(ctx.Done(), informer.HasSynced))
+	}
+
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP ocm_dashboard_fleet_clusters Number of ManagedClusters by status (Online, Offline or Unknown).
+# TYPE ocm_dashboard_fleet_clusters gauge
+ocm_dashboard_fleet_clusters{status="Offline"} 1
+ocm_dashboard_fleet_clusters{status="Online"} 1
+ocm_dashboard_fleet_clusters{status="Unknown"} 1
+# HELP ocm_dashboard_fleet_failed_manifestworks Number of ManifestWorks that failed to apply or are degraded.
+# TYPE ocm_dashboard_fleet_failed_manifestworks gauge
+ocm_dashboard_fleet_failed_manifestworks 2
+# HELP ocm_dashboard_fleet_unhealthy_addons Number of ManagedClusterAddOns that are not Available, by addon.
+# TYPE ocm_dashboard_fleet_unhealthy_addons gauge
+ocm_dashboard_fleet_unhealthy_addons{addon="app-manager"} 1
+# HELP ocm_dashboard_informer_synced Whether the informer cache has completed its initial sync (1) or not (0).
+# TYPE ocm_dashboard_informer_synced gauge
+ocm_dashboard_informer_synced{informer="managedclusteraddons"} 1
+ocm_dashboard_informer_synced{informer="managedclusters"} 1
+ocm_dashboard_informer_synced{informer="manifestworks"} 1
+`)))
+}
+
+func TestChurnCollector(t *testing.T) {
+	collector := newChurnCollector("east", func() ([]PlacementChurn, error) {
+		return []PlacementChurn{{Namespace: "default", Placement: "placement1", ChangesPerHour: 0.5}}, nil
+	})
+
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP ocm_dashboard_placement_churn_changes_per_hour Placement decision changes per hour over the last 24 hours, by placement.
+# TYPE ocm_dashboard_placement_churn_changes_per_hour gauge
+ocm_dashboard_placement_churn_changes_per_hour{hub="east",namespace="default",placement="placement1"} 0.5
+`)))
+}
+
+func TestHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"open-cluster-management-io/lab/apiserver/pkg/apirequest"
+)
+
+// InstrumentTransport wraps a round tripper to record hub API call counts and
+// latency per resource. It is meant for rest.Config.Wrap.
+func InstrumentTransport(rt http.RoundTripper) http.RoundTripper {
+	return &instrumentedTransport{next: rt}
+}
+
+type instrumentedTransport struct {
+	next http.RoundTripper
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource, verb := apirequest.ResourceAndVerb(req)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	hubRequestDuration.WithLabelValues(resource, verb).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	hubRequestsTotal.WithLabelValues(resource, verb, code).Inc()
+
+	return resp, err
+}
@@ -28,4 +28,27 @@ type ManagedClusterAddon struct {
 	Conditions        []Condition            `json:"conditions,omitempty"`
 	Registrations     []AddonRegistration    `json:"registrations,omitempty"`
 	SupportedConfigs  []AddonSupportedConfig `json:"supportedConfigs,omitempty"`
+	ConfigReferences  []AddonConfigReference `json:"configReferences,omitempty"`
+	Template          *AddonTemplateVersion  `json:"template,omitempty"` // set for template-based addons
+}
+
+// AddonTemplateVersion represents the AddOnTemplate desired for an addon and the one its agent is running
+type AddonTemplateVersion struct {
+	Name            string `json:"name"`
+	DesiredSpecHash string `json:"desiredSpecHash,omitempty"`
+	RunningName     string `json:"runningName,omitempty"`
+	RunningSpecHash string `json:"runningSpecHash,omitempty"`
+	Applied         bool   `json:"applied"` // the agent runs the desired template
+}
+
+// AddonConfigReference represents a configuration used by an addon with its desired and last applied spec hashes
+type AddonConfigReference struct {
+	Group                  string `json:"group"`
+	Resource               string `json:"resource"`
+	Namespace              string `json:"namespace,omitempty"`
+	Name                   string `json:"name"`
+	DesiredSpecHash        string `json:"desiredSpecHash,omitempty"`
+	LastAppliedSpecHash    string `json:"lastAppliedSpecHash,omitempty"`
+	LastObservedGeneration int64  `json:"lastObservedGeneration,omitempty"`
+	Applied                bool   `json:"applied"` // the spoke applied the desired config
 }
@@ -0,0 +1,23 @@
+package models
+
+// AddonCertificate represents the certificate issued for an addon registration
+type AddonCertificate struct {
+	ClusterName   string `json:"clusterName"`
+	AddonName     string `json:"addonName"`
+	SignerName    string `json:"signerName"`
+	Subject       string `json:"subject,omitempty"`    // common name of the issued certificate
+	Source        string `json:"source,omitempty"`     // "CertificateSigningRequest" or "ManagedClusterAddOnCondition"
+	SourceName    string `json:"sourceName,omitempty"` // name of the CSR or of the condition
+	NotBefore     string `json:"notBefore,omitempty"`
+	NotAfter      string `json:"notAfter,omitempty"`
+	DaysRemaining *int   `json:"daysRemaining,omitempty"`
+	Status        string `json:"status"` // "Valid", "Expiring", "Expired", "Unknown"
+}
+
+// AddonCertificateList represents the addon registration certificates expiring within a number of days
+type AddonCertificateList struct {
+	WithinDays int                `json:"withinDays"`
+	Total      int                `json:"total"`   // registrations checked
+	Unknown    int                `json:"unknown"` // registrations without a certificate found on the hub
+	Items      []AddonCertificate `json:"items"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddonCertificateListModel(t *testing.T) {
+	days := 3
+	list := AddonCertificateList{
+		WithinDays: 30,
+		Total:      2,
+		Unknown:    1,
+		Items: []AddonCertificate{
+			{
+				ClusterName:   "cluster1",
+				AddonName:     "application-manager",
+				SignerName:    "kubernetes.io/kube-apiserver-client",
+				Source:        "CertificateSigningRequest",
+				SourceName:    "addon-cluster1-application-manager-abcde",
+				NotAfter:      "2026-10-21T00:00:00Z",
+				DaysRemaining: &days,
+				Status:        "Expiring",
+			},
+		},
+	}
+
+	assert.Equal(t, 30, list.WithinDays)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "Expiring", list.Items[0].Status)
+	assert.Equal(t, 3, *list.Items[0].DaysRemaining)
+}
@@ -0,0 +1,71 @@
+package models
+
+// AddonVariable represents a customized variable of an AddOnDeploymentConfig
+type AddonVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AddonToleration represents a toleration of the addon agent pods
+type AddonToleration struct {
+	Key               string `json:"key,omitempty"`
+	Operator          string `json:"operator,omitempty"`
+	Value             string `json:"value,omitempty"`
+	Effect            string `json:"effect,omitempty"`
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
+
+// AddonNodePlacement represents where the addon agent pods are scheduled
+type AddonNodePlacement struct {
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	Tolerations  []AddonToleration `json:"tolerations,omitempty"`
+}
+
+// AddonImageMirror represents a registry mirror for the addon images
+type AddonImageMirror struct {
+	Mirror string `json:"mirror"`
+	Source string `json:"source"`
+}
+
+// AddonProxyConfig represents the proxy settings of the addon agent, the CA bundle itself is not returned
+type AddonProxyConfig struct {
+	HTTPProxy   string `json:"httpProxy,omitempty"`
+	HTTPSProxy  string `json:"httpsProxy,omitempty"`
+	NoProxy     string `json:"noProxy,omitempty"`
+	HasCABundle bool   `json:"hasCABundle"`
+}
+
+// AddonResourceRequirements represents the resources of the addon agent containers matching a container ID
+type AddonResourceRequirements struct {
+	ContainerID string            `json:"containerID"`
+	Requests    map[string]string `json:"requests,omitempty"`
+	Limits      map[string]string `json:"limits,omitempty"`
+}
+
+// AddonDeploymentConfig represents a simplified OCM AddOnDeploymentConfig
+type AddonDeploymentConfig struct {
+	Name                  string                      `json:"name"`
+	Namespace             string                      `json:"namespace"`
+	AgentInstallNamespace string                      `json:"agentInstallNamespace,omitempty"`
+	CustomizedVariables   []AddonVariable             `json:"customizedVariables,omitempty"`
+	NodePlacement         *AddonNodePlacement         `json:"nodePlacement,omitempty"`
+	Registries            []AddonImageMirror          `json:"registries,omitempty"`
+	ProxyConfig           *AddonProxyConfig           `json:"proxyConfig,omitempty"`
+	ResourceRequirements  []AddonResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
+// ResolvedAddonConfig represents a config reference of an addon together with the referenced object
+type ResolvedAddonConfig struct {
+	Reference        AddonConfigReference   `json:"reference"`
+	DeploymentConfig *AddonDeploymentConfig `json:"deploymentConfig,omitempty"` // set for AddOnDeploymentConfig references
+	Object           map[string]interface{} `json:"object,omitempty"`           // set for other config types
+	Error            string                 `json:"error,omitempty"`
+}
+
+// AddonConfigStatus represents the resolved configs of an addon on a cluster
+type AddonConfigStatus struct {
+	AddonName   string                `json:"addonName"`
+	ClusterName string                `json:"clusterName"`
+	UpToDate    bool                  `json:"upToDate"` // every desired config has been applied by the spoke
+	Configs     []ResolvedAddonConfig `json:"configs"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddonConfigStatusModel(t *testing.T) {
+	status := AddonConfigStatus{
+		AddonName:   "application-manager",
+		ClusterName: "cluster1",
+		UpToDate:    false,
+		Configs: []ResolvedAddonConfig{
+			{
+				Reference: AddonConfigReference{
+					Group:               "addon.open-cluster-management.io",
+					Resource:            "addondeploymentconfigs",
+					Namespace:           "cluster1",
+					Name:                "deploy-config",
+					DesiredSpecHash:     "abc",
+					LastAppliedSpecHash: "def",
+				},
+				DeploymentConfig: &AddonDeploymentConfig{
+					Name:                "deploy-config",
+					Namespace:           "cluster1",
+					CustomizedVariables: []AddonVariable{{Name: "LOG_LEVEL", Value: "debug"}},
+					NodePlacement:       &AddonNodePlacement{NodeSelector: map[string]string{"role": "infra"}},
+					Registries:          []AddonImageMirror{{Mirror: "quay.io/mirror", Source: "quay.io/ocm"}},
+					ProxyConfig:         &AddonProxyConfig{HTTPProxy: "http://proxy:3128", HasCABundle: true},
+				},
+			},
+		},
+	}
+
+	assert.False(t, status.UpToDate)
+	assert.Len(t, status.Configs, 1)
+	assert.False(t, status.Configs[0].Reference.Applied)
+	assert.Equal(t, "debug", status.Configs[0].DeploymentConfig.CustomizedVariables[0].Value)
+	assert.True(t, status.Configs[0].DeploymentConfig.ProxyConfig.HasCABundle)
+}
@@ -0,0 +1,33 @@
+package models
+
+// AddonMatrixCell represents the health of one addon on one cluster
+type AddonMatrixCell struct {
+	ClusterName         string `json:"clusterName"`
+	AddonName           string `json:"addonName"`
+	Status              string `json:"status"` // "Available", "Degraded", "Progressing", "Unavailable", "Unknown"
+	Available           string `json:"available"`
+	Degraded            string `json:"degraded"`
+	Progressing         string `json:"progressing"`
+	RegistrationApplied string `json:"registrationApplied"`
+	HealthCheckMode     string `json:"healthCheckMode"` // "Lease" or "Customized"
+	LastProbeTime       string `json:"lastProbeTime,omitempty"`
+}
+
+// AddonMatrixSummary represents the health counts of a row (cluster) or column (addon) of the matrix
+type AddonMatrixSummary struct {
+	Name        string `json:"name"`
+	Total       int    `json:"total"`
+	Available   int    `json:"available"`
+	Degraded    int    `json:"degraded"`
+	Progressing int    `json:"progressing"`
+	Unhealthy   int    `json:"unhealthy"` // every status other than Available
+}
+
+// AddonMatrix represents the health of every addon across the fleet as a cluster × addon grid
+type AddonMatrix struct {
+	Clusters         []string             `json:"clusters"`
+	Addons           []string             `json:"addons"`
+	Cells            []AddonMatrixCell    `json:"cells"`
+	ClusterSummaries []AddonMatrixSummary `json:"clusterSummaries"` // rows, before the unhealthy filter
+	AddonSummaries   []AddonMatrixSummary `json:"addonSummaries"`   // columns, before the unhealthy filter
+}
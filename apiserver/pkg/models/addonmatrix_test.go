@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddonMatrixModel(t *testing.T) {
+	matrix := AddonMatrix{
+		Clusters: []string{"cluster1"},
+		Addons:   []string{"application-manager"},
+		Cells: []AddonMatrixCell{
+			{
+				ClusterName:         "cluster1",
+				AddonName:           "application-manager",
+				Status:              "Degraded",
+				Available:           "True",
+				Degraded:            "True",
+				Progressing:         "False",
+				RegistrationApplied: "True",
+				HealthCheckMode:     "Lease",
+			},
+		},
+		ClusterSummaries: []AddonMatrixSummary{{Name: "cluster1", Total: 1, Degraded: 1, Unhealthy: 1}},
+		AddonSummaries:   []AddonMatrixSummary{{Name: "application-manager", Total: 1, Degraded: 1, Unhealthy: 1}},
+	}
+
+	assert.Len(t, matrix.Cells, 1)
+	assert.Equal(t, "Degraded", matrix.Cells[0].Status)
+	assert.Equal(t, "Lease", matrix.Cells[0].HealthCheckMode)
+	assert.Equal(t, 1, matrix.ClusterSummaries[0].Unhealthy)
+	assert.Equal(t, "application-manager", matrix.AddonSummaries[0].Name)
+}
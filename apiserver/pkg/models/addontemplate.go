@@ -0,0 +1,29 @@
+package models
+
+// AddonHubPermission represents a hub permission granted to the agent of a template-based addon
+type AddonHubPermission struct {
+	Type            string `json:"type"` // "CurrentCluster" or "SingleNamespace"
+	ClusterRoleName string `json:"clusterRoleName,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	RoleKind        string `json:"roleKind,omitempty"`
+	RoleName        string `json:"roleName,omitempty"`
+}
+
+// AddonTemplateRegistration represents how the agent of a template-based addon registers to the hub
+type AddonTemplateRegistration struct {
+	Type           string                    `json:"type"` // "KubeClient" or "CustomSigner"
+	HubPermissions []AddonHubPermission      `json:"hubPermissions,omitempty"`
+	SignerName     string                    `json:"signerName,omitempty"`
+	Subject        *AddonRegistrationSubject `json:"subject,omitempty"`
+	SigningCA      string                    `json:"signingCA,omitempty"` // namespace/name of the CA secret
+}
+
+// AddonTemplate represents a simplified OCM AddOnTemplate
+type AddonTemplate struct {
+	ID                string                      `json:"id"`
+	Name              string                      `json:"name"`
+	AddonName         string                      `json:"addonName"`
+	AgentSpec         ManifestWorkSpec            `json:"agentSpec"`
+	Registration      []AddonTemplateRegistration `json:"registration,omitempty"`
+	CreationTimestamp string                      `json:"creationTimestamp,omitempty"`
+}
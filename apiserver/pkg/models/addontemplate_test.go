@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddonTemplateModel(t *testing.T) {
+	template := AddonTemplate{
+		ID:        "test-id",
+		Name:      "hello-template-v1",
+		AddonName: "hello-template",
+		AgentSpec: ManifestWorkSpec{
+			Workload: []Manifest{{RawExtension: map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"}}},
+		},
+		Registration: []AddonTemplateRegistration{
+			{
+				Type:           "KubeClient",
+				HubPermissions: []AddonHubPermission{{Type: "CurrentCluster", ClusterRoleName: "hello-template"}},
+			},
+			{
+				Type:       "CustomSigner",
+				SignerName: "example.com/signer",
+				Subject:    &AddonRegistrationSubject{User: "agent"},
+				SigningCA:  "open-cluster-management-hub/ca-secret",
+			},
+		},
+	}
+
+	assert.Equal(t, "hello-template", template.AddonName)
+	assert.Len(t, template.AgentSpec.Workload, 1)
+	assert.Equal(t, "hello-template", template.Registration[0].HubPermissions[0].ClusterRoleName)
+	assert.Equal(t, "agent", template.Registration[1].Subject.User)
+}
+
+func TestAddonTemplateVersionModel(t *testing.T) {
+	version := AddonTemplateVersion{
+		Name:            "hello-template-v2",
+		DesiredSpecHash: "def",
+		RunningName:     "hello-template-v1",
+		RunningSpecHash: "abc",
+	}
+
+	assert.False(t, version.Applied)
+	assert.Equal(t, "hello-template-v1", version.RunningName)
+}
@@ -0,0 +1,22 @@
+package models
+
+// ApplyResult represents the outcome of applying one document of a multi-document YAML
+type ApplyResult struct {
+	Index      int    `json:"index"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Status     string `json:"status"` // "Applied", "Forbidden", "Failed"
+	Code       int    `json:"code"`   // HTTP status of the document, e.g. 403 when the user may not write it
+	Error      string `json:"error,omitempty"`
+}
+
+// ApplyResponse represents the per-document results of a server-side apply request
+type ApplyResponse struct {
+	FieldManager string        `json:"fieldManager"`
+	DryRun       bool          `json:"dryRun"`
+	Applied      int           `json:"applied"`
+	Failed       int           `json:"failed"`
+	Results      []ApplyResult `json:"results"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyResponseModel(t *testing.T) {
+	response := ApplyResponse{
+		FieldManager: "ocm-dashboard",
+		Applied:      1,
+		Failed:       1,
+		Results: []ApplyResult{
+			{Index: 0, APIVersion: "cluster.open-cluster-management.io/v1beta1", Kind: "Placement", Namespace: "default", Name: "test-placement", Status: "Applied"},
+			{Index: 1, Kind: "ConfigMap", Status: "Failed", Error: "unsupported kind"},
+		},
+	}
+
+	assert.Equal(t, "ocm-dashboard", response.FieldManager)
+	assert.False(t, response.DryRun)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, "Applied", response.Results[0].Status)
+	assert.Equal(t, "unsupported kind", response.Results[1].Error)
+}
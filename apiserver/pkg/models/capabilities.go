@@ -0,0 +1,27 @@
+package models
+
+// FeatureCapability reports whether a dashboard feature can be used with the hub
+type FeatureCapability struct {
+	Available bool   `json:"available"`
+	Optional  bool   `json:"optional"`
+	Message   string `json:"message,omitempty"`
+}
+
+// ResourceCapability lists the served and supported versions of an OCM resource.
+// Version is the supported version when it is served, empty otherwise.
+type ResourceCapability struct {
+	Group             string   `json:"group"`
+	Resource          string   `json:"resource"`
+	Version           string   `json:"version,omitempty"`
+	ServedVersions    []string `json:"servedVersions"`
+	SupportedVersions []string `json:"supportedVersions"`
+}
+
+// Capabilities reports the OCM APIs served by the hub and the features they
+// enable. Features are reported available while detection has not succeeded.
+type Capabilities struct {
+	Detected  bool                         `json:"detected"`
+	Error     string                       `json:"error,omitempty"`
+	Features  map[string]FeatureCapability `json:"features"`
+	Resources []ResourceCapability         `json:"resources"`
+}
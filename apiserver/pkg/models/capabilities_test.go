@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilitiesModel(t *testing.T) {
+	capabilities := Capabilities{
+		Detected: true,
+		Features: map[string]FeatureCapability{
+			"clusters": {Available: true},
+			"addons":   {Available: false, Optional: true, Message: "managedclusteraddons.addon.open-cluster-management.io is not installed on the hub"},
+		},
+		Resources: []ResourceCapability{
+			{
+				Group:             "cluster.open-cluster-management.io",
+				Resource:          "managedclustersets",
+				Version:           "v1beta2",
+				ServedVersions:    []string{"v1beta2"},
+				SupportedVersions: []string{"v1beta2"},
+			},
+		},
+	}
+
+	assert.True(t, capabilities.Detected)
+	assert.False(t, capabilities.Features["addons"].Available)
+	assert.True(t, capabilities.Features["addons"].Optional)
+	assert.Equal(t, "v1beta2", capabilities.Resources[0].Version)
+}
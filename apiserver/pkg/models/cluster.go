@@ -41,6 +41,7 @@ type Cluster struct {
 	Taints                      []Taint                      `json:"taints,omitempty"`
 	ManagedClusterClientConfigs []ManagedClusterClientConfig `json:"managedClusterClientConfigs,omitempty"`
 	CreationTimestamp           string                       `json:"creationTimestamp,omitempty"`
+	Hub                         string                       `json:"hub,omitempty"` // set by fan-out endpoints
 }
 
 // LabelSelector represents a Kubernetes label selector
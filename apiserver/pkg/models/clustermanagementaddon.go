@@ -0,0 +1,65 @@
+package models
+
+// AddonConfigReferent identifies a single addon configuration object
+type AddonConfigReferent struct {
+	Group     string `json:"group"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// AddonConfigType represents a configuration type supported by an addon with its default config
+type AddonConfigType struct {
+	Group         string               `json:"group"`
+	Resource      string               `json:"resource"`
+	DefaultConfig *AddonConfigReferent `json:"defaultConfig,omitempty"`
+}
+
+// AddonPlacementStrategy represents a placement the addon is installed on with its configs and rollout
+type AddonPlacementStrategy struct {
+	Namespace       string                `json:"namespace"`
+	Name            string                `json:"name"`
+	Configs         []AddonConfigReferent `json:"configs,omitempty"`
+	RolloutStrategy RolloutStrategy       `json:"rolloutStrategy"`
+}
+
+// AddonInstallStrategy represents how an addon is installed on the managed clusters
+type AddonInstallStrategy struct {
+	Type       string                   `json:"type"` // "Manual" or "Placements"
+	Placements []AddonPlacementStrategy `json:"placements,omitempty"`
+}
+
+// ClusterManagementAddon represents a simplified OCM ClusterManagementAddOn with its installations
+type ClusterManagementAddon struct {
+	ID                string               `json:"id"`
+	Name              string               `json:"name"`
+	DisplayName       string               `json:"displayName,omitempty"`
+	Description       string               `json:"description,omitempty"`
+	SupportedConfigs  []AddonConfigType    `json:"supportedConfigs,omitempty"`
+	InstallStrategy   AddonInstallStrategy `json:"installStrategy"`
+	InstalledCount    int                  `json:"installedCount"`
+	AvailableCount    int                  `json:"availableCount"`
+	Installations     []AddonHealth        `json:"installations"` // Namespace is the cluster name
+	CreationTimestamp string               `json:"creationTimestamp,omitempty"`
+}
+
+// AddonInstallRequest represents the clusters to install an addon on or uninstall it from
+type AddonInstallRequest struct {
+	Clusters         []string `json:"clusters"`
+	InstallNamespace string   `json:"installNamespace,omitempty"`
+}
+
+// AddonInstallResult represents the outcome of installing or uninstalling an addon on one cluster
+type AddonInstallResult struct {
+	ClusterName string `json:"clusterName"`
+	Action      string `json:"action"` // "Installed", "AlreadyInstalled", "Uninstalled", "NotInstalled", "Forbidden", "Failed"
+	Error       string `json:"error,omitempty"`
+}
+
+// AddonInstallResponse represents the per-cluster results of an install or uninstall request
+type AddonInstallResponse struct {
+	AddonName string               `json:"addonName"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []AddonInstallResult `json:"results"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterManagementAddonModel(t *testing.T) {
+	addon := ClusterManagementAddon{
+		ID:          "test-id",
+		Name:        "application-manager",
+		DisplayName: "Application Manager",
+		SupportedConfigs: []AddonConfigType{
+			{
+				Group:    "addon.open-cluster-management.io",
+				Resource: "addondeploymentconfigs",
+				DefaultConfig: &AddonConfigReferent{
+					Group:     "addon.open-cluster-management.io",
+					Resource:  "addondeploymentconfigs",
+					Namespace: "open-cluster-management",
+					Name:      "default",
+				},
+			},
+		},
+		InstallStrategy: AddonInstallStrategy{
+			Type: "Placements",
+			Placements: []AddonPlacementStrategy{
+				{Namespace: "default", Name: "all", RolloutStrategy: RolloutStrategy{Type: "All"}},
+			},
+		},
+		InstalledCount: 1,
+		AvailableCount: 1,
+		Installations: []AddonHealth{
+			{Name: "application-manager", Namespace: "cluster1", Status: "Available"},
+		},
+	}
+
+	assert.Equal(t, "application-manager", addon.Name)
+	assert.Equal(t, "default", addon.SupportedConfigs[0].DefaultConfig.Name)
+	assert.Equal(t, "Placements", addon.InstallStrategy.Type)
+	assert.Equal(t, "all", addon.InstallStrategy.Placements[0].Name)
+	assert.Len(t, addon.Installations, 1)
+	assert.Equal(t, "cluster1", addon.Installations[0].Namespace)
+}
+
+func TestAddonInstallResponseModel(t *testing.T) {
+	response := AddonInstallResponse{
+		AddonName: "application-manager",
+		Succeeded: 1,
+		Failed:    1,
+		Results: []AddonInstallResult{
+			{ClusterName: "cluster1", Action: "Installed"},
+			{ClusterName: "cluster2", Action: "Forbidden", Error: "not allowed"},
+		},
+	}
+
+	assert.Equal(t, "application-manager", response.AddonName)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, "Forbidden", response.Results[1].Action)
+}
@@ -0,0 +1,20 @@
+package models
+
+// ClusterDeployResult represents the outcome of deploying a ManifestWork to one cluster
+type ClusterDeployResult struct {
+	ClusterName  string `json:"clusterName"`
+	ManifestWork string `json:"manifestWork"`
+	Action       string `json:"action"` // "Created", "Updated", "Unchanged", "Deleted", "Failed"
+	Error        string `json:"error,omitempty"`
+}
+
+// DeployResult represents the per-cluster results of deploying a workload to a placement
+type DeployResult struct {
+	PlacementName    string                `json:"placementName"`
+	Namespace        string                `json:"namespace"`
+	ManifestWorkName string                `json:"manifestWorkName"`
+	Follow           bool                  `json:"follow"`
+	Succeeded        int                   `json:"succeeded"`
+	Failed           int                   `json:"failed"`
+	Results          []ClusterDeployResult `json:"results"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployResultModel(t *testing.T) {
+	result := DeployResult{
+		PlacementName:    "test-placement",
+		Namespace:        "default",
+		ManifestWorkName: "default.test-placement",
+		Follow:           true,
+		Succeeded:        1,
+		Failed:           1,
+		Results: []ClusterDeployResult{
+			{ClusterName: "cluster1", ManifestWork: "default.test-placement", Action: "Created"},
+			{ClusterName: "cluster2", ManifestWork: "default.test-placement", Action: "Failed", Error: "forbidden"},
+		},
+	}
+
+	assert.Equal(t, "test-placement", result.PlacementName)
+	assert.True(t, result.Follow)
+	assert.Len(t, result.Results, 2)
+	assert.Equal(t, "Created", result.Results[0].Action)
+	assert.Equal(t, "forbidden", result.Results[1].Error)
+}
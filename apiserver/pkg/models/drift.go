@@ -0,0 +1,26 @@
+package models
+
+// FieldDrift represents a field whose live value differs from the value in the ManifestWork
+type FieldDrift struct {
+	Feedback string      `json:"feedback"` // name of the feedback value the live value came from
+	Path     string      `json:"path"`
+	Desired  interface{} `json:"desired"`
+	Live     interface{} `json:"live"`
+}
+
+// ResourceDrift represents the drift of a single resource of a ManifestWork
+type ResourceDrift struct {
+	ResourceMeta  ManifestResourceMeta `json:"resourceMeta"`
+	Drifted       bool                 `json:"drifted"`
+	Differences   []FieldDrift         `json:"differences"`
+	CheckedFields []string             `json:"checkedFields,omitempty"` // feedback values compared with the manifest
+	SkippedFields []string             `json:"skippedFields,omitempty"` // feedback values without a desired value in the manifest
+}
+
+// DriftReport represents the field-level drift of every resource of a ManifestWork
+type DriftReport struct {
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace"`
+	Drifted   bool            `json:"drifted"`
+	Resources []ResourceDrift `json:"resources"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriftReportModel(t *testing.T) {
+	report := DriftReport{
+		Name:      "test-manifestwork",
+		Namespace: "cluster1",
+		Drifted:   true,
+		Resources: []ResourceDrift{
+			{
+				ResourceMeta: ManifestResourceMeta{Kind: "Deployment", Name: "test-deployment"},
+				Drifted:      true,
+				Differences: []FieldDrift{
+					{Feedback: "replicas", Path: ".spec.replicas", Desired: float64(3), Live: float64(5)},
+				},
+				CheckedFields: []string{"replicas"},
+				SkippedFields: []string{"readyReplicas"},
+			},
+		},
+	}
+
+	assert.True(t, report.Drifted)
+	assert.Len(t, report.Resources, 1)
+	assert.Equal(t, "Deployment", report.Resources[0].ResourceMeta.Kind)
+	assert.Equal(t, ".spec.replicas", report.Resources[0].Differences[0].Path)
+	assert.Equal(t, float64(5), report.Resources[0].Differences[0].Live)
+	assert.Equal(t, []string{"readyReplicas"}, report.Resources[0].SkippedFields)
+}
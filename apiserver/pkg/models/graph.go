@@ -0,0 +1,23 @@
+package models
+
+// GraphNode represents an OCM resource in the dependency graph
+type GraphNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GraphEdge represents a directed relationship between two resources
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// Graph represents the dependency graph across OCM resources
+type Graph struct {
+	Nodes  []GraphNode       `json:"nodes"`
+	Edges  []GraphEdge       `json:"edges"`
+	Errors map[string]string `json:"errors,omitempty"` // partial errors keyed by resource
+}
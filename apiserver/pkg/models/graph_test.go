@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphModel(t *testing.T) {
+	graph := Graph{
+		Nodes: []GraphNode{
+			{ID: "ManagedCluster/cluster1", Kind: "ManagedCluster", Name: "cluster1"},
+			{ID: "ManifestWork/cluster1/work1", Kind: "ManifestWork", Name: "work1", Namespace: "cluster1"},
+		},
+		Edges: []GraphEdge{
+			{From: "ManifestWork/cluster1/work1", To: "ManagedCluster/cluster1", Type: "deploysTo"},
+		},
+	}
+
+	assert.Len(t, graph.Nodes, 2)
+	assert.Equal(t, "cluster1", graph.Nodes[1].Namespace)
+	assert.Equal(t, "deploysTo", graph.Edges[0].Type)
+	assert.Nil(t, graph.Errors)
+}
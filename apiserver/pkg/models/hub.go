@@ -0,0 +1,24 @@
+package models
+
+// Hub connectivity statuses
+const (
+	HubConnected   = "connected"
+	HubUnreachable = "unreachable"
+)
+
+// Hub represents a configured OCM hub and whether the dashboard can reach it
+type Hub struct {
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+	Default bool   `json:"default"`
+	Status  string `json:"status"`
+	Version string `json:"version,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// FleetClusters represents the clusters of every hub, each tagged with its hub.
+// Hubs whose clusters could not be listed are reported in Errors.
+type FleetClusters struct {
+	Clusters []Cluster         `json:"clusters"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
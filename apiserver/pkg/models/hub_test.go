@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHubModel(t *testing.T) {
+	hub := Hub{
+		Name:    "east",
+		Source:  "context:east-admin",
+		Default: true,
+		Status:  HubConnected,
+		Version: "v1.30.0",
+	}
+
+	assert.Equal(t, "east", hub.Name)
+	assert.True(t, hub.Default)
+	assert.Equal(t, HubConnected, hub.Status)
+}
+
+func TestFleetClustersModel(t *testing.T) {
+	fleet := FleetClusters{
+		Clusters: []Cluster{{ID: "cluster1", Name: "cluster1", Hub: "east"}},
+		Errors:   map[string]string{"west": "connection refused"},
+	}
+
+	assert.Len(t, fleet.Clusters, 1)
+	assert.Equal(t, "east", fleet.Clusters[0].Hub)
+	assert.Equal(t, "connection refused", fleet.Errors["west"])
+}
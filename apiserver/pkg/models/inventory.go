@@ -0,0 +1,36 @@
+package models
+
+// AddonHealth represents the health of an addon derived from its conditions
+type AddonHealth struct {
+	Name             string      `json:"name"`
+	Namespace        string      `json:"namespace"`
+	InstallNamespace string      `json:"installNamespace,omitempty"`
+	Status           string      `json:"status"` // "Available", "Degraded", "Progressing", "Unavailable", "Unknown"
+	Conditions       []Condition `json:"conditions,omitempty"`
+}
+
+// ManifestWorkResourceStatus represents the apply status of a single resource of a ManifestWork
+type ManifestWorkResourceStatus struct {
+	ResourceMeta ManifestResourceMeta `json:"resourceMeta"`
+	Applied      string               `json:"applied"`   // status of the Applied condition
+	Available    string               `json:"available"` // status of the Available condition
+}
+
+// ManifestWorkSummary represents a ManifestWork with its overall and per-resource status
+type ManifestWorkSummary struct {
+	Name       string                       `json:"name"`
+	Namespace  string                       `json:"namespace"`
+	Status     string                       `json:"status"` // "Applied", "Available", "Degraded", "Progressing", "Failed", "Unknown"
+	Conditions []Condition                  `json:"conditions,omitempty"`
+	Resources  []ManifestWorkResourceStatus `json:"resources,omitempty"`
+}
+
+// ClusterInventory represents everything that runs on, or targets, a single cluster
+type ClusterInventory struct {
+	Cluster       *Cluster              `json:"cluster,omitempty"`
+	Addons        []AddonHealth         `json:"addons"`
+	ManifestWorks []ManifestWorkSummary `json:"manifestWorks"`
+	ClusterSets   []string              `json:"clusterSets"`
+	Placements    []ClusterPlacement    `json:"placements"`
+	Errors        map[string]string     `json:"errors,omitempty"` // partial errors keyed by section
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterInventoryModel(t *testing.T) {
+	inventory := ClusterInventory{
+		Cluster: &Cluster{Name: "cluster1"},
+		Addons: []AddonHealth{
+			{Name: "application-manager", Namespace: "cluster1", Status: "Available"},
+		},
+		ManifestWorks: []ManifestWorkSummary{
+			{
+				Name:      "work1",
+				Namespace: "cluster1",
+				Status:    "Applied",
+				Resources: []ManifestWorkResourceStatus{
+					{ResourceMeta: ManifestResourceMeta{Kind: "Deployment", Name: "app"}, Applied: "True", Available: "Unknown"},
+				},
+			},
+		},
+		ClusterSets: []string{"default"},
+		Placements:  []ClusterPlacement{{PlacementName: "placement1", Namespace: "default"}},
+		Errors:      map[string]string{"addons": "forbidden"},
+	}
+
+	assert.Equal(t, "cluster1", inventory.Cluster.Name)
+	assert.Equal(t, "Available", inventory.Addons[0].Status)
+	assert.Equal(t, "True", inventory.ManifestWorks[0].Resources[0].Applied)
+	assert.Equal(t, []string{"default"}, inventory.ClusterSets)
+	assert.Equal(t, "placement1", inventory.Placements[0].PlacementName)
+	assert.Equal(t, "forbidden", inventory.Errors["addons"])
+}
@@ -8,6 +8,8 @@ type ManifestWork struct {
 	Labels            map[string]string      `json:"labels,omitempty"`
 	Manifests         []Manifest             `json:"manifests,omitempty"`
 	Conditions        []Condition            `json:"conditions,omitempty"`
+	DeleteOption      *DeleteOption          `json:"deleteOption,omitempty"`
+	ManifestConfigs   []ManifestConfigOption `json:"manifestConfigs,omitempty"`
 	ResourceStatus    ManifestResourceStatus `json:"resourceStatus,omitempty"`
 	CreationTimestamp string                 `json:"creationTimestamp,omitempty"`
 }
@@ -24,8 +26,34 @@ type ManifestResourceStatus struct {
 
 // ManifestCondition represents the conditions of resources deployed on a managed cluster
 type ManifestCondition struct {
-	ResourceMeta ManifestResourceMeta `json:"resourceMeta"`
-	Conditions   []Condition          `json:"conditions"`
+	ResourceMeta    ManifestResourceMeta `json:"resourceMeta"`
+	StatusFeedbacks []FeedbackValue      `json:"statusFeedbacks,omitempty"`
+	Conditions      []Condition          `json:"conditions"`
+}
+
+// FeedbackValue represents a status value returned by a feedback rule
+type FeedbackValue struct {
+	Name  string     `json:"name"`
+	Value FieldValue `json:"fieldValue"`
+}
+
+// FieldValue holds the value of a status feedback, only the field matching Type is set
+type FieldValue struct {
+	Type    string  `json:"type"`
+	Integer *int64  `json:"integer,omitempty"`
+	String  *string `json:"string,omitempty"`
+	Boolean *bool   `json:"boolean,omitempty"`
+	JsonRaw *string `json:"jsonRaw,omitempty"`
+}
+
+// ManifestWorkResource represents a single manifest of a ManifestWork together with its status
+type ManifestWorkResource struct {
+	Ordinal         int32                 `json:"ordinal"`
+	Manifest        Manifest              `json:"manifest"`
+	ResourceMeta    *ManifestResourceMeta `json:"resourceMeta,omitempty"`
+	Conditions      []Condition           `json:"conditions,omitempty"`
+	StatusFeedbacks []FeedbackValue       `json:"statusFeedbacks,omitempty"`
+	ManifestConfig  *ManifestConfigOption `json:"manifestConfig,omitempty"`
 }
 
 // ManifestResourceMeta represents the metadata of a resource in a manifest
@@ -116,3 +144,10 @@ type JsonPath struct {
 type ManifestWorkList struct {
 	Items []ManifestWork `json:"items"`
 }
+
+// ManifestWorkFleet represents ManifestWorks listed across all cluster namespaces
+type ManifestWorkFleet struct {
+	Items        []ManifestWorkSummary `json:"items"`
+	Total        int                   `json:"total"`
+	StatusCounts map[string]int        `json:"statusCounts"` // histogram of the overall status, before the status filter
+}
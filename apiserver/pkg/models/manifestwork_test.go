@@ -309,3 +309,49 @@ func TestManifestWorkListModel(t *testing.T) {
 	assert.Equal(t, "manifestwork-1", list.Items[0].Name)
 	assert.Equal(t, "work2", list.Items[1].ID)
 }
+
+func TestFeedbackValueModel(t *testing.T) {
+	replicas := int64(2)
+	raw := `{"ready":true}`
+	feedbacks := []FeedbackValue{
+		{Name: "replicas", Value: FieldValue{Type: "Integer", Integer: &replicas}},
+		{Name: "status", Value: FieldValue{Type: "JsonRaw", JsonRaw: &raw}},
+	}
+
+	assert.Equal(t, "replicas", feedbacks[0].Name)
+	assert.Equal(t, int64(2), *feedbacks[0].Value.Integer)
+	assert.Nil(t, feedbacks[0].Value.String)
+	assert.Equal(t, "JsonRaw", feedbacks[1].Value.Type)
+	assert.Equal(t, `{"ready":true}`, *feedbacks[1].Value.JsonRaw)
+}
+
+func TestManifestWorkResourceModel(t *testing.T) {
+	resource := ManifestWorkResource{
+		Ordinal: 1,
+		Manifest: Manifest{
+			RawExtension: map[string]interface{}{"kind": "ConfigMap"},
+		},
+		ResourceMeta: &ManifestResourceMeta{Ordinal: 1, Kind: "ConfigMap", Resource: "configmaps", Name: "test-config"},
+		Conditions:   []Condition{{Type: "Applied", Status: "True"}},
+	}
+
+	assert.Equal(t, int32(1), resource.Ordinal)
+	assert.Equal(t, "ConfigMap", resource.Manifest.RawExtension["kind"])
+	assert.Equal(t, "configmaps", resource.ResourceMeta.Resource)
+	assert.Len(t, resource.Conditions, 1)
+	assert.Nil(t, resource.ManifestConfig)
+}
+
+func TestManifestWorkFleetModel(t *testing.T) {
+	fleet := ManifestWorkFleet{
+		Items: []ManifestWorkSummary{
+			{Name: "app", Namespace: "cluster1", Status: "Failed"},
+		},
+		Total:        1,
+		StatusCounts: map[string]int{"Failed": 1, "Available": 2},
+	}
+
+	assert.Len(t, fleet.Items, 1)
+	assert.Equal(t, 1, fleet.Total)
+	assert.Equal(t, 2, fleet.StatusCounts["Available"])
+}
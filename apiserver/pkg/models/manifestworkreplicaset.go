@@ -0,0 +1,54 @@
+package models
+
+// ManifestWorkReplicaSet represents a simplified version of the OCM ManifestWorkReplicaSet resource
+type ManifestWorkReplicaSet struct {
+	ID                   string                        `json:"id"`
+	Name                 string                        `json:"name"`
+	Namespace            string                        `json:"namespace"`
+	Labels               map[string]string             `json:"labels,omitempty"`
+	ManifestWorkTemplate ManifestWorkSpec              `json:"manifestWorkTemplate"`
+	PlacementRefs        []LocalPlacementReference     `json:"placementRefs,omitempty"`
+	Conditions           []Condition                   `json:"conditions,omitempty"`
+	Summary              ManifestWorkReplicaSetSummary `json:"summary"`
+	PlacementSummaries   []PlacementSummary            `json:"placementSummaries,omitempty"`
+	ManifestWorks        []ManifestWorkSummary         `json:"manifestWorks,omitempty"` // generated per cluster, only set on get
+	CreationTimestamp    string                        `json:"creationTimestamp,omitempty"`
+}
+
+// LocalPlacementReference represents a placement in the same namespace and its rollout strategy
+type LocalPlacementReference struct {
+	Name            string          `json:"name"`
+	RolloutStrategy RolloutStrategy `json:"rolloutStrategy"`
+}
+
+// RolloutStrategy represents how ManifestWorks are rolled out to the placement decisions
+type RolloutStrategy struct {
+	Type                    string                   `json:"type"` // "All", "Progressive", "ProgressivePerGroup"
+	MinSuccessTime          string                   `json:"minSuccessTime,omitempty"`
+	ProgressDeadline        string                   `json:"progressDeadline,omitempty"`
+	MaxFailures             string                   `json:"maxFailures,omitempty"`
+	MaxConcurrency          string                   `json:"maxConcurrency,omitempty"`
+	MandatoryDecisionGroups []MandatoryDecisionGroup `json:"mandatoryDecisionGroups,omitempty"`
+}
+
+// MandatoryDecisionGroup identifies a decision group that must succeed before the rollout continues
+type MandatoryDecisionGroup struct {
+	GroupName  string `json:"groupName,omitempty"`
+	GroupIndex int32  `json:"groupIndex,omitempty"`
+}
+
+// ManifestWorkReplicaSetSummary represents the counts of ManifestWorks by condition
+type ManifestWorkReplicaSetSummary struct {
+	Total       int `json:"total"`
+	Applied     int `json:"applied"`
+	Available   int `json:"available"`
+	Progressing int `json:"progressing"`
+	Degraded    int `json:"degraded"`
+}
+
+// PlacementSummary represents the rollout summary for a single placement
+type PlacementSummary struct {
+	Name                    string                        `json:"name"`
+	AvailableDecisionGroups string                        `json:"availableDecisionGroups,omitempty"`
+	Summary                 ManifestWorkReplicaSetSummary `json:"summary"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestWorkReplicaSetModel(t *testing.T) {
+	mwrs := ManifestWorkReplicaSet{
+		ID:        "test-id",
+		Name:      "test-mwrs",
+		Namespace: "default",
+		ManifestWorkTemplate: ManifestWorkSpec{
+			Workload: []Manifest{
+				{RawExtension: map[string]interface{}{"kind": "ConfigMap"}},
+			},
+		},
+		PlacementRefs: []LocalPlacementReference{
+			{
+				Name: "test-placement",
+				RolloutStrategy: RolloutStrategy{
+					Type:           "Progressive",
+					MaxConcurrency: "25%",
+					MandatoryDecisionGroups: []MandatoryDecisionGroup{
+						{GroupName: "canary"},
+					},
+				},
+			},
+		},
+		Summary: ManifestWorkReplicaSetSummary{Total: 3, Applied: 3, Available: 2, Progressing: 1},
+		PlacementSummaries: []PlacementSummary{
+			{Name: "test-placement", AvailableDecisionGroups: "1 (1 / 2 clusters applied)"},
+		},
+		ManifestWorks: []ManifestWorkSummary{
+			{Name: "test-mwrs", Namespace: "cluster1", Status: "Available"},
+		},
+	}
+
+	assert.Equal(t, "test-mwrs", mwrs.Name)
+	assert.Len(t, mwrs.ManifestWorkTemplate.Workload, 1)
+	assert.Equal(t, "Progressive", mwrs.PlacementRefs[0].RolloutStrategy.Type)
+	assert.Equal(t, "canary", mwrs.PlacementRefs[0].RolloutStrategy.MandatoryDecisionGroups[0].GroupName)
+	assert.Equal(t, 3, mwrs.Summary.Total)
+	assert.Equal(t, "test-placement", mwrs.PlacementSummaries[0].Name)
+	assert.Equal(t, "cluster1", mwrs.ManifestWorks[0].Namespace)
+}
@@ -126,13 +126,70 @@ type ClusterDecision struct {
 
 // PlacementDecision represents a simplified OCM PlacementDecision
 type PlacementDecision struct {
-	ID        string            `json:"id"`
-	Name      string            `json:"name"`
-	Namespace string            `json:"namespace"`
-	Decisions []ClusterDecision `json:"decisions,omitempty"`
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"`
+	Namespace          string            `json:"namespace"`
+	PlacementName      string            `json:"placementName,omitempty"`
+	DecisionGroupIndex int32             `json:"decisionGroupIndex"`
+	DecisionGroupName  string            `json:"decisionGroupName,omitempty"`
+	Decisions          []ClusterDecision `json:"decisions,omitempty"`
+}
+
+// PlacementDecisionGroup represents the cluster decisions of a single decision group
+type PlacementDecisionGroup struct {
+	DecisionGroupIndex int32             `json:"decisionGroupIndex"`
+	DecisionGroupName  string            `json:"decisionGroupName,omitempty"`
+	DecisionNames      []string          `json:"decisionNames"`
+	Decisions          []ClusterDecision `json:"decisions"`
+}
+
+// MergedPlacementDecision represents all PlacementDecisions of a placement merged
+// into one view, ordered by decision group index
+type MergedPlacementDecision struct {
+	PlacementName string                   `json:"placementName"`
+	Namespace     string                   `json:"namespace"`
+	ClusterCount  int                      `json:"clusterCount"`
+	Groups        []PlacementDecisionGroup `json:"groups"`
+	Decisions     []ClusterDecision        `json:"decisions"`
+}
+
+// ClusterPlacement represents a placement that currently selects a given cluster
+type ClusterPlacement struct {
+	PlacementName      string `json:"placementName"`
+	Namespace          string `json:"namespace"`
+	DecisionName       string `json:"decisionName"`
+	DecisionGroupIndex int32  `json:"decisionGroupIndex"`
+	DecisionGroupName  string `json:"decisionGroupName,omitempty"`
+	Reason             string `json:"reason,omitempty"`
 }
 
 // Helper function to create a pointer to an int32
 func IntPtr(i int32) *int32 {
 	return &i
 }
+
+// PlacementDecisionChange represents a change in the set of clusters selected by a placement
+type PlacementDecisionChange struct {
+	Timestamp string   `json:"timestamp"`
+	Added     []string `json:"added,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+	Clusters  []string `json:"clusters"`
+}
+
+// PlacementChurn summarizes how often the decided clusters of a placement change
+type PlacementChurn struct {
+	Window          string  `json:"window"`
+	Changes         int     `json:"changes"`
+	ClustersAdded   int     `json:"clustersAdded"`
+	ClustersRemoved int     `json:"clustersRemoved"`
+	ChangesPerHour  float64 `json:"changesPerHour"`
+}
+
+// PlacementDecisionHistory represents the recorded decision changes of a placement
+type PlacementDecisionHistory struct {
+	Namespace string                    `json:"namespace"`
+	Name      string                    `json:"name"`
+	Clusters  []string                  `json:"clusters"`
+	Changes   []PlacementDecisionChange `json:"changes"`
+	Churn     PlacementChurn            `json:"churn"`
+}
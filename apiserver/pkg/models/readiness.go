@@ -0,0 +1,21 @@
+package models
+
+// Readiness check statuses
+const (
+	ReadinessOK     = "ok"
+	ReadinessFailed = "failed"
+)
+
+// ReadinessCheck represents the result of a single readiness check
+type ReadinessCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Readiness represents the overall readiness of the dashboard, checks are only
+// listed when verbose output is requested or a check failed
+type Readiness struct {
+	Status string           `json:"status"`
+	Checks []ReadinessCheck `json:"checks,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadinessModel(t *testing.T) {
+	readiness := Readiness{
+		Status: ReadinessFailed,
+		Checks: []ReadinessCheck{
+			{Name: "hub", Status: ReadinessOK, Message: "v1.30.0"},
+			{Name: "crd/placements.cluster.open-cluster-management.io", Status: ReadinessFailed, Message: "not served"},
+		},
+	}
+
+	assert.Equal(t, ReadinessFailed, readiness.Status)
+	assert.Len(t, readiness.Checks, 2)
+	assert.Equal(t, "hub", readiness.Checks[0].Name)
+}
@@ -0,0 +1,49 @@
+package models
+
+// RolloutCounts represents the number of clusters in each rollout state
+type RolloutCounts struct {
+	Total       int `json:"total"`
+	Succeeded   int `json:"succeeded"`
+	Progressing int `json:"progressing"`
+	Failed      int `json:"failed"`
+	TimedOut    int `json:"timedOut"`
+	Pending     int `json:"pending"`
+}
+
+// ClusterRolloutStatus represents the rollout state of a single cluster
+type ClusterRolloutStatus struct {
+	ClusterName        string `json:"clusterName"`
+	Status             string `json:"status"` // "Succeeded", "Progressing", "Failed", "TimedOut", "Pending"
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+	Message            string `json:"message,omitempty"`
+}
+
+// DecisionGroupRollout represents the rollout progress of a placement decision group
+type DecisionGroupRollout struct {
+	DecisionGroupIndex int32                  `json:"decisionGroupIndex"`
+	DecisionGroupName  string                 `json:"decisionGroupName,omitempty"`
+	Phase              string                 `json:"phase"`
+	Counts             RolloutCounts          `json:"counts"`
+	Clusters           []ClusterRolloutStatus `json:"clusters"`
+}
+
+// PlacementRollout represents the rollout progress through the decision groups of one placement
+type PlacementRollout struct {
+	PlacementName   string                 `json:"placementName"`
+	RolloutStrategy RolloutStrategy        `json:"rolloutStrategy"`
+	Phase           string                 `json:"phase"`
+	Counts          RolloutCounts          `json:"counts"`
+	Groups          []DecisionGroupRollout `json:"groups"`
+	Error           string                 `json:"error,omitempty"`
+}
+
+// RolloutProgress represents the rollout progress of a ManifestWorkReplicaSet
+type RolloutProgress struct {
+	Name                string             `json:"name"`
+	Namespace           string             `json:"namespace"`
+	Phase               string             `json:"phase"` // "Pending", "Progressing", "Succeeded", "Failed"
+	Counts              RolloutCounts      `json:"counts"`
+	Placements          []PlacementRollout `json:"placements"`
+	StartTime           string             `json:"startTime,omitempty"`
+	EstimatedCompletion string             `json:"estimatedCompletion,omitempty"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolloutProgressModel(t *testing.T) {
+	progress := RolloutProgress{
+		Name:      "test-mwrs",
+		Namespace: "default",
+		Phase:     "Progressing",
+		Counts:    RolloutCounts{Total: 3, Succeeded: 1, Progressing: 1, Pending: 1},
+		Placements: []PlacementRollout{
+			{
+				PlacementName:   "test-placement",
+				RolloutStrategy: RolloutStrategy{Type: "ProgressivePerGroup"},
+				Phase:           "Progressing",
+				Groups: []DecisionGroupRollout{
+					{
+						DecisionGroupIndex: 0,
+						DecisionGroupName:  "canary",
+						Phase:              "Succeeded",
+						Clusters: []ClusterRolloutStatus{
+							{ClusterName: "cluster1", Status: "Succeeded"},
+						},
+					},
+				},
+			},
+		},
+		EstimatedCompletion: "2024-01-01T00:10:00Z",
+	}
+
+	assert.Equal(t, "Progressing", progress.Phase)
+	assert.Equal(t, 3, progress.Counts.Total)
+	assert.Equal(t, "ProgressivePerGroup", progress.Placements[0].RolloutStrategy.Type)
+	assert.Equal(t, "canary", progress.Placements[0].Groups[0].DecisionGroupName)
+	assert.Equal(t, "cluster1", progress.Placements[0].Groups[0].Clusters[0].ClusterName)
+	assert.Equal(t, "2024-01-01T00:10:00Z", progress.EstimatedCompletion)
+}
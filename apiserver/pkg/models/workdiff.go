@@ -0,0 +1,38 @@
+package models
+
+// FieldDiff represents a field that differs between the current and proposed manifest
+type FieldDiff struct {
+	Path      string      `json:"path"`
+	Operation string      `json:"operation"` // "add", "remove", "replace"
+	Current   interface{} `json:"current,omitempty"`
+	Proposed  interface{} `json:"proposed,omitempty"`
+}
+
+// ManifestDiff represents the change of a single manifest, matched by group, kind, namespace and name
+type ManifestDiff struct {
+	Group         string      `json:"group,omitempty"`
+	Version       string      `json:"version,omitempty"`
+	Kind          string      `json:"kind"`
+	Resource      string      `json:"resource,omitempty"`
+	Namespace     string      `json:"namespace,omitempty"`
+	Name          string      `json:"name"`
+	Change        string      `json:"change"` // "Added", "Removed", "Modified", "Unchanged"
+	Differences   []FieldDiff `json:"differences,omitempty"`
+	UnifiedDiff   string      `json:"unifiedDiff,omitempty"`
+	RemovalAction string      `json:"removalAction,omitempty"` // "Deleted" or "Orphaned" for removed manifests
+}
+
+// ManifestWorkDiff represents the preview of updating a ManifestWork to a proposed spec
+type ManifestWorkDiff struct {
+	Name                string               `json:"name"`
+	Namespace           string               `json:"namespace"`
+	Changed             bool                 `json:"changed"`
+	Added               int                  `json:"added"`
+	Removed             int                  `json:"removed"`
+	Modified            int                  `json:"modified"`
+	Unchanged           int                  `json:"unchanged"`
+	DeleteOptionChanged bool                 `json:"deleteOptionChanged"`
+	DeletedResources    []ResourceIdentifier `json:"deletedResources,omitempty"`  // removed resources deleted from the cluster
+	OrphanedResources   []ResourceIdentifier `json:"orphanedResources,omitempty"` // removed resources left on the cluster
+	Manifests           []ManifestDiff       `json:"manifests"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestWorkDiffModel(t *testing.T) {
+	diff := ManifestWorkDiff{
+		Name:      "test-work",
+		Namespace: "cluster1",
+		Changed:   true,
+		Modified:  1,
+		Removed:   1,
+		OrphanedResources: []ResourceIdentifier{
+			{Resource: "configmaps", Name: "config", Namespace: "default"},
+		},
+		Manifests: []ManifestDiff{
+			{
+				Group:       "apps",
+				Version:     "v1",
+				Kind:        "Deployment",
+				Namespace:   "default",
+				Name:        "web",
+				Change:      "Modified",
+				Differences: []FieldDiff{{Path: "spec.replicas", Operation: "replace", Current: 1, Proposed: 3}},
+			},
+			{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "config", Change: "Removed", RemovalAction: "Orphaned"},
+		},
+	}
+
+	assert.True(t, diff.Changed)
+	assert.Len(t, diff.Manifests, 2)
+	assert.Equal(t, "spec.replicas", diff.Manifests[0].Differences[0].Path)
+	assert.Equal(t, "Orphaned", diff.Manifests[1].RemovalAction)
+	assert.Equal(t, "configmaps", diff.OrphanedResources[0].Resource)
+}
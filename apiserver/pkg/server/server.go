@@ -2,29 +2,46 @@ package server
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/config"
+	"open-cluster-management-io/lab/apiserver/pkg/deploy"
 	"open-cluster-management-io/lab/apiserver/pkg/handlers"
+	"open-cluster-management-io/lab/apiserver/pkg/history"
+	"open-cluster-management-io/lab/apiserver/pkg/logging"
+	"open-cluster-management-io/lab/apiserver/pkg/metrics"
+	"open-cluster-management-io/lab/apiserver/pkg/tracing"
 
 	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 )
 
-// validateToken validates a Bearer token using Kubernetes TokenReview API
-func validateToken(token string, ocmClient *client.OCMClient, ctx context.Context) bool {
+// validateToken validates a Bearer token using Kubernetes TokenReview API and
+// returns the authenticated user
+func validateToken(token string, ocmClient *client.OCMClient, ctx context.Context) (authv1.UserInfo, bool) {
 	if ocmClient == nil || ocmClient.KubernetesClient == nil {
-		log.Println("OCM client or Kubernetes client is nil")
-		return false
+		logging.FromContext(ctx).Error("Cannot validate token, OCM client or Kubernetes client is nil")
+		return authv1.UserInfo{}, false
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "TokenReview")
+	defer span.End()
+
 	// Create TokenReview request
 	tokenReview := &authv1.TokenReview{
 		Spec: authv1.TokenReviewSpec{
@@ -33,185 +50,516 @@ func validateToken(token string, ocmClient *client.OCMClient, ctx context.Contex
 	}
 
 	// Send TokenReview to Kubernetes API
+	start := time.Now()
 	result, err := ocmClient.KubernetesClient.AuthenticationV1().TokenReviews().Create(ctx, tokenReview, metav1.CreateOptions{})
 	if err != nil {
-		log.Printf("TokenReview API call failed: %v", err)
-		return false
+		metrics.ObserveTokenReview(metrics.TokenReviewError, time.Since(start))
+		tracing.RecordError(span, err)
+		logging.FromContext(ctx).Error("TokenReview API call failed", "error", err)
+		return authv1.UserInfo{}, false
 	}
 
 	// Check if token is authenticated
 	if !result.Status.Authenticated {
-		log.Printf("Token not authenticated: %s", result.Status.Error)
+		metrics.ObserveTokenReview(metrics.TokenReviewUnauthenticated, time.Since(start))
+		span.SetAttributes(attribute.Bool("authenticated", false))
+		logging.FromContext(ctx).Info("Token not authenticated", "reason", result.Status.Error)
+		return authv1.UserInfo{}, false
+	}
+	metrics.ObserveTokenReview(metrics.TokenReviewAuthenticated, time.Since(start))
+	span.SetAttributes(attribute.Bool("authenticated", true))
+
+	logging.FromContext(ctx).Debug("Token authenticated", "user", result.Status.User.Username)
+	return result.Status.User, true
+}
+
+// bearerToken reads the bearer token of the request. It writes the error
+// response and returns false if the Authorization header is missing or malformed.
+func bearerToken(c *gin.Context) (string, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		logging.FromContext(c.Request.Context()).Info("Authorization header missing")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		return "", false
+	}
+
+	// Extract token from "Bearer <token>" format
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		logging.FromContext(c.Request.Context()).Info("Invalid authorization header format")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format. Expected: Bearer <token>"})
+		return "", false
+	}
+	return tokenParts[1], true
+}
+
+// authenticate checks the bearer token of the request and records the
+// authenticated user. It writes the error response and returns false if the
+// request must not proceed.
+func authenticate(c *gin.Context, ocmClient *client.OCMClient, authMode string) bool {
+	// Check if authentication is bypassed
+	if authMode == config.AuthModeNone {
+		logging.FromContext(c.Request.Context()).Debug("Authentication bypassed (auth mode none)")
+		return true
+	}
+
+	token, ok := bearerToken(c)
+	if !ok {
+		return false
+	}
+
+	// Validate token using Kubernetes TokenReview API
+	user, ok := validateToken(token, ocmClient, c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 		return false
 	}
 
-	log.Printf("Token authenticated for user: %s", result.Status.User.Username)
+	logging.SetUser(c.Request.Context(), user.Username)
+	c.Set(handlers.UserInfoKey, user)
 	return true
 }
 
-// min returns the minimum of two integers
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
+// fanOutAttributes is the access a user needs on a hub to see it in the hub
+// listing and fan-out responses
+var fanOutAttributes = authorizationv1.ResourceAttributes{
+	Verb:     "list",
+	Group:    clusterv1.GroupName,
+	Resource: "managedclusters",
 }
 
-// SetupServer initializes the HTTP server with all required routes
-func SetupServer(ocmClient *client.OCMClient, ctx context.Context, debugMode bool) *gin.Engine {
-	// Check if debug mode is enabled
-	if debugMode {
-		log.Println("Debug mode enabled")
-		gin.SetMode(gin.DebugMode)
-	} else {
-		gin.SetMode(gin.ReleaseMode)
+// authorizedHubs returns the hubs, in order, that authenticate the bearer token
+// of the request with a TokenReview and allow the user to list ManagedClusters.
+// Users and RBAC differ between hubs, so the token is reviewed by every hub
+// concurrently and hubs that reject it are left out. It writes 401 and returns
+// false if no hub authenticates the token.
+func authorizedHubs(c *gin.Context, hubs []client.Hub, authMode string) ([]client.Hub, bool) {
+	if authMode == config.AuthModeNone {
+		logging.FromContext(c.Request.Context()).Debug("Authentication bypassed (auth mode none)")
+		return hubs, true
+	}
+
+	token, ok := bearerToken(c)
+	if !ok {
+		return nil, false
 	}
 
-	// Set up Gin router
-	r := gin.Default()
-
-	// Configure CORS
-	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
-
-	// API routes
-	api := r.Group("/api")
-	{
-		// Enhanced authorization middleware with TokenReview validation
-		authMiddleware := func(c *gin.Context) {
-			// Check if authentication is bypassed
-			if os.Getenv("DASHBOARD_BYPASS_AUTH") == "true" {
-				log.Println("Authentication bypassed (DASHBOARD_BYPASS_AUTH=true)")
-				c.Next()
+	ctx := c.Request.Context()
+	authenticated := make([]bool, len(hubs))
+	allowed := make([]bool, len(hubs))
+	var wg sync.WaitGroup
+	for i, hub := range hubs {
+		wg.Add(1)
+		go func(i int, hub client.Hub) {
+			defer wg.Done()
+			user, ok := validateToken(token, hub.Client, ctx)
+			if !ok {
 				return
 			}
+			authenticated[i] = true
 
-			authHeader := c.GetHeader("Authorization")
-			if authHeader == "" {
-				log.Println("Authorization header missing")
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-				c.Abort()
+			ok, reason, err := handlers.ReviewAccess(ctx, hub.Client, user, fanOutAttributes)
+			if err != nil {
+				logging.FromContext(ctx).Error("SubjectAccessReview failed", "hub", hub.Name, "error", err)
 				return
 			}
-
-			// Extract token from "Bearer <token>" format
-			tokenParts := strings.Split(authHeader, " ")
-			if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-				log.Println("Invalid authorization header format")
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format. Expected: Bearer <token>"})
-				c.Abort()
+			if !ok {
+				logging.FromContext(ctx).Debug("Hub skipped", "hub", hub.Name, "reason", reason)
 				return
 			}
+			allowed[i] = true
+		}(i, hub)
+	}
+	wg.Wait()
+
+	var result []client.Hub
+	for i, hub := range hubs {
+		if allowed[i] {
+			result = append(result, hub)
+		}
+	}
+	for _, ok := range authenticated {
+		if ok {
+			return result, true
+		}
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+	return nil, false
+}
+
+// hubKey is the gin context key of the hub a request targets
+const hubKey = "hub"
+
+// hubServer holds the client of a hub and the components started for it
+type hubServer struct {
+	client         *client.OCMClient
+	historyStore   *history.Store
+	deployFollower *deploy.Follower
+}
+
+// selectHub returns a middleware selecting the hub named by the :hub path
+// parameter, answering 404 for unknown hubs
+func selectHub(servers map[string]*hubServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hub, ok := servers[c.Param("hub")]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("hub %q not found", c.Param("hub"))})
+			return
+		}
+		c.Set(hubKey, hub)
+		c.Next()
+	}
+}
 
-			token := tokenParts[1]
+// currentHub returns the hub selected for the request
+func currentHub(c *gin.Context) *hubServer {
+	return c.MustGet(hubKey).(*hubServer)
+}
+
+// hubClient returns the client of the hub selected for the request
+func hubClient(c *gin.Context) *client.OCMClient {
+	return currentHub(c).client
+}
 
-			// Validate token using Kubernetes TokenReview API
-			if !validateToken(token, ocmClient, ctx) {
-				log.Printf("Token validation failed for token: %s...", token[:min(len(token), 10)])
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-				c.Abort()
+// requireFeature returns a middleware answering 501 Not Implemented when the hub
+// does not serve the OCM APIs one of the features relies on, e.g. when the addon
+// framework is not installed
+func requireFeature(features ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ocmClient := hubClient(c)
+		if ocmClient == nil {
+			c.Next()
+			return
+		}
+		for _, feature := range features {
+			if available, reason := ocmClient.Capabilities.FeatureAvailable(feature); !available {
+				c.AbortWithStatusJSON(http.StatusNotImplemented, gin.H{
+					"error":   fmt.Sprintf("Feature %s is not available on this hub: %s", feature, reason),
+					"feature": feature,
+				})
 				return
 			}
+		}
+		c.Next()
+	}
+}
 
-			log.Println("Token validation successful")
-			c.Next()
+// featureAvailable reports whether the feature can be used, logging why not
+func featureAvailable(ocmClient *client.OCMClient, feature, component string) bool {
+	available, reason := ocmClient.Capabilities.FeatureAvailable(feature)
+	if !available {
+		slog.Warn(component+" disabled", "feature", feature, "reason", reason)
+	}
+	return available
+}
+
+// requireEnabled returns a middleware answering 403 Forbidden while a feature
+// is disabled in the configuration, read on every request so that reloads apply
+func requireEnabled(configs *config.Manager, feature string, enabled func(*config.Config) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled(configs.Current()) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   fmt.Sprintf("Feature %s is disabled by the server configuration", feature),
+				"feature": feature,
+			})
+			return
 		}
+		c.Next()
+	}
+}
 
-		// Register cluster routes
-		api.GET("/clusters", authMiddleware, func(c *gin.Context) {
-			handlers.GetClusters(c, ocmClient, ctx)
+// corsMiddleware handles cross-origin requests for the configured origins. The
+// handler is built again when a reload changes the origins.
+func corsMiddleware(configs *config.Manager) gin.HandlerFunc {
+	newHandler := func(cfg *config.Config) gin.HandlerFunc {
+		return cors.New(cors.Config{
+			AllowOrigins:     cfg.CORS.AllowedOrigins,
+			AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+			ExposeHeaders:    []string{"Content-Length"},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
 		})
+	}
 
-		api.GET("/clusters/:name", authMiddleware, func(c *gin.Context) {
-			handlers.GetCluster(c, ocmClient, ctx)
-		})
+	var handler atomic.Pointer[gin.HandlerFunc]
+	initial := newHandler(configs.Current())
+	handler.Store(&initial)
+	configs.OnReload(func(cfg *config.Config) {
+		next := newHandler(cfg)
+		handler.Store(&next)
+	})
 
-		// Register cluster addon routes
-		api.GET("/clusters/:name/addons", authMiddleware, func(c *gin.Context) {
-			handlers.GetClusterAddons(c, ocmClient, ctx)
-		})
+	return func(c *gin.Context) {
+		(*handler.Load())(c)
+	}
+}
 
-		api.GET("/clusters/:name/addons/:addonName", authMiddleware, func(c *gin.Context) {
-			handlers.GetClusterAddon(c, ocmClient, ctx)
-		})
+// historyPath returns the placement history database of a hub. Hubs other than
+// the default hub get their own file next to path, suffixed with the hub name.
+func historyPath(path, hubName string, defaultHub bool) string {
+	if defaultHub {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + hubName + ext
+}
 
-		// Register clusterset routes
-		api.GET("/clustersets", authMiddleware, func(c *gin.Context) {
-			handlers.GetClusterSets(c, ocmClient, ctx)
-		})
+// setupPlacementHistory opens the placement history store at path, starts
+// recording decision changes and reports their churn as a metric. The store is
+// closed when ctx is done. It returns nil if history cannot be recorded.
+func setupPlacementHistory(ocmClient *client.OCMClient, hubName, path string, ctx context.Context) *history.Store {
+	if ocmClient == nil || ocmClient.ClusterInformerFactory == nil ||
+		!featureAvailable(ocmClient, client.FeaturePlacements, "Placement history") {
+		return nil
+	}
 
-		api.GET("/clustersets/:name", authMiddleware, func(c *gin.Context) {
-			handlers.GetClusterSet(c, ocmClient, ctx)
-		})
+	store, err := history.Open(path)
+	if err != nil {
+		slog.Warn("Placement history disabled", "error", err)
+		return nil
+	}
 
-		// Register clustersetbinding routes
-		api.GET("/clustersetbindings", authMiddleware, func(c *gin.Context) {
-			handlers.GetAllClusterSetBindings(c, ocmClient, ctx)
-		})
+	if _, err := history.NewRecorder(ocmClient, store); err != nil {
+		slog.Warn("Placement history disabled", "error", err)
+		store.Close()
+		return nil
+	}
 
-		api.GET("/namespaces/:namespace/clustersetbindings", authMiddleware, func(c *gin.Context) {
-			handlers.GetClusterSetBindings(c, ocmClient, ctx)
-		})
+	if err := metrics.RegisterPlacementChurnCollector(hubName, placementChurn(store)); err != nil {
+		slog.Warn("Placement churn metric disabled", "hub", hubName, "error", err)
+	}
 
-		api.GET("/namespaces/:namespace/clustersetbindings/:name", authMiddleware, func(c *gin.Context) {
-			handlers.GetClusterSetBinding(c, ocmClient, ctx)
-		})
+	go func() {
+		<-ctx.Done()
+		store.Close()
+	}()
 
-		// Register manifestwork routes
-		api.GET("/namespaces/:namespace/manifestworks", authMiddleware, func(c *gin.Context) {
-			handlers.GetManifestWorks(c, ocmClient, ctx)
-		})
+	slog.Info("Recording placement history", "path", path)
+	return store
+}
 
-		api.GET("/namespaces/:namespace/manifestworks/:name", authMiddleware, func(c *gin.Context) {
-			handlers.GetManifestWork(c, ocmClient, ctx)
-		})
+// placementChurn reads the churn of every placement recorded in store for the
+// placement churn metric
+func placementChurn(store *history.Store) func() ([]metrics.PlacementChurn, error) {
+	return func() ([]metrics.PlacementChurn, error) {
+		rates, err := store.ChurnRates(metrics.ChurnWindow, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		result := make([]metrics.PlacementChurn, 0, len(rates))
+		for _, rate := range rates {
+			result = append(result, metrics.PlacementChurn{
+				Namespace:      rate.Namespace,
+				Placement:      rate.Name,
+				ChangesPerHour: rate.ChangesPerHour,
+			})
+		}
+		return result, nil
+	}
+}
 
-		// Register placement routes
-		api.GET("/placements", authMiddleware, func(c *gin.Context) {
-			handlers.GetPlacements(c, ocmClient, ctx)
-		})
+// setupDeployFollower starts keeping ManifestWorks deployed in follow mode in
+// sync with their placement decisions. It returns nil if follow mode is unavailable.
+func setupDeployFollower(ocmClient *client.OCMClient, ctx context.Context) *deploy.Follower {
+	if ocmClient == nil || ocmClient.ClusterInformerFactory == nil || ocmClient.WorkClient == nil ||
+		!featureAvailable(ocmClient, client.FeaturePlacements, "Placement deploy follow mode") ||
+		!featureAvailable(ocmClient, client.FeatureManifestWorks, "Placement deploy follow mode") {
+		return nil
+	}
 
-		api.GET("/namespaces/:namespace/placements", authMiddleware, func(c *gin.Context) {
-			handlers.GetPlacementsByNamespace(c, ocmClient, ctx)
-		})
+	follower, err := deploy.NewFollower(ctx, ocmClient, handlers.DashboardNamespace(), func(ctx context.Context, user authv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+		return handlers.ReviewAccess(ctx, ocmClient, user, attributes)
+	})
+	if err != nil {
+		slog.Warn("Placement deploy follow mode disabled", "error", err)
+		return nil
+	}
+	return follower
+}
 
-		api.GET("/namespaces/:namespace/placements/:name", authMiddleware, func(c *gin.Context) {
-			handlers.GetPlacement(c, ocmClient, ctx)
-		})
+// setupFleetMetrics registers the informer sync and fleet gauges and the
+// informers backing them
+func setupFleetMetrics(ocmClient *client.OCMClient) {
+	if ocmClient == nil || ocmClient.ClusterInformerFactory == nil ||
+		ocmClient.AddonInformerFactory == nil || ocmClient.WorkInformerFactory == nil {
+		return
+	}
 
-		api.GET("/namespaces/:namespace/placements/:name/decisions", authMiddleware, func(c *gin.Context) {
-			handlers.GetPlacementDecisions(c, ocmClient, ctx)
-		})
+	// The fleet gauges are only reported once all their informers have synced
+	for _, feature := range []string{client.FeatureClusters, client.FeatureAddons, client.FeatureManifestWorks} {
+		if !featureAvailable(ocmClient, feature, "Fleet metrics") {
+			return
+		}
+	}
 
-		// Register placementdecision routes
-		api.GET("/placementdecisions", authMiddleware, func(c *gin.Context) {
-			handlers.GetAllPlacementDecisions(c, ocmClient, ctx)
-		})
+	if err := metrics.RegisterFleetCollector(ocmClient.ClusterInformerFactory,
+		ocmClient.AddonInformerFactory, ocmClient.WorkInformerFactory); err != nil {
+		slog.Warn("Fleet metrics disabled", "error", err)
+	}
+}
 
-		api.GET("/namespaces/:namespace/placementdecisions", authMiddleware, func(c *gin.Context) {
-			handlers.GetPlacementDecisionsByNamespace(c, ocmClient, ctx)
-		})
+// setupReadinessInformers registers the informers the server relies on and
+// returns their sync state for the readiness probe. Informers of features the
+// hub does not serve would never sync and are left out.
+func setupReadinessInformers(ocmClient *client.OCMClient) map[string]cache.InformerSynced {
+	if ocmClient == nil || ocmClient.ClusterInformerFactory == nil ||
+		ocmClient.AddonInformerFactory == nil || ocmClient.WorkInformerFactory == nil {
+		return nil
+	}
 
-		api.GET("/namespaces/:namespace/placementdecisions/:name", authMiddleware, func(c *gin.Context) {
-			handlers.GetPlacementDecision(c, ocmClient, ctx)
-		})
+	informers := map[string]cache.InformerSynced{
+		"managedclusters": ocmClient.ClusterInformerFactory.Cluster().V1().ManagedClusters().Informer().HasSynced,
+	}
+	if available, _ := ocmClient.Capabilities.FeatureAvailable(client.FeaturePlacements); available {
+		informers["placementdecisions"] = ocmClient.ClusterInformerFactory.Cluster().V1beta1().PlacementDecisions().Informer().HasSynced
+	}
+	if available, _ := ocmClient.Capabilities.FeatureAvailable(client.FeatureAddons); available {
+		informers["managedclusteraddons"] = ocmClient.AddonInformerFactory.Addon().V1alpha1().ManagedClusterAddOns().Informer().HasSynced
+	}
+	if available, _ := ocmClient.Capabilities.FeatureAvailable(client.FeatureManifestWorks); available {
+		informers["manifestworks"] = ocmClient.WorkInformerFactory.Work().V1().ManifestWorks().Informer().HasSynced
+	}
 
-		api.GET("/namespaces/:namespace/placements/:name/placementdecisions", authMiddleware, func(c *gin.Context) {
-			handlers.GetPlacementDecisionsByPlacement(c, ocmClient, ctx)
-		})
+	return informers
+}
 
-		// Register streaming routes
-		api.GET("/stream/clusters", authMiddleware, func(c *gin.Context) {
-			handlers.StreamClusters(c, ocmClient.Interface, ctx)
-		})
+// startInformers starts the informer factories of a hub. It is called once all
+// informers of the hub are registered, as informers requested from a factory
+// after it started are only run by a later Start.
+func startInformers(ocmClient *client.OCMClient, ctx context.Context) {
+	if ocmClient == nil {
+		return
+	}
+	if ocmClient.ClusterInformerFactory != nil {
+		ocmClient.ClusterInformerFactory.Start(ctx.Done())
+	}
+	if ocmClient.AddonInformerFactory != nil {
+		ocmClient.AddonInformerFactory.Start(ctx.Done())
+	}
+	if ocmClient.WorkInformerFactory != nil {
+		ocmClient.WorkInformerFactory.Start(ctx.Done())
+	}
+}
+
+// SetupServer initializes the HTTP server with all required routes for a
+// single hub
+func SetupServer(ocmClient *client.OCMClient, ctx context.Context, debugMode bool) (*gin.Engine, error) {
+	return SetupHubsServer([]client.Hub{{Name: client.DefaultHubName, Source: client.HubSourceKubeconfig, Client: ocmClient}}, ctx, debugMode)
+}
+
+// SetupHubsServer initializes the HTTP server for the given hubs, configured
+// from the environment. The routes of every hub are served under /api/hubs/:hub,
+// the first hub is the default hub also served directly under /api. It fails
+// when the configuration in the environment is invalid, rather than serving
+// with settings other than the configured ones.
+func SetupHubsServer(hubs []client.Hub, ctx context.Context, debugMode bool) (*gin.Engine, error) {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return nil, err
+	}
+	return setupServer(hubs, ctx, debugMode, config.NewManager(cfg, nil)), nil
+}
+
+// SetupConfiguredServer initializes the HTTP server for the given hubs with the
+// configuration of configs, applying reloaded CORS settings and feature toggles
+func SetupConfiguredServer(hubs []client.Hub, ctx context.Context, configs *config.Manager) *gin.Engine {
+	return setupServer(hubs, ctx, configs.Current().Log.Level == "debug", configs)
+}
+
+func setupServer(hubs []client.Hub, ctx context.Context, debugMode bool, configs *config.Manager) *gin.Engine {
+	cfg := configs.Current()
+
+	// Check if debug mode is enabled
+	if debugMode {
+		slog.Debug("Debug mode enabled")
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// Set up Gin router, requests are logged by the structured request logger
+	r := gin.New()
+	r.Use(gin.Recovery(), logging.Middleware(), tracing.Middleware())
+
+	// Record placement decision changes and follow deployments on every hub
+	servers := make(map[string]*hubServer, len(hubs))
+	for i, hub := range hubs {
+		server := &hubServer{client: hub.Client}
+		if cfg.Features.PlacementHistory {
+			server.historyStore = setupPlacementHistory(hub.Client, hub.Name, historyPath(cfg.HistoryDB, hub.Name, i == 0), ctx)
+		}
+		if cfg.Features.DeployFollow {
+			server.deployFollower = setupDeployFollower(hub.Client, ctx)
+		}
+		servers[hub.Name] = server
+	}
+	defaultHub := servers[hubs[0].Name]
+
+	// Fleet metrics and readiness cover the default hub
+	ocmClient := defaultHub.client
+	if cfg.Features.FleetMetrics {
+		setupFleetMetrics(ocmClient)
+	}
+	readinessInformers := setupReadinessInformers(ocmClient)
+
+	// Start the informers of every hub once all of them are registered
+	for _, hub := range hubs {
+		startInformers(hub.Client, ctx)
+	}
+
+	// Record request metrics by route template
+	r.Use(metrics.Middleware())
+
+	// Configure CORS, the allowed origins are applied again on reload
+	r.Use(corsMiddleware(configs))
+
+	// Enhanced authorization middleware with TokenReview validation against the
+	// hub the request targets, traced separately from the handler it guards
+	authMiddleware := func(c *gin.Context) {
+		parent := c.Request.Context()
+		ctx, span := tracing.Tracer().Start(parent, "authMiddleware")
+		c.Request = c.Request.WithContext(ctx)
+		ok := authenticate(c, hubClient(c), configs.Current().Auth.Mode)
+		span.SetAttributes(attribute.Bool("authenticated", ok))
+		span.End()
+		c.Request = c.Request.WithContext(parent)
+
+		if !ok {
+			c.Abort()
+			return
+		}
+		c.Next()
 	}
 
+	// API routes, served for the default hub
+	api := r.Group("/api", func(c *gin.Context) {
+		c.Set(hubKey, defaultHub)
+	})
+	registerHubRoutes(api, authMiddleware, configs)
+
+	// Hub routes, served for the hub named in the path
+	registerHubRoutes(api.Group("/hubs/:hub", selectHub(servers)), authMiddleware, configs)
+
+	// Register configuration route, secrets are redacted
+	api.GET("/config", authMiddleware, func(c *gin.Context) {
+		c.JSON(http.StatusOK, configs.Current().Redacted())
+	})
+
+	// Register hub listing and fan-out routes, covering the hubs that authorize
+	// the user
+	api.GET("/hubs", func(c *gin.Context) {
+		if authorized, ok := authorizedHubs(c, hubs, configs.Current().Auth.Mode); ok {
+			handlers.GetHubs(c, authorized, hubs[0].Name, c.Request.Context())
+		}
+	})
+
+	api.GET("/fleet/clusters", func(c *gin.Context) {
+		if authorized, ok := authorizedHubs(c, hubs, configs.Current().Auth.Mode); ok {
+			handlers.GetFleetClusters(c, authorized, c.Request.Context())
+		}
+	})
+
 	// Add health check endpoint (no authentication required)
 	r.GET("/health", func(c *gin.Context) {
 		// Simple health check - you can add more sophisticated checks here
@@ -228,6 +576,14 @@ func SetupServer(ocmClient *client.OCMClient, ctx context.Context, debugMode boo
 		})
 	})
 
+	// Readiness check against the hub, use ?verbose for per-check output
+	r.GET("/readyz", func(c *gin.Context) {
+		handlers.GetReadiness(c, ocmClient, readinessInformers, c.Request.Context())
+	})
+
+	// Prometheus metrics endpoint
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	// API status endpoint
 	r.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -237,7 +593,10 @@ func SetupServer(ocmClient *client.OCMClient, ctx context.Context, debugMode boo
 			"endpoints": gin.H{
 				"health":  "/health",
 				"healthz": "/healthz",
+				"readyz":  "/readyz",
+				"metrics": "/metrics",
 				"api":     "/api/*",
+				"hubs":    "/api/hubs",
 			},
 		})
 	})
@@ -245,13 +604,261 @@ func SetupServer(ocmClient *client.OCMClient, ctx context.Context, debugMode boo
 	return r
 }
 
-// RunServer starts the HTTP server on the specified port
+// registerHubRoutes registers the routes operating on a single hub, the hub is
+// chosen by the middleware of the group
+func registerHubRoutes(routes *gin.RouterGroup, authMiddleware gin.HandlerFunc, configs *config.Manager) {
+	// Register capabilities route, routes of unavailable features answer 501
+	routes.GET("/capabilities", authMiddleware, func(c *gin.Context) {
+		handlers.GetCapabilities(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register cluster routes
+	routes.GET("/clusters", authMiddleware, requireFeature(client.FeatureClusters), func(c *gin.Context) {
+		handlers.GetClusters(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/clusters/:name", authMiddleware, requireFeature(client.FeatureClusters), func(c *gin.Context) {
+		handlers.GetCluster(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register cluster addon routes
+	routes.GET("/clusters/:name/addons", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.GetClusterAddons(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/clusters/:name/inventory", authMiddleware, requireFeature(client.FeatureClusters), func(c *gin.Context) {
+		handlers.GetClusterInventory(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/clusters/:name/placements", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetClusterPlacements(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/clusters/:name/addons/:addonName", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.GetClusterAddon(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/clusters/:name/addons/:addonName/config", authMiddleware, requireFeature(client.FeatureAddons, client.FeatureAddonConfigs), func(c *gin.Context) {
+		handlers.GetClusterAddonConfig(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register addon catalog routes
+	routes.GET("/addons", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.GetClusterManagementAddons(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/addons/matrix", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.GetAddonMatrix(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/addons/certificates", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.GetAddonCertificates(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/addons/:addonName", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.GetClusterManagementAddon(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.POST("/addons/:addonName/install", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.InstallAddon(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.POST("/addons/:addonName/uninstall", authMiddleware, requireFeature(client.FeatureAddons), func(c *gin.Context) {
+		handlers.UninstallAddon(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register addon template routes
+	routes.GET("/addontemplates", authMiddleware, requireFeature(client.FeatureAddonTemplates), func(c *gin.Context) {
+		handlers.GetAddonTemplates(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/addontemplates/:name", authMiddleware, requireFeature(client.FeatureAddonTemplates), func(c *gin.Context) {
+		handlers.GetAddonTemplate(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register clusterset routes
+	routes.GET("/clustersets", authMiddleware, requireFeature(client.FeatureClusterSets), func(c *gin.Context) {
+		handlers.GetClusterSets(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/clustersets/:name", authMiddleware, requireFeature(client.FeatureClusterSets), func(c *gin.Context) {
+		handlers.GetClusterSet(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register clustersetbinding routes
+	routes.GET("/clustersetbindings", authMiddleware, requireFeature(client.FeatureClusterSets), func(c *gin.Context) {
+		handlers.GetAllClusterSetBindings(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/clustersetbindings", authMiddleware, requireFeature(client.FeatureClusterSets), func(c *gin.Context) {
+		handlers.GetClusterSetBindings(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/clustersetbindings/:name", authMiddleware, requireFeature(client.FeatureClusterSets), func(c *gin.Context) {
+		handlers.GetClusterSetBinding(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register manifestwork routes
+	routes.GET("/manifestworks", authMiddleware, requireFeature(client.FeatureManifestWorks), func(c *gin.Context) {
+		handlers.GetAllManifestWorks(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/manifestworks", authMiddleware, requireFeature(client.FeatureManifestWorks), func(c *gin.Context) {
+		handlers.GetManifestWorks(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/manifestworks/:name", authMiddleware, requireFeature(client.FeatureManifestWorks), func(c *gin.Context) {
+		handlers.GetManifestWork(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/manifestworks/:name/resources/:ordinal", authMiddleware, requireFeature(client.FeatureManifestWorks), func(c *gin.Context) {
+		handlers.GetManifestWorkResource(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/manifestworks/:name/drift", authMiddleware, requireFeature(client.FeatureManifestWorks), func(c *gin.Context) {
+		handlers.GetManifestWorkDrift(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.POST("/namespaces/:namespace/manifestworks/:name/diff", authMiddleware, requireFeature(client.FeatureManifestWorks), func(c *gin.Context) {
+		handlers.DiffManifestWork(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register manifestworkreplicaset routes
+	routes.GET("/manifestworkreplicasets", authMiddleware, requireFeature(client.FeatureManifestWorkReplicaSets), func(c *gin.Context) {
+		handlers.GetManifestWorkReplicaSets(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/manifestworkreplicasets", authMiddleware, requireFeature(client.FeatureManifestWorkReplicaSets), func(c *gin.Context) {
+		handlers.GetManifestWorkReplicaSetsByNamespace(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/manifestworkreplicasets/:name", authMiddleware, requireFeature(client.FeatureManifestWorkReplicaSets), func(c *gin.Context) {
+		handlers.GetManifestWorkReplicaSet(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/manifestworkreplicasets/:name/rollout", authMiddleware, requireFeature(client.FeatureManifestWorkReplicaSets), func(c *gin.Context) {
+		handlers.GetManifestWorkReplicaSetRollout(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register placement routes
+	routes.GET("/placements", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacements(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placements", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacementsByNamespace(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placements/:name", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacement(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.POST("/namespaces/:namespace/placements", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.CreatePlacement(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.PUT("/namespaces/:namespace/placements/:name", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.UpdatePlacement(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.DELETE("/namespaces/:namespace/placements/:name", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.DeletePlacement(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placements/:name/decisions", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacementDecisions(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placements/:name/decisions/merged", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetMergedPlacementDecisions(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placements/:name/history", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacementHistory(c, currentHub(c).historyStore)
+	})
+
+	routes.POST("/namespaces/:namespace/placements/:name/deploy", authMiddleware, requireFeature(client.FeaturePlacements, client.FeatureManifestWorks), func(c *gin.Context) {
+		handlers.DeployToPlacement(c, hubClient(c), currentHub(c).deployFollower, c.Request.Context())
+	})
+
+	// Register placementdecision routes
+	routes.GET("/placementdecisions", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetAllPlacementDecisions(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placementdecisions", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacementDecisionsByNamespace(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placementdecisions/:name", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacementDecision(c, hubClient(c), c.Request.Context())
+	})
+
+	routes.GET("/namespaces/:namespace/placements/:name/placementdecisions", authMiddleware, requireFeature(client.FeaturePlacements), func(c *gin.Context) {
+		handlers.GetPlacementDecisionsByPlacement(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register apply routes
+	// Documents of other unavailable features are reported per document
+	routes.POST("/apply", authMiddleware, requireEnabled(configs, "apply", func(cfg *config.Config) bool {
+		return cfg.Features.Apply
+	}), requireFeature(client.FeatureClusters), func(c *gin.Context) {
+		handlers.ApplyResources(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register dependency graph routes
+	routes.GET("/graph", authMiddleware, requireFeature(client.FeatureClusters, client.FeatureClusterSets,
+		client.FeaturePlacements, client.FeatureManifestWorks, client.FeatureAddons), func(c *gin.Context) {
+		handlers.GetGraph(c, hubClient(c), c.Request.Context())
+	})
+
+	// Register streaming routes
+	routes.GET("/stream/clusters", authMiddleware, requireFeature(client.FeatureClusters), func(c *gin.Context) {
+		defer metrics.TrackSSEConnection("clusters")()
+		handlers.StreamClusters(c, hubClient(c).Interface, c.Request.Context())
+	})
+}
+
+// RunServer starts the HTTP server on the address configured in the environment
 func RunServer(r *gin.Engine) {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	cfg, err := config.Load(nil)
+	if err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		return
+	}
+	if err := RunConfiguredServer(r, cfg, context.Background()); err != nil {
+		slog.Error("Server stopped", "error", err)
+	}
+}
+
+// shutdownTimeout bounds the wait for in-flight requests when the server stops
+const shutdownTimeout = 10 * time.Second
+
+// RunConfiguredServer serves r on the configured listen address, serving HTTPS
+// when a certificate is configured, until ctx is done. The server then stops
+// accepting connections and waits for in-flight requests.
+func RunConfiguredServer(r *gin.Engine, cfg *config.Config, ctx context.Context) error {
+	srv := &http.Server{Addr: cfg.ListenAddress, Handler: r}
+
+	errs := make(chan error, 1)
+	go func() {
+		if cfg.TLS.Enabled() {
+			slog.Info("Starting server", "address", cfg.ListenAddress, "tls", true)
+			errs <- srv.ListenAndServeTLS(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+			return
+		}
+		slog.Info("Starting server", "address", cfg.ListenAddress, "tls", false)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
 	}
 
-	log.Printf("Starting server on port %s", port)
-	r.Run(":" + port)
+	slog.Info("Stopping server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
 }
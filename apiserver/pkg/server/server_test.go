@@ -2,15 +2,31 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"open-cluster-management-io/lab/apiserver/pkg/client"
+	"open-cluster-management-io/lab/apiserver/pkg/config"
+	"open-cluster-management-io/lab/apiserver/pkg/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 )
 
 func TestSetupServer(t *testing.T) {
@@ -41,7 +57,8 @@ func TestSetupServer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			router := SetupServer(tt.client, ctx, tt.debugMode)
+			router, err := SetupServer(tt.client, ctx, tt.debugMode)
+			require.NoError(t, err)
 
 			assert.NotNil(t, router)
 
@@ -60,6 +77,15 @@ func TestSetupServer(t *testing.T) {
 	}
 }
 
+func TestSetupServerInvalidConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv(config.AuthModeEnv, "basic")
+
+	router, err := SetupServer(nil, context.Background(), false)
+	assert.EqualError(t, err, `invalid configuration: auth.mode: unknown mode "basic", expected tokenreview or none`)
+	assert.Nil(t, router)
+}
+
 func TestRunServer(t *testing.T) {
 	tests := []struct {
 		name string
@@ -138,7 +164,8 @@ func TestAuthMiddleware(t *testing.T) {
 			defer os.Unsetenv("DASHBOARD_BYPASS_AUTH")
 
 			ctx := context.Background()
-			router := SetupServer(nil, ctx, false)
+			router, err := SetupServer(nil, ctx, false)
+			require.NoError(t, err)
 
 			req, _ := http.NewRequest("GET", "/api/clusters", nil)
 			if tt.authHeader != "" {
@@ -156,7 +183,8 @@ func TestAuthMiddleware(t *testing.T) {
 func TestCORSConfiguration(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	ctx := context.Background()
-	router := SetupServer(nil, ctx, false)
+	router, err := SetupServer(nil, ctx, false)
+	require.NoError(t, err)
 
 	req, _ := http.NewRequest("OPTIONS", "/api/clusters", nil)
 	req.Header.Set("Origin", "http://localhost:3000")
@@ -172,7 +200,8 @@ func TestCORSConfiguration(t *testing.T) {
 func TestRootRedirect(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	ctx := context.Background()
-	router := SetupServer(nil, ctx, false)
+	router, err := SetupServer(nil, ctx, false)
+	require.NoError(t, err)
 
 	req, _ := http.NewRequest("GET", "/", nil)
 	w := httptest.NewRecorder()
@@ -181,3 +210,325 @@ func TestRootRedirect(t *testing.T) {
 	assert.Equal(t, http.StatusMovedPermanently, w.Code)
 	assert.Equal(t, "/static/index.html", w.Header().Get("Location"))
 }
+
+func TestRequireFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("DASHBOARD_BYPASS_AUTH", "true")
+
+	// The hub serves every OCM resource except the addon framework
+	kubeClient := kubefake.NewSimpleClientset()
+	discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	lists := make(map[string]*metav1.APIResourceList)
+	for _, gvr := range client.Resources {
+		if gvr.Group == "addon.open-cluster-management.io" {
+			continue
+		}
+		list, ok := lists[gvr.GroupVersion().String()]
+		if !ok {
+			list = &metav1.APIResourceList{GroupVersion: gvr.GroupVersion().String()}
+			lists[list.GroupVersion] = list
+			discovery.Resources = append(discovery.Resources, list)
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: gvr.Resource})
+	}
+	ocmClient := &client.OCMClient{KubernetesClient: kubeClient, Capabilities: client.NewCapabilities(discovery)}
+	require.NoError(t, ocmClient.Capabilities.Detect())
+
+	router, err := SetupServer(ocmClient, context.Background(), false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path            string
+		expectedStatus  int
+		expectedFeature string
+	}{
+		{path: "/api/addons", expectedStatus: http.StatusNotImplemented, expectedFeature: client.FeatureAddons},
+		{path: "/api/clusters/cluster1/addons", expectedStatus: http.StatusNotImplemented, expectedFeature: client.FeatureAddons},
+		{path: "/api/addontemplates", expectedStatus: http.StatusNotImplemented, expectedFeature: client.FeatureAddonTemplates},
+		{path: "/api/graph", expectedStatus: http.StatusNotImplemented, expectedFeature: client.FeatureAddons},
+		{path: "/api/capabilities", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedFeature != "" {
+				var body map[string]string
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedFeature, body["feature"])
+				assert.Contains(t, body["error"], "is not installed on the hub")
+			}
+		})
+	}
+}
+
+func TestHubRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("DASHBOARD_BYPASS_AUTH", "true")
+
+	newHub := func(name string, clusterNames ...string) client.Hub {
+		var clusters []runtime.Object
+		for _, clusterName := range clusterNames {
+			clusters = append(clusters, &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}})
+		}
+		kubeClient := kubefake.NewSimpleClientset()
+		kubeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.30.0"}
+		return client.Hub{
+			Name:   name,
+			Source: "context:" + name,
+			Client: &client.OCMClient{KubernetesClient: kubeClient, ClusterClient: clusterfake.NewSimpleClientset(clusters...)},
+		}
+	}
+	router, err := SetupHubsServer([]client.Hub{newHub("east", "cluster1"), newHub("west", "cluster2", "cluster3")}, context.Background(), false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path             string
+		expectedStatus   int
+		expectedClusters []string
+	}{
+		{path: "/api/clusters", expectedStatus: http.StatusOK, expectedClusters: []string{"cluster1"}},
+		{path: "/api/hubs/east/clusters", expectedStatus: http.StatusOK, expectedClusters: []string{"cluster1"}},
+		{path: "/api/hubs/west/clusters", expectedStatus: http.StatusOK, expectedClusters: []string{"cluster2", "cluster3"}},
+		{path: "/api/hubs/north/clusters", expectedStatus: http.StatusNotFound},
+		{path: "/api/hubs", expectedStatus: http.StatusOK},
+		{path: "/api/fleet/clusters", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedClusters != nil {
+				var clusters []models.Cluster
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &clusters))
+				var names []string
+				for _, cluster := range clusters {
+					names = append(names, cluster.Name)
+				}
+				assert.Equal(t, tt.expectedClusters, names)
+			}
+		})
+	}
+
+	t.Run("hubs", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/hubs", nil))
+
+		var hubs []models.Hub
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hubs))
+		require.Len(t, hubs, 2)
+		assert.Equal(t, "east", hubs[0].Name)
+		assert.True(t, hubs[0].Default)
+		assert.Equal(t, models.HubConnected, hubs[1].Status)
+	})
+
+	t.Run("fleet clusters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/fleet/clusters", nil))
+
+		var fleet models.FleetClusters
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fleet))
+		var ids []string
+		for _, cluster := range fleet.Clusters {
+			ids = append(ids, cluster.ID)
+		}
+		assert.Equal(t, []string{"east/cluster1", "west/cluster2", "west/cluster3"}, ids)
+		assert.Empty(t, fleet.Errors)
+	})
+}
+
+func TestFanOutAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Every hub authenticates its own users and decides with its own RBAC
+	newHub := func(name string, authenticated, allowed bool) client.Hub {
+		kubeClient := kubefake.NewSimpleClientset()
+		kubeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.30.0"}
+		kubeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authv1.TokenReview)
+			review.Status = authv1.TokenReviewStatus{Authenticated: authenticated, User: authv1.UserInfo{Username: "alice"}}
+			return true, review, nil
+		})
+		kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}
+			return true, review, nil
+		})
+		cluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+		return client.Hub{
+			Name:   name,
+			Source: "context:" + name,
+			Client: &client.OCMClient{KubernetesClient: kubeClient, ClusterClient: clusterfake.NewSimpleClientset(cluster)},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		hubs           []client.Hub
+		header         string
+		expectedStatus int
+		expectedHubs   []string
+	}{
+		{
+			name:           "hubs that reject the user are skipped",
+			hubs:           []client.Hub{newHub("east", true, false), newHub("west", true, true), newHub("north", false, true)},
+			header:         "Bearer token",
+			expectedStatus: http.StatusOK,
+			expectedHubs:   []string{"west"},
+		},
+		{
+			name:           "token authenticated by no hub",
+			hubs:           []client.Hub{newHub("east", false, true), newHub("west", false, true)},
+			header:         "Bearer token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing token",
+			hubs:           []client.Hub{newHub("east", true, true)},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, err := SetupHubsServer(tt.hubs, context.Background(), false)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/hubs", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var hubs []models.Hub
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hubs))
+			var names []string
+			for _, hub := range hubs {
+				names = append(names, hub.Name)
+				assert.False(t, hub.Default)
+			}
+			assert.Equal(t, tt.expectedHubs, names)
+
+			w = httptest.NewRecorder()
+			req = httptest.NewRequest(http.MethodGet, "/api/fleet/clusters", nil)
+			req.Header.Set("Authorization", tt.header)
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var fleet models.FleetClusters
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fleet))
+			var ids []string
+			for _, cluster := range fleet.Clusters {
+				ids = append(ids, cluster.ID)
+			}
+			assert.Equal(t, []string{"west/cluster1"}, ids)
+		})
+	}
+}
+
+func TestHistoryPath(t *testing.T) {
+	assert.Equal(t, "/data/history.db", historyPath("/data/history.db", "east", true))
+	assert.Equal(t, "/data/history-west.db", historyPath("/data/history.db", "west", false))
+}
+
+func TestConfiguredServer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	writeConfig(`
+auth:
+  mode: none
+cors:
+  allowedOrigins: ["https://dashboard.example.com"]
+features:
+  placementHistory: false
+  deployFollow: false
+  fleetMetrics: false
+  apply: true
+`)
+	args := []string{"--config", path}
+	cfg, err := config.Load(args)
+	require.NoError(t, err)
+	configs := config.NewManager(cfg, args)
+
+	hubs := []client.Hub{{Name: client.DefaultHubName, Source: client.HubSourceMock, Client: client.CreateMockClient()}}
+	router := SetupConfiguredServer(hubs, context.Background(), configs)
+
+	serve := func(method, path, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(""))
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("config", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/config", "")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var served config.Config
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+		assert.Equal(t, config.AuthModeNone, served.Auth.Mode)
+		assert.Equal(t, []string{"https://dashboard.example.com"}, served.CORS.AllowedOrigins)
+	})
+
+	t.Run("cors", func(t *testing.T) {
+		assert.Equal(t, "https://dashboard.example.com",
+			serve(http.MethodOptions, "/api/clusters", "https://dashboard.example.com").Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodOptions, "/api/clusters", "http://localhost:3000").Code)
+	})
+
+	t.Run("apply enabled", func(t *testing.T) {
+		assert.NotEqual(t, http.StatusForbidden, serve(http.MethodPost, "/api/apply", "").Code)
+	})
+
+	// Reloading applies the new origins and feature toggles
+	writeConfig(`
+auth:
+  mode: none
+cors:
+  allowedOrigins: ["http://localhost:3000"]
+features:
+  placementHistory: false
+  deployFollow: false
+  fleetMetrics: false
+  apply: false
+`)
+	require.NoError(t, configs.Reload())
+
+	t.Run("cors reloaded", func(t *testing.T) {
+		assert.Equal(t, "http://localhost:3000",
+			serve(http.MethodOptions, "/api/clusters", "http://localhost:3000").Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("apply disabled", func(t *testing.T) {
+		w := serve(http.MethodPost, "/api/apply", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "Feature apply is disabled by the server configuration")
+	})
+
+	t.Run("mock clusters", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/clusters", "")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var clusters []models.Cluster
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &clusters))
+		assert.Len(t, clusters, 3)
+	})
+}
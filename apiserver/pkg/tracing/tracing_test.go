@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a tracer provider recording every span for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		exporter    string
+		expectError bool
+	}{
+		{exporter: ""},
+		{exporter: ExporterNone},
+		{exporter: ExporterStdout},
+		{exporter: "zipkin", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exporter, func(t *testing.T) {
+			previousProvider := otel.GetTracerProvider()
+			t.Cleanup(func() { otel.SetTracerProvider(previousProvider) })
+			t.Setenv(ExporterEnv, tt.exporter)
+
+			shutdown, err := Setup(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestMiddlewareAndTransport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := recordSpans(t)
+
+	// The hub receives the trace context of the client span
+	var hubTraceParent string
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hubTraceParent = r.Header.Get("traceparent")
+	}))
+	defer hub.Close()
+	hubClient := &http.Client{Transport: WrapTransport(http.DefaultTransport)}
+
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/api/clusters/:name/addons", func(c *gin.Context) {
+		req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet,
+			hub.URL+"/apis/addon.open-cluster-management.io/v1alpha1/namespaces/cluster1/managedclusteraddons", nil)
+		require.NoError(t, err)
+		resp, err := hubClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		c.Status(http.StatusOK)
+	})
+
+	// Continue the trace started by the uiserver proxy
+	parentTraceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/api/clusters/cluster1/addons", nil)
+	req.Header.Set("traceparent", "00-"+parentTraceID+"-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	client, server := spans[0], spans[1]
+
+	assert.Equal(t, "GET /api/clusters/:name/addons", server.Name())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, parentTraceID, server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+
+	assert.Equal(t, "list managedclusteraddons.addon.open-cluster-management.io", client.Name())
+	assert.Equal(t, trace.SpanKindClient, client.SpanKind())
+	assert.Equal(t, server.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Contains(t, hubTraceParent, parentTraceID)
+	assert.Contains(t, hubTraceParent, client.SpanContext().SpanID().String())
+}
+
+func TestTransportWithoutParent(t *testing.T) {
+	recorder := recordSpans(t)
+
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer hub.Close()
+	hubClient := &http.Client{Transport: WrapTransport(http.DefaultTransport)}
+
+	resp, err := hubClient.Get(hub.URL + "/apis/cluster.open-cluster-management.io/v1/managedclusters?watch=true")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Empty(t, recorder.Ended())
+}
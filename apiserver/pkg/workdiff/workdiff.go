@@ -0,0 +1,262 @@
+package workdiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	workv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/yaml"
+
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+// Change types of a manifest
+const (
+	ChangeAdded     = "Added"
+	ChangeRemoved   = "Removed"
+	ChangeModified  = "Modified"
+	ChangeUnchanged = "Unchanged"
+)
+
+// Actions taken on the resource of a removed manifest
+const (
+	RemovalDeleted  = "Deleted"
+	RemovalOrphaned = "Orphaned"
+)
+
+// manifestKey identifies a manifest regardless of its position and version
+type manifestKey struct {
+	group, kind, namespace, name string
+}
+
+// Compare previews updating a ManifestWork to the proposed spec. Manifests are matched by group,
+// kind, namespace and name, and the resources of removed manifests are deleted or orphaned
+// according to the proposed DeleteOption.
+func Compare(current models.ManifestWork, proposed models.ManifestWorkSpec) models.ManifestWorkDiff {
+	result := models.ManifestWorkDiff{
+		Name:                current.Name,
+		Namespace:           current.Namespace,
+		DeleteOptionChanged: !reflect.DeepEqual(current.DeleteOption, proposed.DeleteOption),
+		Manifests:           []models.ManifestDiff{},
+	}
+
+	// Queue the current manifests per key so duplicates are matched in order
+	pending := make(map[manifestKey][]int)
+	for i, manifest := range current.Manifests {
+		key := keyOf(manifest.RawExtension)
+		pending[key] = append(pending[key], i)
+	}
+	matched := make(map[int]bool)
+
+	for _, manifest := range proposed.Workload {
+		key := keyOf(manifest.RawExtension)
+		diff := newManifestDiff(manifest.RawExtension, current.ResourceStatus)
+
+		if queue := pending[key]; len(queue) > 0 {
+			index := queue[0]
+			pending[key] = queue[1:]
+			matched[index] = true
+
+			currentObj := current.Manifests[index].RawExtension
+			compareValues("", currentObj, manifest.RawExtension, &diff.Differences)
+			if len(diff.Differences) == 0 {
+				diff.Change = ChangeUnchanged
+				result.Unchanged++
+			} else {
+				diff.Change = ChangeModified
+				diff.UnifiedDiff = unified(diff, currentObj, manifest.RawExtension)
+				result.Modified++
+			}
+		} else {
+			diff.Change = ChangeAdded
+			diff.UnifiedDiff = unified(diff, nil, manifest.RawExtension)
+			result.Added++
+		}
+
+		result.Manifests = append(result.Manifests, diff)
+	}
+
+	for i, manifest := range current.Manifests {
+		if matched[i] {
+			continue
+		}
+
+		diff := newManifestDiff(manifest.RawExtension, current.ResourceStatus)
+		diff.Change = ChangeRemoved
+		diff.UnifiedDiff = unified(diff, manifest.RawExtension, nil)
+
+		identifier := models.ResourceIdentifier{
+			Group:     diff.Group,
+			Resource:  diff.Resource,
+			Name:      diff.Name,
+			Namespace: diff.Namespace,
+		}
+		diff.RemovalAction = removalAction(proposed.DeleteOption, identifier)
+		if diff.RemovalAction == RemovalOrphaned {
+			result.OrphanedResources = append(result.OrphanedResources, identifier)
+		} else {
+			result.DeletedResources = append(result.DeletedResources, identifier)
+		}
+
+		result.Removed++
+		result.Manifests = append(result.Manifests, diff)
+	}
+
+	result.Changed = result.Added+result.Removed+result.Modified > 0 || result.DeleteOptionChanged
+	return result
+}
+
+func keyOf(obj map[string]interface{}) manifestKey {
+	u := unstructured.Unstructured{Object: obj}
+	return manifestKey{
+		group:     u.GroupVersionKind().Group,
+		kind:      u.GetKind(),
+		namespace: u.GetNamespace(),
+		name:      u.GetName(),
+	}
+}
+
+// newManifestDiff identifies a manifest, taking the resource name from the applied status when
+// the manifest is already known to the work agent
+func newManifestDiff(obj map[string]interface{}, status models.ManifestResourceStatus) models.ManifestDiff {
+	u := unstructured.Unstructured{Object: obj}
+	gvk := u.GroupVersionKind()
+
+	diff := models.ManifestDiff{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+	}
+
+	for _, condition := range status.Manifests {
+		resourceMeta := condition.ResourceMeta
+		if resourceMeta.Group == diff.Group && resourceMeta.Kind == diff.Kind && resourceMeta.Namespace == diff.Namespace && resourceMeta.Name == diff.Name {
+			diff.Resource = resourceMeta.Resource
+			break
+		}
+	}
+	if diff.Resource == "" && gvk.Kind != "" {
+		plural, _ := meta.UnsafeGuessKindToResource(gvk)
+		diff.Resource = plural.Resource
+	}
+
+	return diff
+}
+
+// removalAction returns whether the resource of a removed manifest is deleted or left on the cluster
+func removalAction(option *models.DeleteOption, identifier models.ResourceIdentifier) string {
+	if option == nil {
+		return RemovalDeleted
+	}
+
+	switch workv1.DeletePropagationPolicyType(option.PropagationPolicy) {
+	case workv1.DeletePropagationPolicyTypeOrphan:
+		return RemovalOrphaned
+	case workv1.DeletePropagationPolicyTypeSelectivelyOrphan:
+		if option.SelectivelyOrphan == nil {
+			return RemovalDeleted
+		}
+		for _, rule := range option.SelectivelyOrphan.OrphaningRules {
+			if rule.Group == identifier.Group && rule.Resource == identifier.Resource &&
+				rule.Namespace == identifier.Namespace && rule.Name == identifier.Name {
+				return RemovalOrphaned
+			}
+		}
+	}
+
+	return RemovalDeleted
+}
+
+// compareValues records the differences between two decoded JSON values, recursing into
+// objects and lists
+func compareValues(path string, current, proposed interface{}, diffs *[]models.FieldDiff) {
+	currentMap, currentIsMap := current.(map[string]interface{})
+	proposedMap, proposedIsMap := proposed.(map[string]interface{})
+	if currentIsMap && proposedIsMap {
+		keys := make(map[string]bool, len(currentMap)+len(proposedMap))
+		for key := range currentMap {
+			keys[key] = true
+		}
+		for key := range proposedMap {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			currentValue, inCurrent := currentMap[key]
+			proposedValue, inProposed := proposedMap[key]
+			switch {
+			case !inCurrent:
+				*diffs = append(*diffs, models.FieldDiff{Path: fieldPath(path, key), Operation: "add", Proposed: proposedValue})
+			case !inProposed:
+				*diffs = append(*diffs, models.FieldDiff{Path: fieldPath(path, key), Operation: "remove", Current: currentValue})
+			default:
+				compareValues(fieldPath(path, key), currentValue, proposedValue, diffs)
+			}
+		}
+		return
+	}
+
+	currentList, currentIsList := current.([]interface{})
+	proposedList, proposedIsList := proposed.([]interface{})
+	if currentIsList && proposedIsList {
+		for i := 0; i < len(currentList) || i < len(proposedList); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(currentList):
+				*diffs = append(*diffs, models.FieldDiff{Path: itemPath, Operation: "add", Proposed: proposedList[i]})
+			case i >= len(proposedList):
+				*diffs = append(*diffs, models.FieldDiff{Path: itemPath, Operation: "remove", Current: currentList[i]})
+			default:
+				compareValues(itemPath, currentList[i], proposedList[i], diffs)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(current, proposed) {
+		*diffs = append(*diffs, models.FieldDiff{Path: path, Operation: "replace", Current: current, Proposed: proposed})
+	}
+}
+
+// fieldPath appends a key to a dotted path, quoting keys that contain dots such as annotations
+func fieldPath(path, key string) string {
+	if strings.Contains(key, ".") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// unified renders the manifests as YAML and returns their unified diff
+func unified(diff models.ManifestDiff, current, proposed map[string]interface{}) string {
+	name := diff.Kind + "/" + diff.Name
+	if diff.Namespace != "" {
+		name = diff.Kind + "/" + diff.Namespace + "/" + diff.Name
+	}
+
+	return Unified("current/"+name, "proposed/"+name, toYAML(current), toYAML(proposed))
+}
+
+func toYAML(obj map[string]interface{}) string {
+	if obj == nil {
+		return ""
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
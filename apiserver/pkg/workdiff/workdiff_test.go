@@ -0,0 +1,172 @@
+package workdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"open-cluster-management-io/lab/apiserver/pkg/models"
+)
+
+func deployment(replicas float64, image string) models.Manifest {
+	return models.Manifest{RawExtension: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": image},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func configMap(name string) models.Manifest {
+	return models.Manifest{RawExtension: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"data":       map[string]interface{}{"key": "value"},
+	}}
+}
+
+func TestCompare(t *testing.T) {
+	current := models.ManifestWork{
+		Name:      "web",
+		Namespace: "cluster1",
+		Manifests: []models.Manifest{configMap("config"), deployment(1, "nginx:1.25"), configMap("legacy")},
+	}
+
+	testCases := []struct {
+		name             string
+		proposed         models.ManifestWorkSpec
+		expectedChanges  map[string]string
+		expectedDeleted  []string
+		expectedOrphaned []string
+		expectedChanged  bool
+	}{
+		{
+			name: "Reordered manifests are unchanged",
+			proposed: models.ManifestWorkSpec{
+				Workload: []models.Manifest{configMap("legacy"), deployment(1, "nginx:1.25"), configMap("config")},
+			},
+			expectedChanges: map[string]string{"config": ChangeUnchanged, "web": ChangeUnchanged, "legacy": ChangeUnchanged},
+			expectedChanged: false,
+		},
+		{
+			name: "Modified, added and deleted manifests",
+			proposed: models.ManifestWorkSpec{
+				Workload: []models.Manifest{deployment(3, "nginx:1.27"), configMap("config"), configMap("extra")},
+			},
+			expectedChanges: map[string]string{"web": ChangeModified, "config": ChangeUnchanged, "extra": ChangeAdded, "legacy": ChangeRemoved},
+			expectedDeleted: []string{"legacy"},
+			expectedChanged: true,
+		},
+		{
+			name: "Orphan propagation keeps removed resources",
+			proposed: models.ManifestWorkSpec{
+				Workload:     []models.Manifest{deployment(1, "nginx:1.25")},
+				DeleteOption: &models.DeleteOption{PropagationPolicy: "Orphan"},
+			},
+			expectedChanges:  map[string]string{"web": ChangeUnchanged, "config": ChangeRemoved, "legacy": ChangeRemoved},
+			expectedOrphaned: []string{"config", "legacy"},
+			expectedChanged:  true,
+		},
+		{
+			name: "Selective orphaning matches the orphaning rules",
+			proposed: models.ManifestWorkSpec{
+				Workload: []models.Manifest{deployment(1, "nginx:1.25")},
+				DeleteOption: &models.DeleteOption{
+					PropagationPolicy: "SelectivelyOrphan",
+					SelectivelyOrphan: &models.SelectivelyOrphan{
+						OrphaningRules: []models.OrphaningRule{{Resource: "configmaps", Name: "legacy", Namespace: "default"}},
+					},
+				},
+			},
+			expectedChanges:  map[string]string{"web": ChangeUnchanged, "config": ChangeRemoved, "legacy": ChangeRemoved},
+			expectedDeleted:  []string{"config"},
+			expectedOrphaned: []string{"legacy"},
+			expectedChanged:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Compare(current, tc.proposed)
+
+			changes := make(map[string]string)
+			for _, manifest := range result.Manifests {
+				changes[manifest.Name] = manifest.Change
+				if manifest.Change == ChangeUnchanged {
+					assert.Empty(t, manifest.UnifiedDiff)
+				} else {
+					assert.NotEmpty(t, manifest.UnifiedDiff)
+				}
+			}
+			assert.Equal(t, tc.expectedChanges, changes)
+			assert.Equal(t, tc.expectedChanged, result.Changed)
+
+			var deleted, orphaned []string
+			for _, resource := range result.DeletedResources {
+				assert.Equal(t, "configmaps", resource.Resource)
+				deleted = append(deleted, resource.Name)
+			}
+			for _, resource := range result.OrphanedResources {
+				orphaned = append(orphaned, resource.Name)
+			}
+			assert.Equal(t, tc.expectedDeleted, deleted)
+			assert.Equal(t, tc.expectedOrphaned, orphaned)
+		})
+	}
+}
+
+func TestCompareFieldDifferences(t *testing.T) {
+	current := models.ManifestWork{Manifests: []models.Manifest{deployment(1, "nginx:1.25")}}
+	proposed := deployment(3, "nginx:1.27")
+	proposed.RawExtension["metadata"].(map[string]interface{})["labels"] = map[string]interface{}{"app.kubernetes.io/name": "web"}
+
+	result := Compare(current, models.ManifestWorkSpec{Workload: []models.Manifest{proposed}})
+	require.Len(t, result.Manifests, 1)
+
+	manifest := result.Manifests[0]
+	assert.Equal(t, "apps", manifest.Group)
+	assert.Equal(t, "deployments", manifest.Resource)
+	assert.Equal(t, []models.FieldDiff{
+		{Path: "metadata.labels", Operation: "add", Proposed: map[string]interface{}{"app.kubernetes.io/name": "web"}},
+		{Path: "spec.replicas", Operation: "replace", Current: float64(1), Proposed: float64(3)},
+		{Path: "spec.template.spec.containers[0].image", Operation: "replace", Current: "nginx:1.25", Proposed: "nginx:1.27"},
+	}, manifest.Differences)
+	assert.Contains(t, manifest.UnifiedDiff, "--- current/Deployment/default/web\n+++ proposed/Deployment/default/web\n")
+	assert.Contains(t, manifest.UnifiedDiff, "-  replicas: 1\n+  replicas: 3\n")
+}
+
+func TestUnified(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nL\n"
+
+	expected := `--- from
++++ to
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -9,4 +9,4 @@
+ i
+ j
+ k
+-l
++L
+`
+	assert.Equal(t, expected, Unified("from", "to", from, to))
+	assert.Empty(t, Unified("from", "to", from, from))
+	assert.Equal(t, "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n", Unified("from", "to", "", "a\nb\n"))
+}
@@ -8,12 +8,14 @@ NC='\033[0m' # No Color
 echo -e "${GREEN}===== OCM Dashboard Development Mode =====${NC}"
 
 # Set development environment variables
-export DASHBOARD_DEBUG=true
+export DASHBOARD_LOG_LEVEL=debug
+export DASHBOARD_LOG_FORMAT=text
 export DASHBOARD_BYPASS_AUTH=true
 export DASHBOARD_USE_MOCK=true
 
 echo -e "${YELLOW}Environment variables set:${NC}"
-echo "DASHBOARD_DEBUG=true       - Enable debug logging"
+echo "DASHBOARD_LOG_LEVEL=debug  - Enable debug logging"
+echo "DASHBOARD_LOG_FORMAT=text  - Human-readable log lines"
 echo "DASHBOARD_BYPASS_AUTH=true - Skip authentication checks"
 echo "DASHBOARD_USE_MOCK=true    - Use mock data instead of real clusters"
 echo -e ""
@@ -0,0 +1,73 @@
+import { createHeaders } from './utils';
+
+// Dashboard features backed by OCM APIs the hub may not serve
+export type Feature =
+  | 'clusters'
+  | 'clusterSets'
+  | 'placements'
+  | 'manifestWorks'
+  | 'manifestWorkReplicaSets'
+  | 'addons'
+  | 'addonConfigs'
+  | 'addonTemplates';
+
+export interface FeatureCapability {
+  available: boolean;
+  optional: boolean;
+  message?: string;
+}
+
+export interface ResourceCapability {
+  group: string;
+  resource: string;
+  version?: string;
+  servedVersions: string[];
+  supportedVersions: string[];
+}
+
+export interface Capabilities {
+  detected: boolean;
+  error?: string;
+  features: Record<string, FeatureCapability>;
+  resources: ResourceCapability[];
+}
+
+// Backend API base URL - configurable for production
+// In production, use relative path so requests go through the same host/ingress
+const API_BASE = import.meta.env.VITE_API_BASE || (import.meta.env.PROD ? '' : 'http://localhost:8080');
+
+// Fetch the OCM APIs served by the hub and the features they enable
+export const fetchCapabilities = async (): Promise<Capabilities | null> => {
+  // Use mock data in development mode unless specifically requested to use real API
+  if (import.meta.env.DEV && !import.meta.env.VITE_USE_REAL_API) {
+    return {
+      detected: true,
+      features: {
+        clusters: { available: true, optional: false },
+        clusterSets: { available: true, optional: true },
+        placements: { available: true, optional: true },
+        manifestWorks: { available: true, optional: true },
+        manifestWorkReplicaSets: { available: true, optional: true },
+        addons: { available: true, optional: true },
+        addonConfigs: { available: true, optional: true },
+        addonTemplates: { available: true, optional: true },
+      },
+      resources: [],
+    };
+  }
+
+  try {
+    const response = await fetch(`${API_BASE}/api/capabilities`, {
+      headers: createHeaders()
+    });
+
+    if (!response.ok) {
+      throw new Error(`API error: ${response.status}`);
+    }
+
+    return await response.json();
+  } catch (error) {
+    console.error('Error fetching capabilities:', error);
+    return null;
+  }
+};
@@ -18,6 +18,7 @@ import ClusterAddonsList from './ClusterAddonsList';
 import ClusterManifestWorksList from './ClusterManifestWorksList';
 import { useClusterAddons } from '../hooks/useClusterAddons';
 import { useClusterManifestWorks } from '../hooks/useClusterManifestWorks';
+import { useCapabilities } from '../hooks/useCapabilities';
 
 interface ClusterDetailContentProps {
   cluster: Cluster;
@@ -102,8 +103,12 @@ function a11yProps(index: number) {
 
 export default function ClusterDetailContent({ cluster, compact = false }: ClusterDetailContentProps) {
   const [tabValue, setTabValue] = useState(0);
-  const { addons, loading, error } = useClusterAddons(cluster.name);
-  const { manifestWorks, loading: manifestWorksLoading, error: manifestWorksError } = useClusterManifestWorks(cluster.name);
+  // Tabs of features the hub does not support are hidden and not fetched
+  const { loading: capabilitiesLoading, isAvailable } = useCapabilities();
+  const showAddons = isAvailable('addons');
+  const showManifestWorks = isAvailable('manifestWorks');
+  const { addons, loading, error } = useClusterAddons(!capabilitiesLoading && showAddons ? cluster.name : null);
+  const { manifestWorks, loading: manifestWorksLoading, error: manifestWorksError } = useClusterManifestWorks(!capabilitiesLoading && showManifestWorks ? cluster.name : null);
 
   const handleTabChange = (_event: React.SyntheticEvent, newValue: number) => {
     setTabValue(newValue);
@@ -137,24 +142,31 @@ export default function ClusterDetailContent({ cluster, compact = false }: Clust
                   <span>Overview</span>
                 </Box>
               }
+              value={0}
               {...a11yProps(0)}
             />
-            <Tab
-              label={
-                <Box sx={{ display: 'flex', alignItems: 'center' }}>
-                  <span>Add-ons</span>
-                </Box>
-              }
-              {...a11yProps(1)}
-            />
-            <Tab
-              label={
-                <Box sx={{ display: 'flex', alignItems: 'center' }}>
-                  <span>ManifestWorks</span>
-                </Box>
-              }
-              {...a11yProps(2)}
-            />
+            {showAddons && (
+              <Tab
+                label={
+                  <Box sx={{ display: 'flex', alignItems: 'center' }}>
+                    <span>Add-ons</span>
+                  </Box>
+                }
+                value={1}
+                {...a11yProps(1)}
+              />
+            )}
+            {showManifestWorks && (
+              <Tab
+                label={
+                  <Box sx={{ display: 'flex', alignItems: 'center' }}>
+                    <span>ManifestWorks</span>
+                  </Box>
+                }
+                value={2}
+                {...a11yProps(2)}
+              />
+            )}
           </Tabs>
         </Box>
       )}
@@ -7,6 +7,7 @@ import { fetchPlacements } from "../api/placementService"
 import type { Cluster } from "../api/clusterService"
 import type { ClusterSet } from "../api/clusterSetService"
 import type { Placement } from "../api/placementService"
+import { useCapabilities } from "../hooks/useCapabilities"
 
 export default function OverviewPage() {
   const theme = useTheme()
@@ -17,6 +18,9 @@ export default function OverviewPage() {
   const [clusterSetsLoading, setClusterSetsLoading] = useState(true)
   const [placementsLoading, setPlacementsLoading] = useState(true)
   const [clusterSetCounts, setClusterSetCounts] = useState<Record<string, number>>({})
+  const { loading: capabilitiesLoading, isAvailable } = useCapabilities()
+  const showClusterSets = isAvailable("clusterSets")
+  const showPlacements = isAvailable("placements")
 
   useEffect(() => {
     const loadClusters = async () => {
@@ -32,6 +36,8 @@ export default function OverviewPage() {
   }, [])
 
   useEffect(() => {
+    // Sections of features the hub does not support are hidden
+    if (capabilitiesLoading || !showClusterSets) return
     const loadClusterSets = async () => {
       setClusterSetsLoading(true)
       try {
@@ -42,9 +48,10 @@ export default function OverviewPage() {
       }
     }
     loadClusterSets()
-  }, [])
+  }, [capabilitiesLoading, showClusterSets])
 
   useEffect(() => {
+    if (capabilitiesLoading || !showPlacements) return
     const loadPlacements = async () => {
       setPlacementsLoading(true)
       try {
@@ -55,7 +62,7 @@ export default function OverviewPage() {
       }
     }
     loadPlacements()
-  }, [])
+  }, [capabilitiesLoading, showPlacements])
 
   // Calculate cluster counts for each cluster set
   useEffect(() => {
@@ -215,168 +222,172 @@ export default function OverviewPage() {
         </Grid>
 
         {/* ManagedClusterSets card */}
-        <Grid size={{ xs: 12, md: 4 }}>
-          <Paper
-            sx={{
-              p: 3,
-              height: "100%",
-              borderRadius: 2,
-              display: "flex",
-              flexDirection: "column",
-            }}
-          >
-            <Box sx={{ display: "flex", alignItems: "center", mb: 2 }}>
-              <Box
-                sx={{
-                  display: "flex",
-                  alignItems: "center",
-                  justifyContent: "center",
-                  width: 48,
-                  height: 48,
-                  borderRadius: 2,
-                  bgcolor: alpha(theme.palette.info.main, 0.1),
-                  mr: 2,
-                }}
-              >
-                <LayersIcon sx={{ color: "info.main", fontSize: 24 }} />
-              </Box>
-              <Box>
-                <Typography variant="subtitle2" color="text.secondary">
-                  ManagedClusterSets
-                </Typography>
-                <Typography variant="h3" sx={{ fontWeight: "medium" }}>
-                  {clusterSetsLoading ? "-" : totalClusterSets}
-                </Typography>
+        {showClusterSets && (
+          <Grid size={{ xs: 12, md: 4 }}>
+            <Paper
+              sx={{
+                p: 3,
+                height: "100%",
+                borderRadius: 2,
+                display: "flex",
+                flexDirection: "column",
+              }}
+            >
+              <Box sx={{ display: "flex", alignItems: "center", mb: 2 }}>
+                <Box
+                  sx={{
+                    display: "flex",
+                    alignItems: "center",
+                    justifyContent: "center",
+                    width: 48,
+                    height: 48,
+                    borderRadius: 2,
+                    bgcolor: alpha(theme.palette.info.main, 0.1),
+                    mr: 2,
+                  }}
+                >
+                  <LayersIcon sx={{ color: "info.main", fontSize: 24 }} />
+                </Box>
+                <Box>
+                  <Typography variant="subtitle2" color="text.secondary">
+                    ManagedClusterSets
+                  </Typography>
+                  <Typography variant="h3" sx={{ fontWeight: "medium" }}>
+                    {clusterSetsLoading ? "-" : totalClusterSets}
+                  </Typography>
+                </Box>
               </Box>
-            </Box>
 
-            <Typography variant="body2" color="text.secondary" sx={{ mb: 1 }}>
-              Cluster distribution
-            </Typography>
+              <Typography variant="body2" color="text.secondary" sx={{ mb: 1 }}>
+                Cluster distribution
+              </Typography>
 
-            {!clusterSetsLoading && clusterSets.length > 0 && (
-              <Box sx={{ mt: "auto" }}>
-                {clusterSets.slice(0, 3).map((set) => (
-                  <Box key={set.id} sx={{ display: "flex", justifyContent: "space-between", mb: 1 }}>
-                    <Typography variant="body2" sx={{ overflow: "hidden", textOverflow: "ellipsis" }}>
-                      {set.name}
+              {!clusterSetsLoading && clusterSets.length > 0 && (
+                <Box sx={{ mt: "auto" }}>
+                  {clusterSets.slice(0, 3).map((set) => (
+                    <Box key={set.id} sx={{ display: "flex", justifyContent: "space-between", mb: 1 }}>
+                      <Typography variant="body2" sx={{ overflow: "hidden", textOverflow: "ellipsis" }}>
+                        {set.name}
+                      </Typography>
+                      <Typography variant="body2" fontWeight="medium">
+                        {clusterSetCounts[set.id] || 0} clusters
+                      </Typography>
+                    </Box>
+                  ))}
+                  {clusterSets.length > 3 && (
+                    <Typography variant="body2" color="text.secondary" sx={{ textAlign: "center", mt: 1 }}>
+                      + {clusterSets.length - 3} more sets
                     </Typography>
-                    <Typography variant="body2" fontWeight="medium">
-                      {clusterSetCounts[set.id] || 0} clusters
-                    </Typography>
-                  </Box>
-                ))}
-                {clusterSets.length > 3 && (
-                  <Typography variant="body2" color="text.secondary" sx={{ textAlign: "center", mt: 1 }}>
-                    + {clusterSets.length - 3} more sets
-                  </Typography>
-                )}
-              </Box>
-            )}
+                  )}
+                </Box>
+              )}
 
-            {clusterSetsLoading && (
-              <Box sx={{ display: "flex", justifyContent: "center", mt: 2 }}>
-                <Typography variant="body2" color="text.secondary">
-                  Loading cluster sets...
-                </Typography>
-              </Box>
-            )}
+              {clusterSetsLoading && (
+                <Box sx={{ display: "flex", justifyContent: "center", mt: 2 }}>
+                  <Typography variant="body2" color="text.secondary">
+                    Loading cluster sets...
+                  </Typography>
+                </Box>
+              )}
 
-            {!clusterSetsLoading && clusterSets.length === 0 && (
-              <Box sx={{ display: "flex", justifyContent: "center", mt: 2 }}>
-                <Typography variant="body2" color="text.secondary">
-                  No cluster sets found
-                </Typography>
-              </Box>
-            )}
-          </Paper>
-        </Grid>
+              {!clusterSetsLoading && clusterSets.length === 0 && (
+                <Box sx={{ display: "flex", justifyContent: "center", mt: 2 }}>
+                  <Typography variant="body2" color="text.secondary">
+                    No cluster sets found
+                  </Typography>
+                </Box>
+              )}
+            </Paper>
+          </Grid>
+        )}
 
         {/* Placements card */}
-        <Grid size={{ xs: 12, md: 4 }}>
-          <Paper
-            sx={{
-              p: 3,
-              height: "100%",
-              borderRadius: 2,
-              display: "flex",
-              flexDirection: "column",
-            }}
-          >
-            <Box sx={{ display: "flex", alignItems: "center", mb: 2 }}>
-              <Box
-                sx={{
-                  display: "flex",
-                  alignItems: "center",
-                  justifyContent: "center",
-                  width: 48,
-                  height: 48,
-                  borderRadius: 2,
-                  bgcolor: alpha(theme.palette.success.main, 0.1),
-                  mr: 2,
-                }}
-              >
-                <DeviceHubIcon sx={{ color: "success.main", fontSize: 24 }} />
-              </Box>
-              <Box sx={{ display: "flex", alignItems: "flex-end" }}>
-                <Box>
-                  <Typography variant="subtitle2" color="text.secondary">
-                    All Placements
-                  </Typography>
-                  <Typography variant="h3" sx={{ fontWeight: "medium" }}>
-                    {placementsLoading ? "-" : totalPlacements}
-                  </Typography>
+        {showPlacements && (
+          <Grid size={{ xs: 12, md: 4 }}>
+            <Paper
+              sx={{
+                p: 3,
+                height: "100%",
+                borderRadius: 2,
+                display: "flex",
+                flexDirection: "column",
+              }}
+            >
+              <Box sx={{ display: "flex", alignItems: "center", mb: 2 }}>
+                <Box
+                  sx={{
+                    display: "flex",
+                    alignItems: "center",
+                    justifyContent: "center",
+                    width: 48,
+                    height: 48,
+                    borderRadius: 2,
+                    bgcolor: alpha(theme.palette.success.main, 0.1),
+                    mr: 2,
+                  }}
+                >
+                  <DeviceHubIcon sx={{ color: "success.main", fontSize: 24 }} />
                 </Box>
-                <Box sx={{ ml: 4 }}>
-                  <Typography variant="subtitle2" color="text.secondary">
-                    Successful
-                  </Typography>
-                  <Typography variant="h3" sx={{ fontWeight: "medium", color: "success.main" }}>
-                    {placementsLoading ? "-" : successfulPlacements}
-                  </Typography>
+                <Box sx={{ display: "flex", alignItems: "flex-end" }}>
+                  <Box>
+                    <Typography variant="subtitle2" color="text.secondary">
+                      All Placements
+                    </Typography>
+                    <Typography variant="h3" sx={{ fontWeight: "medium" }}>
+                      {placementsLoading ? "-" : totalPlacements}
+                    </Typography>
+                  </Box>
+                  <Box sx={{ ml: 4 }}>
+                    <Typography variant="subtitle2" color="text.secondary">
+                      Successful
+                    </Typography>
+                    <Typography variant="h3" sx={{ fontWeight: "medium", color: "success.main" }}>
+                      {placementsLoading ? "-" : successfulPlacements}
+                    </Typography>
+                  </Box>
                 </Box>
               </Box>
-            </Box>
 
-            <Typography variant="body2" color="text.secondary" sx={{ mb: 1 }}>
-              Success Rate
-            </Typography>
+              <Typography variant="body2" color="text.secondary" sx={{ mb: 1 }}>
+                Success Rate
+              </Typography>
 
-            <Box sx={{ display: "flex", alignItems: "center", mb: 2 }}>
-              <Box
-                sx={{
-                  height: 8,
-                  width: "100%",
-                  bgcolor: alpha(theme.palette.success.main, 0.1),
-                  borderRadius: 4,
-                  position: "relative",
-                  overflow: "hidden",
-                }}
-              >
+              <Box sx={{ display: "flex", alignItems: "center", mb: 2 }}>
                 <Box
                   sx={{
-                    position: "absolute",
-                    left: 0,
-                    top: 0,
-                    height: "100%",
-                    width: totalPlacements > 0 ? `${(successfulPlacements / totalPlacements) * 100}%` : 0,
-                    bgcolor: "success.main",
+                    height: 8,
+                    width: "100%",
+                    bgcolor: alpha(theme.palette.success.main, 0.1),
                     borderRadius: 4,
+                    position: "relative",
+                    overflow: "hidden",
                   }}
-                />
+                >
+                  <Box
+                    sx={{
+                      position: "absolute",
+                      left: 0,
+                      top: 0,
+                      height: "100%",
+                      width: totalPlacements > 0 ? `${(successfulPlacements / totalPlacements) * 100}%` : 0,
+                      bgcolor: "success.main",
+                      borderRadius: 4,
+                    }}
+                  />
+                </Box>
+                <Typography variant="body2" fontWeight="medium" sx={{ ml: 2, minWidth: 40 }}>
+                  {placementsLoading || totalPlacements === 0 ? '-' : Math.round((successfulPlacements / totalPlacements) * 100)}%
+                </Typography>
               </Box>
-              <Typography variant="body2" fontWeight="medium" sx={{ ml: 2, minWidth: 40 }}>
-                {placementsLoading || totalPlacements === 0 ? '-' : Math.round((successfulPlacements / totalPlacements) * 100)}%
-              </Typography>
-            </Box>
 
-            <Box sx={{ mt: "auto" }}>
-              <Typography variant="body2" color="text.secondary">
-                {placementsLoading ? '-' : totalPlacements - successfulPlacements} placements currently pending or failed
-              </Typography>
-            </Box>
-          </Paper>
-        </Grid>
+              <Box sx={{ mt: "auto" }}>
+                <Typography variant="body2" color="text.secondary">
+                  {placementsLoading ? '-' : totalPlacements - successfulPlacements} placements currently pending or failed
+                </Typography>
+              </Box>
+            </Paper>
+          </Grid>
+        )}
       </Grid>
     </Box>
   )
@@ -11,12 +11,15 @@ import {
   styled
 } from '@mui/material';
 import type { Theme } from '@mui/material';
+import type { ReactNode } from 'react';
 import { useLocation, useNavigate } from 'react-router-dom';
 import ChevronLeftIcon from '@mui/icons-material/ChevronLeft';
 import DashboardIcon from '@mui/icons-material/Dashboard';
 import StorageIcon from '@mui/icons-material/Storage';
 import DeviceHubIcon from '@mui/icons-material/DeviceHub';
 import LayersIcon from '@mui/icons-material/Layers';
+import { useCapabilities } from '../../hooks/useCapabilities';
+import type { Feature } from '../../api/capabilitiesService';
 
 interface DrawerProps {
   open: boolean;
@@ -53,18 +56,19 @@ const DrawerStyled = styled(MuiDrawer, {
   },
 }));
 
-// Navigation items with paths
-const navItems = [
+// Navigation items with paths, items with a feature are hidden when the hub does not support it
+const navItems: { text: string; icon: ReactNode; path: string; feature?: Feature }[] = [
   { text: 'Overview', icon: <DashboardIcon />, path: '/overview' },
-  { text: 'Clusters', icon: <StorageIcon />, path: '/clusters' },
-  { text: 'Clustersets', icon: <LayersIcon />, path: '/clustersets' },
-  { text: 'Placements', icon: <DeviceHubIcon />, path: '/placements' },
+  { text: 'Clusters', icon: <StorageIcon />, path: '/clusters', feature: 'clusters' },
+  { text: 'Clustersets', icon: <LayersIcon />, path: '/clustersets', feature: 'clusterSets' },
+  { text: 'Placements', icon: <DeviceHubIcon />, path: '/placements', feature: 'placements' },
 ];
 
 export default function Drawer({ open, drawerWidth, onDrawerToggle }: DrawerProps) {
   const location = useLocation();
   const navigate = useNavigate();
   const currentPath = location.pathname;
+  const { isAvailable } = useCapabilities();
 
   const handleNavigation = (path: string) => {
     navigate(path);
@@ -105,7 +109,7 @@ export default function Drawer({ open, drawerWidth, onDrawerToggle }: DrawerProp
       </Toolbar>
       <Divider />
       <List component="nav">
-        {navItems.map((item) => {
+        {navItems.filter((item) => !item.feature || isAvailable(item.feature)).map((item) => {
           const isActive = currentPath === item.path ||
             (item.path !== '/overview' && currentPath.startsWith(item.path));
 
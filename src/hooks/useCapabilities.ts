@@ -0,0 +1,45 @@
+import { useState, useEffect } from 'react';
+import { fetchCapabilities, type Capabilities, type Feature } from '../api/capabilitiesService';
+
+// Capabilities only change when OCM APIs are installed on the hub, they are
+// fetched once and shared by every component
+let capabilitiesRequest: Promise<Capabilities | null> | null = null;
+
+/**
+ * Custom hook for checking which dashboard features the hub supports
+ * @returns Object containing the capabilities, loading state, and an isAvailable function.
+ * Features are reported available until the capabilities are known.
+ */
+export const useCapabilities = () => {
+  const [capabilities, setCapabilities] = useState<Capabilities | null>(null);
+  const [loading, setLoading] = useState<boolean>(true);
+
+  useEffect(() => {
+    let cancelled = false;
+
+    if (!capabilitiesRequest) {
+      capabilitiesRequest = fetchCapabilities().then((data) => {
+        // Retry on the next mount if the capabilities could not be fetched
+        if (!data) {
+          capabilitiesRequest = null;
+        }
+        return data;
+      });
+    }
+
+    capabilitiesRequest.then((data) => {
+      if (!cancelled) {
+        setCapabilities(data);
+        setLoading(false);
+      }
+    });
+
+    return () => {
+      cancelled = true;
+    };
+  }, []);
+
+  const isAvailable = (feature: Feature) => capabilities?.features[feature]?.available ?? true;
+
+  return { capabilities, loading, isAvailable };
+};
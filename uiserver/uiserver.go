@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httputil"
@@ -9,9 +10,18 @@ import (
 	"path/filepath"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 func main() {
+	// Configure tracing, trace context is propagated to the API server
+	shutdownTracing, err := setupTracing(context.Background())
+	if err != nil {
+		fmt.Printf("Error setting up tracing: %v\n", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Set Gin to release mode for production
 	gin.SetMode(gin.ReleaseMode)
 
@@ -44,6 +54,13 @@ func main() {
 
 	proxy := httputil.NewSingleHostReverseProxy(apiURL)
 
+	// Trace proxied requests and inject the trace context into the API request
+	proxy.Transport = otelhttp.NewTransport(http.DefaultTransport)
+	proxyHandler := otelhttp.NewHandler(proxy, "proxy",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return "proxy " + r.Method
+		}))
+
 	// Modify proxy to handle headers properly
 	proxy.ModifyResponse = func(resp *http.Response) error {
 		// Allow CORS
@@ -56,7 +73,7 @@ func main() {
 	// API proxy routes - forward all /api/* requests to API container
 	r.Any("/api/*path", func(c *gin.Context) {
 		fmt.Printf("Proxying API request: %s %s\n", c.Request.Method, c.Request.URL.Path)
-		proxy.ServeHTTP(c.Writer, c.Request)
+		proxyHandler.ServeHTTP(c.Writer, c.Request)
 	})
 
 	// Health check endpoint